@@ -15,8 +15,8 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/v1/analyze/reborn": {
-            "get": {
+        "/v1/admin/jwt/rotate": {
+            "post": {
                 "security": [
                     {
                         "JWT": []
@@ -29,23 +29,52 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Analyze V1"
+                    "Admin V1"
+                ],
+                "summary": "Rotate JWT signing key",
+                "parameters": [
+                    {
+                        "description": "Body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.rotateJWTKeyRequest"
+                        }
+                    }
                 ],
-                "summary": "Get reborn targets",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/v1.reborn"
-                            }
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
                         }
                     }
                 }
             }
         },
-        "/v1/basic/search/future/mxf": {
+        "/v1/admin/rate-limit": {
             "get": {
                 "security": [
                     {
@@ -59,18 +88,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Basic V1"
+                    "Admin V1"
                 ],
-                "summary": "Get nearest MXF",
+                "summary": "Get rate limit state of public endpoints",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.Future"
+                            "$ref": "#/definitions/limiter.State"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/resp.Response"
                         }
@@ -78,8 +113,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/basic/stock": {
-            "put": {
+        "/v1/admin/rate-limit/{key}": {
+            "delete": {
                 "security": [
                     {
                         "JWT": []
@@ -92,35 +127,72 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Basic V1"
+                    "Admin V1"
                 ],
-                "summary": "Get stock detail by stock number",
+                "summary": "Clear failures and lockout of an ip or username",
                 "parameters": [
                     {
-                        "description": "Body",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
+                        "type": "string",
+                        "description": "ip or username",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/v1.stockDetailRequest"
+                            "$ref": "#/definitions/resp.Response"
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/roles": {
+            "get": {
+                "security": [
+                    {
+                        "JWT": []
                     }
                 ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin V1"
+                ],
+                "summary": "Get all roles and permissions",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.stockDetailResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.Role"
+                            }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/resp.Response"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/resp.Response"
                         }
@@ -128,7 +200,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/basic/usage/shioaji": {
+        "/v1/admin/setting": {
             "get": {
                 "security": [
                     {
@@ -142,14 +214,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Basic V1"
+                    "Admin V1"
                 ],
-                "summary": "Get shioaji usage",
+                "summary": "Get system setting",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.ShioajiUsage"
+                            "$ref": "#/definitions/entity.SystemSetting"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
                         }
                     },
                     "500": {
@@ -159,10 +243,8 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/v1/fcm/announcement": {
-            "post": {
+            },
+            "put": {
                 "security": [
                     {
                         "JWT": []
@@ -175,9 +257,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "FCM V1"
+                    "Admin V1"
                 ],
-                "summary": "Announce message to all devices",
+                "summary": "Update system setting",
                 "parameters": [
                     {
                         "description": "Body",
@@ -185,7 +267,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/v1.announceRequest"
+                            "$ref": "#/definitions/entity.SystemSetting"
                         }
                     }
                 ],
@@ -199,6 +281,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/resp.Response"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -208,8 +302,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/fcm/push": {
-            "post": {
+        "/v1/admin/user/auth-trade": {
+            "put": {
                 "security": [
                     {
                         "JWT": []
@@ -222,9 +316,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "FCM V1"
+                    "Admin V1"
                 ],
-                "summary": "Push message to devices which has push token",
+                "summary": "Grant or revoke trade authorization of user",
                 "parameters": [
                     {
                         "description": "Body",
@@ -232,7 +326,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/v1.pushRequest"
+                            "$ref": "#/definitions/v1.userAuthTradeRequest"
                         }
                     }
                 ],
@@ -246,8 +340,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/resp.Response"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/resp.Response"
                         }
@@ -255,8 +355,13 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/login": {
-            "post": {
+        "/v1/admin/user/role": {
+            "put": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -264,9 +369,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User V1"
+                    "Admin V1"
                 ],
-                "summary": "Login",
+                "summary": "Assign role to user",
                 "parameters": [
                     {
                         "description": "Body",
@@ -274,21 +379,36 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/auth.LoginBody"
+                            "$ref": "#/definitions/v1.userRoleRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/auth.LoginResponseBody"
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
                         }
                     }
                 }
             }
         },
-        "/v1/logout": {
+        "/v1/admin/users": {
             "get": {
                 "security": [
                     {
@@ -302,17 +422,41 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User V1"
+                    "Admin V1"
                 ],
-                "summary": "Logout",
+                "summary": "Get all users with role",
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.User"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
                     }
                 }
             }
         },
-        "/v1/order/balance": {
+        "/v1/alert/history": {
             "get": {
                 "security": [
                     {
@@ -326,14 +470,43 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Order V1"
+                    "Alert V1"
+                ],
+                "summary": "List triggered alerts of user, newest first",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "limit, max 200",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get all trade balance",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.tradeBalance"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.AlertHistory"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
                         }
                     },
                     "500": {
@@ -345,7 +518,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/order/future/all": {
+        "/v1/alert/rules": {
             "get": {
                 "security": [
                     {
@@ -359,14 +532,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Order V1"
+                    "Alert V1"
                 ],
-                "summary": "Get all order",
+                "summary": "List alert rules of user",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.allOrder"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.AlertRule"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
                         }
                     },
                     "500": {
@@ -376,9 +564,7 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/v1/order/future/{tradeday}": {
+            },
             "post": {
                 "security": [
                     {
@@ -392,23 +578,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Order V1"
+                    "Alert V1"
                 ],
-                "summary": "Get all future order by trade day",
+                "summary": "Create alert rule, cooldown is in seconds",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "tradeday",
-                        "name": "tradeday",
-                        "in": "path",
-                        "required": true
+                        "description": "Body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.NewAlertRule"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.futureOrders"
+                            "$ref": "#/definitions/entity.AlertRule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
                         }
                     },
                     "500": {
@@ -420,8 +620,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/refresh": {
-            "get": {
+        "/v1/alert/rules/{id}": {
+            "put": {
                 "security": [
                     {
                         "JWT": []
@@ -434,14 +634,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User V1"
+                    "Alert V1"
+                ],
+                "summary": "Update alert rule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.NewAlertRule"
+                        }
+                    }
                 ],
-                "summary": "Refresh token",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/auth.LoginResponseBody"
+                            "$ref": "#/definitions/entity.AlertRule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
                         }
                     },
                     "401": {
@@ -449,12 +673,22 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/resp.Response"
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
                     }
                 }
-            }
-        },
-        "/v1/stream/snapshot": {
-            "put": {
+            },
+            "delete": {
                 "security": [
                     {
                         "JWT": []
@@ -467,29 +701,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Stream V1"
+                    "Alert V1"
                 ],
-                "summary": "Get snapshots",
+                "summary": "Delete alert rule, history is kept",
                 "parameters": [
                     {
-                        "description": "Body",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/v1.snapshotRequest"
-                        }
+                        "type": "integer",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/entity.StockSnapShot"
-                            }
-                        }
+                        "description": "OK"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -497,6 +723,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/resp.Response"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -506,7 +744,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/targets": {
+        "/v1/analyze/indicators/{code}": {
             "get": {
                 "security": [
                     {
@@ -520,24 +758,54 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Targets V1"
+                    "Analyze V1"
+                ],
+                "summary": "Get technical indicators of stock, day series end at date, minute series are bars of date",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "day or minute, default day",
+                        "name": "interval",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "2006-01-02, default today",
+                        "name": "date",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get targets",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/entity.StockTarget"
-                            }
+                            "$ref": "#/definitions/entity.Indicators"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
                         }
                     }
                 }
             }
         },
-        "/v1/trade/cancel": {
-            "put": {
+        "/v1/analyze/patterns": {
+            "get": {
                 "security": [
                     {
                         "JWT": []
@@ -550,26 +818,51 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Trade V1"
+                    "Analyze V1"
                 ],
-                "summary": "Cancel order",
+                "summary": "List pattern scanner hits on date",
                 "parameters": [
                     {
-                        "description": "Body",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/v1.cancelRequest"
-                        }
+                        "type": "string",
+                        "description": "2006-01-02",
+                        "name": "date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ma_cross, gap, breakout, volume_spike, inside_bar or quater_ma_reborn",
+                        "name": "pattern",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "limit, max 200",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "offset",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.PatternHitList"
+                        }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/resp.Response"
                         }
@@ -577,7 +870,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/trade/inventory/stock": {
+        "/v1/basic/search/future/mxf": {
             "get": {
                 "security": [
                     {
@@ -591,21 +884,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Trade V1"
+                    "Basic V1"
                 ],
-                "summary": "Get latest inventory stock",
+                "summary": "Get nearest MXF",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/entity.InventoryStock"
-                            }
+                            "$ref": "#/definitions/entity.Future"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/resp.Response"
                         }
@@ -613,7 +903,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/trade/stock/buy/odd": {
+        "/v1/basic/stock": {
             "put": {
                 "security": [
                     {
@@ -627,9 +917,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Trade V1"
+                    "Basic V1"
                 ],
-                "summary": "Buy odd stock",
+                "summary": "Get stock detail by stock number",
                 "parameters": [
                     {
                         "description": "Body",
@@ -637,7 +927,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/v1.oddStockRequest"
+                            "$ref": "#/definitions/v1.stockDetailRequest"
                         }
                     }
                 ],
@@ -645,11 +935,17 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.tradeResponse"
+                            "$ref": "#/definitions/v1.stockDetailResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/resp.Response"
                         }
@@ -657,8 +953,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/trade/stock/sell/odd": {
-            "put": {
+        "/v1/basic/usage/shioaji": {
+            "get": {
                 "security": [
                     {
                         "JWT": []
@@ -671,29 +967,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Trade V1"
-                ],
-                "summary": "Sell odd stock",
-                "parameters": [
-                    {
-                        "description": "Body",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/v1.oddStockRequest"
-                        }
-                    }
+                    "Basic V1"
                 ],
+                "summary": "Get shioaji usage",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.tradeResponse"
+                            "$ref": "#/definitions/entity.ShioajiUsage"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/resp.Response"
                         }
@@ -701,8 +986,13 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/user": {
+        "/v1/fcm/announcement": {
             "post": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -710,9 +1000,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User V1"
+                    "FCM V1"
                 ],
-                "summary": "New user",
+                "summary": "Announce message to all devices",
                 "parameters": [
                     {
                         "description": "Body",
@@ -720,7 +1010,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/entity.NewUser"
+                            "$ref": "#/definitions/v1.announceRequest"
                         }
                     }
                 ],
@@ -734,6 +1024,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/resp.Response"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -743,8 +1039,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/user/auth": {
-            "put": {
+        "/v1/fcm/push": {
+            "post": {
                 "security": [
                     {
                         "JWT": []
@@ -757,15 +1053,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User V1"
+                    "FCM V1"
                 ],
-                "summary": "Update auth trade user",
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    },
-                    "401": {
-                        "description": "Unauthorized",
+                "summary": "Push message to devices which has push token",
+                "parameters": [
+                    {
+                        "description": "Body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.pushRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/resp.Response"
                         }
@@ -773,7 +1092,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/user/info": {
+        "/v1/history/close/{code}": {
             "get": {
                 "security": [
                     {
@@ -787,15 +1106,47 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User V1"
+                    "History V1"
+                ],
+                "summary": "Get raw and corporate action adjusted closes of stock between dates, up to 366 days",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "2006-01-02",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "2006-01-02",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    }
                 ],
-                "summary": "Get user info",
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.StockCloseSeries"
+                        }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/resp.Response"
                         }
@@ -803,7 +1154,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/user/push-token": {
+        "/v1/history/continuous/{code}": {
             "get": {
                 "security": [
                     {
@@ -817,15 +1168,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User V1"
+                    "History V1"
                 ],
-                "summary": "Get user push token status",
+                "summary": "Get real contracts and roll adjustments of a virtual future code like MXF1! between dates, up to 366 days",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "token",
-                        "name": "token",
-                        "in": "header",
+                        "description": "code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "2006-01-02",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "2006-01-02",
+                        "name": "to",
+                        "in": "query",
                         "required": true
                     }
                 ],
@@ -833,7 +1198,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.pushTokenStatusResponse"
+                            "$ref": "#/definitions/entity.ContinuousContract"
                         }
                     },
                     "400": {
@@ -842,8 +1207,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/resp.Response"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/resp.Response"
                         }
@@ -855,13 +1220,79 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/v1/history/kbar/{code}": {
+            "get": {
                 "security": [
                     {
                         "JWT": []
                     }
                 ],
+                "description": "Virtual future codes like MXF1! are stitched from the front contracts and adjusted to the latest one",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "History V1"
+                ],
+                "summary": "Get kbars of stock or future between dates, minute resolutions up to 31 days, 1d up to 366 days",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "2006-01-02",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "2006-01-02",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "1m, 5m, 15m, 60m or 1d, default 1m",
+                        "name": "resolution",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.KbarRange"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/login": {
+            "post": {
                 "consumes": [
                     "application/json"
                 ],
@@ -871,7 +1302,7 @@ const docTemplate = `{
                 "tags": [
                     "User V1"
                 ],
-                "summary": "Update user push token",
+                "summary": "Login",
                 "parameters": [
                     {
                         "description": "Body",
@@ -879,13 +1310,73 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/v1.userPushTokenRequest"
+                            "$ref": "#/definitions/auth.LoginBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/auth.LoginResponseBody"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
                         }
                     }
+                }
+            }
+        },
+        "/v1/logout": {
+            "get": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
                 ],
+                "tags": [
+                    "User V1"
+                ],
+                "summary": "Logout",
                 "responses": {
                     "200": {
                         "description": "OK"
+                    }
+                }
+            }
+        },
+        "/v1/notify/preference": {
+            "get": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notify V1"
+                ],
+                "summary": "Get channels of each notify event, events not set use default channels",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.NotifyPreference"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -899,6 +1390,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/resp.Response"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -907,7 +1404,7 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
+            "put": {
                 "security": [
                     {
                         "JWT": []
@@ -920,13 +1417,42 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User V1"
+                    "Notify V1"
+                ],
+                "summary": "Replace notify preference, event keys are order_fill, alert, daily_report",
+                "parameters": [
+                    {
+                        "description": "Body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.NotifyPreference"
+                        }
+                    }
                 ],
-                "summary": "Clear all push token",
                 "responses": {
                     "200": {
                         "description": "OK"
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -936,8 +1462,13 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/user/verify/{user}/{code}": {
-            "post": {
+        "/v1/option/chain/{category}/{month}": {
+            "get": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -945,49 +1476,3142 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User V1"
+                    "Option V1"
                 ],
-                "summary": "Verify email",
+                "summary": "Get option chain by strike with live quotes, implied volatility and greeks",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "user",
-                        "name": "user",
+                        "description": "category, e.g. TXO",
+                        "name": "category",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "code",
-                        "name": "code",
+                        "description": "delivery month, e.g. 202611",
+                        "name": "month",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "future code, default nearest future of category",
+                        "name": "underlying",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/entity.OptionChain"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/resp.Response"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "auth.LoginBody": {
+        },
+        "/v1/option/chains": {
+            "get": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Option V1"
+                ],
+                "summary": "List option chains by category and delivery month",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.OptionChainInfo"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/option/snapshot/{code}": {
+            "get": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Option V1"
+                ],
+                "summary": "Get live quote of option with implied volatility and greeks",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.OptionQuote"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/order/balance": {
+            "get": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Order V1"
+                ],
+                "summary": "Get all trade balance",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.tradeBalance"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/order/future/all": {
+            "get": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Order V1"
+                ],
+                "summary": "Get all order",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.allOrder"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/order/future/{tradeday}": {
+            "post": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Order V1"
+                ],
+                "summary": "Get all future order by trade day",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "tradeday",
+                        "name": "tradeday",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.futureOrders"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/refresh": {
+            "get": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User V1"
+                ],
+                "summary": "Refresh token",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/auth.LoginResponseBody"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User V1"
+                ],
+                "summary": "Refresh token by session refresh token",
+                "parameters": [
+                    {
+                        "description": "Body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/auth.RefreshBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/auth.LoginResponseBody"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/report/daily/{date}": {
+            "get": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Report V1"
+                ],
+                "summary": "Get end-of-day report by date",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "date, 2006-01-02",
+                        "name": "date",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.DailyReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/stream/snapshot": {
+            "put": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stream V1"
+                ],
+                "summary": "Get snapshots",
+                "parameters": [
+                    {
+                        "description": "Body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.snapshotRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.StockSnapShot"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/targets": {
+            "get": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Targets V1"
+                ],
+                "summary": "Get targets",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.StockTarget"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/targets/rule": {
+            "get": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Targets V1"
+                ],
+                "summary": "Get target selection rule",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.TargetRule"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Targets V1"
+                ],
+                "summary": "Update target selection rule, applies from the next target search",
+                "parameters": [
+                    {
+                        "description": "Body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.TargetRule"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/trade/cancel": {
+            "put": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trade V1"
+                ],
+                "summary": "Cancel order",
+                "parameters": [
+                    {
+                        "description": "Body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.cancelRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/trade/future/expiry": {
+            "get": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trade V1"
+                ],
+                "summary": "Get open future positions close to delivery and the contract to roll to",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.FutureExpiryWarning"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/trade/inventory/stock": {
+            "get": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trade V1"
+                ],
+                "summary": "Get latest inventory stock",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.InventoryStock"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/trade/option/buy": {
+            "put": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trade V1"
+                ],
+                "summary": "Buy option",
+                "parameters": [
+                    {
+                        "description": "Body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.optionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.tradeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/trade/option/sell": {
+            "put": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trade V1"
+                ],
+                "summary": "Sell option",
+                "parameters": [
+                    {
+                        "description": "Body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.optionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.tradeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/trade/stock/buy/odd": {
+            "put": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trade V1"
+                ],
+                "summary": "Buy odd stock",
+                "parameters": [
+                    {
+                        "description": "Body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.oddStockRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.tradeResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/trade/stock/sell/odd": {
+            "put": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trade V1"
+                ],
+                "summary": "Sell odd stock",
+                "parameters": [
+                    {
+                        "description": "Body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.oddStockRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.tradeResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User V1"
+                ],
+                "summary": "New user",
+                "parameters": [
+                    {
+                        "description": "Body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.NewUser"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/api-keys": {
+            "get": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User V1"
+                ],
+                "summary": "List api keys of user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.APIKey"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User V1"
+                ],
+                "summary": "Create api key, the key is only shown once",
+                "parameters": [
+                    {
+                        "description": "Body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.NewAPIKey"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.CreatedAPIKey"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/api-keys/{key_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User V1"
+                ],
+                "summary": "Revoke api key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "key_id",
+                        "name": "key_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/auth": {
+            "put": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User V1"
+                ],
+                "summary": "Update auth trade user",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/info": {
+            "get": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User V1"
+                ],
+                "summary": "Get user info",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/password": {
+            "put": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User V1"
+                ],
+                "summary": "Change password",
+                "parameters": [
+                    {
+                        "description": "Body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.changePasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/password/forgot": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User V1"
+                ],
+                "summary": "Send password reset code to email",
+                "parameters": [
+                    {
+                        "description": "Body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.forgotPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/password/reset": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User V1"
+                ],
+                "summary": "Reset password by code",
+                "parameters": [
+                    {
+                        "description": "Body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.resetPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/push-token": {
+            "get": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User V1"
+                ],
+                "summary": "Get user push token status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token",
+                        "name": "token",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.pushTokenStatusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User V1"
+                ],
+                "summary": "Update user push token",
+                "parameters": [
+                    {
+                        "description": "Body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.userPushTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User V1"
+                ],
+                "summary": "Clear all push token",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User V1"
+                ],
+                "summary": "List active sessions of user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.Session"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/sessions/{session_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User V1"
+                ],
+                "summary": "Revoke a session of user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "session_id",
+                        "name": "session_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/totp": {
+            "get": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User V1"
+                ],
+                "summary": "Get totp status of user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.TOTPStatus"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/totp/confirm": {
+            "post": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User V1"
+                ],
+                "summary": "Confirm totp enrollment by code",
+                "parameters": [
+                    {
+                        "description": "Body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.totpCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/totp/disable": {
+            "post": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User V1"
+                ],
+                "summary": "Disable totp by code or backup code",
+                "parameters": [
+                    {
+                        "description": "Body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.totpCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/totp/enroll": {
+            "post": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User V1"
+                ],
+                "summary": "Enroll totp, secret and backup codes are only shown once",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.TOTPEnrollment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/verify/resend": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User V1"
+                ],
+                "summary": "Resend verification email",
+                "parameters": [
+                    {
+                        "description": "Body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.resendVerificationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/verify/{user}/{code}": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User V1"
+                ],
+                "summary": "Verify email",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "user",
+                        "name": "user",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/watchlist": {
+            "get": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Watchlist V1"
+                ],
+                "summary": "List watchlists of user with items",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.Watchlist"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Watchlist V1"
+                ],
+                "summary": "Create watchlist, the first one is default",
+                "parameters": [
+                    {
+                        "description": "Body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.NewWatchlist"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Watchlist"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/watchlist/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Watchlist V1"
+                ],
+                "summary": "Update watchlist name, sort or make it default",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.NewWatchlist"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Watchlist"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Watchlist V1"
+                ],
+                "summary": "Delete watchlist with its items",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/watchlist/{id}/items": {
+            "put": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Watchlist V1"
+                ],
+                "summary": "Add stock to watchlist or update its note and sort",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.NewWatchlistItem"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Watchlist"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/watchlist/{id}/items/{code}": {
+            "delete": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Watchlist V1"
+                ],
+                "summary": "Remove stock from watchlist",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Watchlist"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/watchlist/{id}/sort": {
+            "put": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Watchlist V1"
+                ],
+                "summary": "Reorder items, codes must contain every stock of the watchlist once",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.watchlistSortRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Watchlist"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/resp.Response"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "auth.LoginBody": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "totp": {
+                    "description": "TOTP is required if the user enabled totp, backup code is also accepted",
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "auth.LoginResponseBody": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "expire": {
+                    "type": "string"
+                },
+                "refresh_token": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "auth.RefreshBody": {
+            "type": "object",
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.APIKey": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "string"
+                },
+                "expire_at": {
+                    "type": "string"
+                },
+                "ip_allowlist": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "key_id": {
+                    "type": "string"
+                },
+                "last_used": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "revoked": {
+                    "type": "boolean"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.APIKeyScope"
+                    }
+                }
+            }
+        },
+        "entity.APIKeyScope": {
+            "type": "string",
+            "enum": [
+                "market",
+                "order",
+                "admin"
+            ],
+            "x-enum-varnames": [
+                "APIKeyScopeMarket",
+                "APIKeyScopeOrder",
+                "APIKeyScopeAdmin"
+            ]
+        },
+        "entity.AccountBalance": {
+            "type": "object",
+            "properties": {
+                "available_margin": {
+                    "type": "number"
+                },
+                "balance": {
+                    "type": "number"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "risk_indicator": {
+                    "type": "number"
+                },
+                "today_margin": {
+                    "type": "number"
+                },
+                "yesterday_margin": {
+                    "type": "number"
+                }
+            }
+        },
+        "entity.AlertHistory": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "rule_id": {
+                    "type": "integer"
+                },
+                "threshold": {
+                    "type": "number"
+                },
+                "triggered": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/entity.AlertType"
+                },
+                "value": {
+                    "type": "number"
+                }
+            }
+        },
+        "entity.AlertRule": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "cooldown": {
+                    "type": "integer"
+                },
+                "created": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_triggered": {
+                    "type": "string"
+                },
+                "threshold": {
+                    "type": "number"
+                },
+                "type": {
+                    "$ref": "#/definitions/entity.AlertType"
+                }
+            }
+        },
+        "entity.AlertType": {
+            "type": "string",
+            "enum": [
+                "price_cross",
+                "pct_change",
+                "volume_ratio",
+                "future_basis"
+            ],
+            "x-enum-varnames": [
+                "AlertTypePriceCross",
+                "AlertTypePctChange",
+                "AlertTypeVolumeRatio",
+                "AlertTypeFutureBasis"
+            ]
+        },
+        "entity.BollingerSeries": {
+            "type": "object",
+            "properties": {
+                "lower": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "middle": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "upper": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                }
+            }
+        },
+        "entity.ContinuousAdjustment": {
+            "type": "string",
+            "enum": [
+                "none",
+                "difference",
+                "ratio"
+            ],
+            "x-enum-varnames": [
+                "ContinuousAdjustmentNone",
+                "ContinuousAdjustmentDifference",
+                "ContinuousAdjustmentRatio"
+            ]
+        },
+        "entity.ContinuousContract": {
+            "type": "object",
+            "properties": {
+                "adjustment": {
+                    "$ref": "#/definitions/entity.ContinuousAdjustment"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "nth": {
+                    "type": "integer"
+                },
+                "segments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.ContinuousSegment"
+                    }
+                }
+            }
+        },
+        "entity.ContinuousSegment": {
+            "type": "object",
+            "properties": {
+                "adjustment": {
+                    "type": "number"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "delivery_date": {
+                    "type": "string"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.CorporateAction": {
+            "type": "object",
+            "properties": {
+                "cash": {
+                    "type": "number"
+                },
+                "ex_date": {
+                    "type": "string"
+                },
+                "ratio": {
+                    "type": "number"
+                },
+                "stock_num": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/entity.CorporateActionType"
+                }
+            }
+        },
+        "entity.CorporateActionType": {
+            "type": "string",
+            "enum": [
+                "cash_dividend",
+                "stock_dividend",
+                "split",
+                "capital_reduction"
+            ],
+            "x-enum-varnames": [
+                "CorporateActionCashDividend",
+                "CorporateActionStockDividend",
+                "CorporateActionSplit",
+                "CorporateActionCapitalReduction"
+            ]
+        },
+        "entity.CreatedAPIKey": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "string"
+                },
+                "expire_at": {
+                    "type": "string"
+                },
+                "ip_allowlist": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "key": {
+                    "type": "string"
+                },
+                "key_id": {
+                    "type": "string"
+                },
+                "last_used": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "revoked": {
+                    "type": "boolean"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.APIKeyScope"
+                    }
+                }
+            }
+        },
+        "entity.DailyReport": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "$ref": "#/definitions/entity.AccountBalance"
+                },
+                "created": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "future": {
+                    "$ref": "#/definitions/entity.DailyReportTrade"
+                },
+                "future_positions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.DailyReportPosition"
+                    }
+                },
+                "net_pnl": {
+                    "type": "integer"
+                },
+                "stock": {
+                    "$ref": "#/definitions/entity.DailyReportTrade"
+                },
+                "stock_positions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.DailyReportPosition"
+                    }
+                }
+            }
+        },
+        "entity.DailyReportPosition": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "direction": {
+                    "type": "string"
+                },
+                "last_price": {
+                    "type": "number"
+                },
+                "pnl": {
+                    "type": "number"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.DailyReportTrade": {
+            "type": "object",
+            "properties": {
+                "discount": {
+                    "type": "integer"
+                },
+                "fee": {
+                    "type": "integer"
+                },
+                "forward": {
+                    "type": "integer"
+                },
+                "net": {
+                    "type": "integer"
+                },
+                "order_count": {
+                    "type": "integer"
+                },
+                "reverse": {
+                    "type": "integer"
+                },
+                "tax": {
+                    "type": "integer"
+                },
+                "trade_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.Future": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "delivery_date": {
+                    "type": "string"
+                },
+                "delivery_month": {
+                    "type": "string"
+                },
+                "limit_down": {
+                    "type": "number"
+                },
+                "limit_up": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "reference": {
+                    "type": "number"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "underlying_kind": {
+                    "type": "string"
+                },
+                "unit": {
+                    "type": "integer"
+                },
+                "update_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.FutureExpiryWarning": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "delivery_date": {
+                    "type": "string"
+                },
+                "direction": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "roll_error": {
+                    "type": "string"
+                },
+                "roll_to": {
+                    "type": "string"
+                },
+                "rolled": {
+                    "type": "boolean"
+                },
+                "trade_days_left": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.FutureOrder": {
+            "type": "object",
+            "properties": {
+                "base_order": {
+                    "$ref": "#/definitions/entity.OrderDetail"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "future": {
+                    "$ref": "#/definitions/entity.Future"
+                },
+                "position": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.FutureTradeBalance": {
+            "type": "object",
+            "properties": {
+                "forward": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "reverse": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "trade_count": {
+                    "type": "integer"
+                },
+                "trade_day": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.HistoryCloseBase": {
+            "type": "object",
+            "properties": {
+                "close": {
+                    "type": "number"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.HistoryKbarBase": {
+            "type": "object",
+            "properties": {
+                "close": {
+                    "type": "number"
+                },
+                "high": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kbar_time": {
+                    "type": "string"
+                },
+                "low": {
+                    "type": "number"
+                },
+                "open": {
+                    "type": "number"
+                },
+                "volume": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.IndicatorInterval": {
+            "type": "string",
+            "enum": [
+                "day",
+                "minute"
+            ],
+            "x-enum-varnames": [
+                "IndicatorIntervalDay",
+                "IndicatorIntervalMinute"
+            ]
+        },
+        "entity.Indicators": {
+            "type": "object",
+            "properties": {
+                "atr": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "bollinger": {
+                    "$ref": "#/definitions/entity.BollingerSeries"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "created": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "interval": {
+                    "$ref": "#/definitions/entity.IndicatorInterval"
+                },
+                "kd": {
+                    "$ref": "#/definitions/entity.KDSeries"
+                },
+                "macd": {
+                    "$ref": "#/definitions/entity.MACDSeries"
+                },
+                "rsi": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "time": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "entity.InventoryStock": {
+            "type": "object",
+            "properties": {
+                "AvgPrice": {
+                    "type": "number"
+                },
+                "Date": {
+                    "type": "string"
+                },
+                "Lot": {
+                    "type": "integer"
+                },
+                "Position": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.PositionStock"
+                    }
+                },
+                "Share": {
+                    "type": "integer"
+                },
+                "StockNum": {
+                    "type": "string"
+                },
+                "UUID": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.KDSeries": {
+            "type": "object",
+            "properties": {
+                "d": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "k": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                }
+            }
+        },
+        "entity.KbarRange": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "continuous": {
+                    "description": "Continuous is set when code is a virtual code like MXF1!.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.ContinuousContract"
+                        }
+                    ]
+                },
+                "from": {
+                    "type": "string"
+                },
+                "kbars": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.HistoryKbarBase"
+                    }
+                },
+                "resolution": {
+                    "$ref": "#/definitions/entity.KbarResolution"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.KbarResolution": {
+            "type": "string",
+            "enum": [
+                "1m",
+                "5m",
+                "15m",
+                "60m",
+                "1d"
+            ],
+            "x-enum-varnames": [
+                "KbarResolution1m",
+                "KbarResolution5m",
+                "KbarResolution15m",
+                "KbarResolution60m",
+                "KbarResolution1d"
+            ]
+        },
+        "entity.MACDSeries": {
+            "type": "object",
+            "properties": {
+                "hist": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "macd": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "signal": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                }
+            }
+        },
+        "entity.NewAPIKey": {
+            "type": "object",
+            "properties": {
+                "expire_at": {
+                    "type": "string"
+                },
+                "ip_allowlist": {
+                    "description": "IPAllowlist accepts ip or cidr, empty means any ip",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.APIKeyScope"
+                    }
+                }
+            }
+        },
+        "entity.NewAlertRule": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "cooldown": {
+                    "type": "integer"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "threshold": {
+                    "type": "number"
+                },
+                "type": {
+                    "$ref": "#/definitions/entity.AlertType"
+                }
+            }
+        },
+        "entity.NewUser": {
             "type": "object",
             "properties": {
+                "email": {
+                    "type": "string"
+                },
                 "password": {
                     "type": "string"
                 },
@@ -996,21 +4620,78 @@ const docTemplate = `{
                 }
             }
         },
-        "auth.LoginResponseBody": {
+        "entity.NewWatchlist": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "default": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "sort": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.NewWatchlistItem": {
             "type": "object",
+            "required": [
+                "code"
+            ],
             "properties": {
                 "code": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "sort": {
                     "type": "integer"
+                }
+            }
+        },
+        "entity.NotifyChannel": {
+            "type": "string",
+            "enum": [
+                "fcm",
+                "email",
+                "slack",
+                "webhook"
+            ],
+            "x-enum-varnames": [
+                "NotifyChannelFCM",
+                "NotifyChannelEmail",
+                "NotifyChannelSlack",
+                "NotifyChannelWebhook"
+            ]
+        },
+        "entity.NotifyPreference": {
+            "type": "object",
+            "properties": {
+                "channels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "$ref": "#/definitions/entity.NotifyChannel"
+                        }
+                    }
                 },
-                "expire": {
+                "slack_webhook": {
+                    "description": "SlackWebhook must be a https://hooks.slack.com incoming webhook",
                     "type": "string"
                 },
-                "token": {
+                "webhook_url": {
+                    "description": "WebhookURL must resolve to public addresses only",
                     "type": "string"
                 }
             }
         },
-        "entity.Future": {
+        "entity.Option": {
             "type": "object",
             "properties": {
                 "category": {
@@ -1034,9 +4715,15 @@ const docTemplate = `{
                 "name": {
                     "type": "string"
                 },
+                "option_right": {
+                    "type": "string"
+                },
                 "reference": {
                     "type": "number"
                 },
+                "strike_price": {
+                    "type": "number"
+                },
                 "symbol": {
                     "type": "string"
                 },
@@ -1051,86 +4738,169 @@ const docTemplate = `{
                 }
             }
         },
-        "entity.FutureOrder": {
+        "entity.OptionChain": {
             "type": "object",
             "properties": {
-                "base_order": {
-                    "$ref": "#/definitions/entity.OrderDetail"
+                "category": {
+                    "type": "string"
                 },
-                "code": {
+                "delivery_date": {
                     "type": "string"
                 },
-                "future": {
-                    "$ref": "#/definitions/entity.Future"
+                "delivery_month": {
+                    "type": "string"
                 },
-                "position": {
+                "strike_count": {
                     "type": "integer"
+                },
+                "strikes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.OptionStrike"
+                    }
+                },
+                "underlying": {
+                    "$ref": "#/definitions/entity.OptionUnderlying"
+                },
+                "underlying_kind": {
+                    "type": "string"
+                },
+                "updated": {
+                    "type": "string"
                 }
             }
         },
-        "entity.FutureTradeBalance": {
+        "entity.OptionChainInfo": {
             "type": "object",
             "properties": {
-                "forward": {
-                    "type": "integer"
+                "category": {
+                    "type": "string"
                 },
-                "id": {
-                    "type": "integer"
+                "delivery_date": {
+                    "type": "string"
                 },
-                "reverse": {
-                    "type": "integer"
+                "delivery_month": {
+                    "type": "string"
                 },
-                "total": {
+                "strike_count": {
                     "type": "integer"
                 },
-                "trade_count": {
-                    "type": "integer"
+                "underlying_kind": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.OptionGreeks": {
+            "type": "object",
+            "properties": {
+                "delta": {
+                    "type": "number"
                 },
-                "trade_day": {
+                "gamma": {
+                    "type": "number"
+                },
+                "rho": {
+                    "type": "number"
+                },
+                "theta": {
+                    "type": "number"
+                },
+                "vega": {
+                    "type": "number"
+                }
+            }
+        },
+        "entity.OptionOrder": {
+            "type": "object",
+            "properties": {
+                "base_order": {
+                    "$ref": "#/definitions/entity.OrderDetail"
+                },
+                "code": {
                     "type": "string"
+                },
+                "option": {
+                    "$ref": "#/definitions/entity.Option"
+                },
+                "quantity": {
+                    "type": "integer"
                 }
             }
         },
-        "entity.InventoryStock": {
+        "entity.OptionQuote": {
             "type": "object",
             "properties": {
-                "AvgPrice": {
+                "buy_price": {
                     "type": "number"
                 },
-                "Date": {
+                "change_price": {
+                    "type": "number"
+                },
+                "close": {
+                    "type": "number"
+                },
+                "code": {
                     "type": "string"
                 },
-                "Lot": {
+                "greeks": {
+                    "$ref": "#/definitions/entity.OptionGreeks"
+                },
+                "iv": {
+                    "type": "number"
+                },
+                "sell_price": {
+                    "type": "number"
+                },
+                "total_volume": {
                     "type": "integer"
+                }
+            }
+        },
+        "entity.OptionStrike": {
+            "type": "object",
+            "properties": {
+                "call": {
+                    "$ref": "#/definitions/entity.OptionQuote"
                 },
-                "Position": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/entity.PositionStock"
-                    }
+                "put": {
+                    "$ref": "#/definitions/entity.OptionQuote"
                 },
-                "Share": {
+                "strike_price": {
+                    "type": "number"
+                }
+            }
+        },
+        "entity.OptionTradeBalance": {
+            "type": "object",
+            "properties": {
+                "forward": {
                     "type": "integer"
                 },
-                "StockNum": {
-                    "type": "string"
+                "id": {
+                    "type": "integer"
                 },
-                "UUID": {
+                "reverse": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "trade_count": {
+                    "type": "integer"
+                },
+                "trade_day": {
                     "type": "string"
                 }
             }
         },
-        "entity.NewUser": {
+        "entity.OptionUnderlying": {
             "type": "object",
             "properties": {
-                "email": {
-                    "type": "string"
-                },
-                "password": {
+                "code": {
                     "type": "string"
                 },
-                "username": {
-                    "type": "string"
+                "price": {
+                    "type": "number"
                 }
             }
         },
@@ -1190,6 +4960,78 @@ const docTemplate = `{
                 "StatusPartFilled"
             ]
         },
+        "entity.PatternHit": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "pattern": {
+                    "$ref": "#/definitions/entity.PatternName"
+                },
+                "stock": {
+                    "$ref": "#/definitions/entity.Stock"
+                },
+                "stock_num": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "number"
+                }
+            }
+        },
+        "entity.PatternHitList": {
+            "type": "object",
+            "properties": {
+                "hits": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.PatternHit"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.PatternName": {
+            "type": "string",
+            "enum": [
+                "ma_cross",
+                "gap",
+                "breakout",
+                "volume_spike",
+                "inside_bar",
+                "quater_ma_reborn"
+            ],
+            "x-enum-varnames": [
+                "PatternMACross",
+                "PatternGap",
+                "PatternBreakout",
+                "PatternVolumeSpike",
+                "PatternInsideBar",
+                "PatternQuaterMA"
+            ]
+        },
+        "entity.Permission": {
+            "type": "string",
+            "enum": [
+                "view",
+                "trade",
+                "admin"
+            ],
+            "x-enum-varnames": [
+                "PermissionView",
+                "PermissionTrade",
+                "PermissionAdmin"
+            ]
+        },
         "entity.PositionStock": {
             "type": "object",
             "properties": {
@@ -1225,6 +5067,49 @@ const docTemplate = `{
                 }
             }
         },
+        "entity.Role": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Permission"
+                    }
+                }
+            }
+        },
+        "entity.Session": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "string"
+                },
+                "current": {
+                    "type": "boolean"
+                },
+                "expire_at": {
+                    "type": "string"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "last_seen": {
+                    "type": "string"
+                },
+                "session_id": {
+                    "type": "string"
+                },
+                "user_agent": {
+                    "type": "string"
+                }
+            }
+        },
         "entity.ShioajiUsage": {
             "type": "object",
             "properties": {
@@ -1254,13 +5139,45 @@ const docTemplate = `{
                 "last_close": {
                     "type": "number"
                 },
-                "name": {
+                "name": {
+                    "type": "string"
+                },
+                "number": {
+                    "type": "string"
+                },
+                "update_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.StockCloseSeries": {
+            "type": "object",
+            "properties": {
+                "actions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.CorporateAction"
+                    }
+                },
+                "adjusted": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.HistoryCloseBase"
+                    }
+                },
+                "from": {
                     "type": "string"
                 },
-                "number": {
+                "raw": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.HistoryCloseBase"
+                    }
+                },
+                "stock_num": {
                     "type": "string"
                 },
-                "update_date": {
+                "to": {
                     "type": "string"
                 }
             }
@@ -1350,6 +5267,9 @@ const docTemplate = `{
                 "rank": {
                     "type": "integer"
                 },
+                "reason": {
+                    "type": "string"
+                },
                 "stock": {
                     "$ref": "#/definitions/entity.Stock"
                 },
@@ -1393,6 +5313,195 @@ const docTemplate = `{
                 }
             }
         },
+        "entity.SystemSetting": {
+            "type": "object",
+            "properties": {
+                "totp_required_for_trade": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "entity.TOTPEnrollment": {
+            "type": "object",
+            "properties": {
+                "backup_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "secret": {
+                    "type": "string"
+                },
+                "uri": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.TOTPStatus": {
+            "type": "object",
+            "properties": {
+                "backup_codes_left": {
+                    "type": "integer"
+                },
+                "enabled": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "entity.TargetRule": {
+            "type": "object",
+            "properties": {
+                "day_trade_only": {
+                    "type": "boolean"
+                },
+                "exclude_category": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "exclude_exchange": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "include_category": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "include_exchange": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "max_count": {
+                    "type": "integer"
+                },
+                "min_volume": {
+                    "type": "integer"
+                },
+                "min_volume_ratio": {
+                    "type": "number"
+                },
+                "price_high": {
+                    "type": "number"
+                },
+                "price_low": {
+                    "type": "number"
+                },
+                "real_time_rank": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.User": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.Watchlist": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "string"
+                },
+                "default": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.WatchlistItem"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "sort": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.WatchlistItem": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "created": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "sort": {
+                    "type": "integer"
+                }
+            }
+        },
+        "limiter.State": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "ip": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ratelimit.State"
+                    }
+                },
+                "username": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ratelimit.State"
+                    }
+                }
+            }
+        },
+        "ratelimit.State": {
+            "type": "object",
+            "properties": {
+                "failures": {
+                    "type": "integer"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "last_seen": {
+                    "type": "string"
+                },
+                "locked_until": {
+                    "type": "string"
+                },
+                "rejected": {
+                    "type": "integer"
+                },
+                "tokens": {
+                    "type": "number"
+                }
+            }
+        },
         "resp.Response": {
             "type": "object",
             "properties": {
@@ -1413,6 +5522,12 @@ const docTemplate = `{
                         "$ref": "#/definitions/entity.FutureOrder"
                     }
                 },
+                "option": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.OptionOrder"
+                    }
+                },
                 "stock": {
                     "type": "array",
                     "items": {
@@ -1437,6 +5552,25 @@ const docTemplate = `{
                 }
             }
         },
+        "v1.changePasswordRequest": {
+            "type": "object",
+            "properties": {
+                "new_password": {
+                    "type": "string"
+                },
+                "old_password": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.forgotPasswordRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
         "v1.futureOrders": {
             "type": "object",
             "properties": {
@@ -1462,6 +5596,20 @@ const docTemplate = `{
                 }
             }
         },
+        "v1.optionRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "quantity": {
+                    "type": "integer"
+                }
+            }
+        },
         "v1.pushRequest": {
             "type": "object",
             "properties": {
@@ -1481,17 +5629,34 @@ const docTemplate = `{
                 }
             }
         },
-        "v1.reborn": {
+        "v1.resendVerificationRequest": {
             "type": "object",
             "properties": {
-                "date": {
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.resetPasswordRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
                     "type": "string"
                 },
-                "stocks": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/entity.Stock"
-                    }
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.rotateJWTKeyRequest": {
+            "type": "object",
+            "properties": {
+                "grace_minutes": {
+                    "description": "GraceMinutes is how long tokens signed by old keys are still accepted",
+                    "type": "integer"
                 }
             }
         },
@@ -1528,6 +5693,14 @@ const docTemplate = `{
                 }
             }
         },
+        "v1.totpCodeRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                }
+            }
+        },
         "v1.tradeBalance": {
             "type": "object",
             "properties": {
@@ -1537,6 +5710,12 @@ const docTemplate = `{
                         "$ref": "#/definitions/entity.FutureTradeBalance"
                     }
                 },
+                "option": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.OptionTradeBalance"
+                    }
+                },
                 "stock": {
                     "type": "array",
                     "items": {
@@ -1556,19 +5735,64 @@ const docTemplate = `{
                 }
             }
         },
+        "v1.userAuthTradeRequest": {
+            "type": "object",
+            "properties": {
+                "auth_trade": {
+                    "type": "boolean"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
         "v1.userPushTokenRequest": {
             "type": "object",
             "properties": {
                 "enabled": {
                     "type": "boolean"
                 },
+                "order_push": {
+                    "description": "OrderPush receives order updates even if the user is not authorized to trade",
+                    "type": "boolean"
+                },
                 "push_token": {
                     "type": "string"
                 }
             }
+        },
+        "v1.userRoleRequest": {
+            "type": "object",
+            "properties": {
+                "role": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.watchlistSortRequest": {
+            "type": "object",
+            "required": [
+                "codes"
+            ],
+            "properties": {
+                "codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
         }
     },
     "securityDefinitions": {
+        "APIKey": {
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
+        },
         "JWT": {
             "type": "apiKey",
             "name": "Authorization",
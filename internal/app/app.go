@@ -29,6 +29,7 @@ func Run() {
 
 	// Do not adjust the order
 	fcm := usecase.NewFCM()
+	notify := usecase.NewNotify()
 	basic := usecase.NewBasic()
 	trade := usecase.NewTrade()
 	analyze := usecase.NewAnalyze()
@@ -36,6 +37,10 @@ func Run() {
 	realTime := usecase.NewRealTime()
 	system := usecase.NewSystem()
 	target := usecase.NewTarget()
+	alert := usecase.NewAlert()
+	report := usecase.NewReport()
+	watchlist := usecase.NewWatchlist()
+	option := usecase.NewOption()
 
 	// HTTP Server
 	r := router.NewRouter(system).
@@ -43,10 +48,15 @@ func Run() {
 		AddV1BasicRoutes(basic).
 		AddV1OrderRoutes(trade).
 		AddV1TradeRoutes(trade).
-		AddV1RealTimeRoutes(basic, realTime, history).
-		AddV1AnalyzeRoutes(analyze).
+		AddV1RealTimeRoutes(basic, realTime, history, watchlist).
+		AddV1AnalyzeRoutes(analyze, history).
 		AddV1HistoryRoutes(history).
-		AddV1TargetRoutes(target)
+		AddV1TargetRoutes(target).
+		AddV1AlertRoutes(alert).
+		AddV1NotifyRoutes(notify).
+		AddV1ReportRoutes(report).
+		AddV1WatchlistRoutes(watchlist).
+		AddV1OptionRoutes(option)
 
 	if e := httpserver.New(
 		r.GetHandler(),
@@ -33,6 +33,14 @@ type Config struct {
 	Quota        Quota        `json:"Quota" yaml:"Quota"`
 	AnalyzeStock AnalyzeStock `json:"AnalyzeStock" yaml:"AnalyzeStock"`
 	TradeFuture  TradeFuture  `json:"TradeFuture" yaml:"TradeFuture"`
+	RateLimit    RateLimit    `json:"RateLimit" yaml:"RateLimit"`
+	Notify       Notify       `json:"Notify" yaml:"Notify"`
+	TargetRule   TargetRule   `json:"TargetRule" yaml:"TargetRule"`
+	Indicator    Indicator    `json:"Indicator" yaml:"Indicator"`
+	Scanner      Scanner      `json:"Scanner" yaml:"Scanner"`
+	Option       Option       `json:"Option" yaml:"Option"`
+	Continuous   Continuous   `json:"Continuous" yaml:"Continuous"`
+	Partition    Partition    `json:"Partition" yaml:"Partition"`
 
 	dbPool      *postgres.Postgres `json:"-" yaml:"-"`
 	sinopacPool *grpc.ClientConn   `json:"-" yaml:"-"`
@@ -98,7 +106,8 @@ func (c *Config) readEnv() {
 			PoolMax: c.vp.GetInt("DB_POOL_MAX"),
 		},
 		Server: Server{
-			HTTP: c.vp.GetString("HTTP"),
+			HTTP:           c.vp.GetString("HTTP"),
+			TrustedProxies: c.vp.GetStringSlice("HTTP_TRUSTED_PROXIES"),
 		},
 		Sinopac: Sinopac{
 			URL: c.vp.GetString("SINOPAC_URL"),
@@ -109,6 +118,9 @@ func (c *Config) readEnv() {
 			Username: c.vp.GetString("SMTP_USERNAME"),
 			Password: c.vp.GetString("SMTP_PASSWORD"),
 		},
+		Auth: Auth{
+			AdminUser: c.vp.GetString("AUTH_ADMIN_USER"),
+		},
 	}
 	c.EnvConfig = env
 }
@@ -5,6 +5,7 @@ type EnvConfig struct {
 	Server   Server   `json:"Server" yaml:"Server"`
 	Sinopac  Sinopac  `json:"Sinopac" yaml:"Sinopac"`
 	SMTP     SMTP     `json:"SMTP" yaml:"SMTP"`
+	Auth     Auth     `json:"Auth" yaml:"Auth"`
 }
 
 type Database struct {
@@ -15,6 +16,9 @@ type Database struct {
 
 type Server struct {
 	HTTP string `json:"HTTP" yaml:"HTTP"`
+
+	// TrustedProxies are the proxy ips or cidrs whose X-Forwarded-For is trusted, empty to use the remote address only
+	TrustedProxies []string `json:"TrustedProxies" yaml:"TrustedProxies"`
 }
 
 // Sinopac -.
@@ -28,3 +32,7 @@ type SMTP struct {
 	Username string `json:"Username" yaml:"Username"`
 	Password string `json:"Password" yaml:"Password"`
 }
+
+type Auth struct {
+	AdminUser string `json:"AdminUser" yaml:"AdminUser"`
+}
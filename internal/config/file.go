@@ -5,6 +5,9 @@ type History struct {
 	HistoryClosePeriod int64 `json:"HistoryClosePeriod" yaml:"HistoryClosePeriod"`
 	HistoryTickPeriod  int64 `json:"HistoryTickPeriod" yaml:"HistoryTickPeriod"`
 	HistoryKbarPeriod  int64 `json:"HistoryKbarPeriod" yaml:"HistoryKbarPeriod"`
+
+	// CorporateActionFilePath is a csv of stock_num,ex_date,type,cash,ratio imported on start, empty to skip
+	CorporateActionFilePath string `json:"CorporateActionFilePath" yaml:"CorporateActionFilePath"`
 }
 
 // Quota -.
@@ -12,6 +15,7 @@ type Quota struct {
 	StockTradeQuota  int64   `json:"StockTradeQuota" yaml:"StockTradeQuota"`
 	StockFeeDiscount float64 `json:"StockFeeDiscount" yaml:"StockFeeDiscount"`
 	FutureTradeFee   int64   `json:"FutureTradeFee" yaml:"FutureTradeFee"`
+	OptionTradeFee   int64   `json:"OptionTradeFee" yaml:"OptionTradeFee"`
 }
 
 // PriceLimit -.
@@ -20,6 +24,20 @@ type PriceLimit struct {
 	High float64 `json:"High" yaml:"High"`
 }
 
+// TargetRule is the default rule to select stock targets, it can be overridden by api.
+type TargetRule struct {
+	PriceLimit      PriceLimit `json:"PriceLimit" yaml:"PriceLimit"`
+	MinVolume       int64      `json:"MinVolume" yaml:"MinVolume"`
+	IncludeExchange []string   `json:"IncludeExchange" yaml:"IncludeExchange"`
+	ExcludeExchange []string   `json:"ExcludeExchange" yaml:"ExcludeExchange"`
+	IncludeCategory []string   `json:"IncludeCategory" yaml:"IncludeCategory"`
+	ExcludeCategory []string   `json:"ExcludeCategory" yaml:"ExcludeCategory"`
+	DayTradeOnly    bool       `json:"DayTradeOnly" yaml:"DayTradeOnly"`
+	MinVolumeRatio  float64    `json:"MinVolumeRatio" yaml:"MinVolumeRatio"`
+	MaxCount        int        `json:"MaxCount" yaml:"MaxCount"`
+	RealTimeRank    int        `json:"RealTimeRank" yaml:"RealTimeRank"`
+}
+
 // AnalyzeStock -.
 type AnalyzeStock struct {
 	MaxHoldTime          float64 `json:"MaxHoldTime" yaml:"MaxHoldTime"`
@@ -33,6 +51,50 @@ type AnalyzeStock struct {
 	MAPeriod             int64   `json:"MAPeriod" yaml:"MAPeriod"`
 }
 
+// Option -.
+type Option struct {
+	RiskFreeRate float64 `json:"RiskFreeRate" yaml:"RiskFreeRate"`
+}
+
+// Continuous -.
+type Continuous struct {
+	RollDaysBefore     int      `json:"RollDaysBefore" yaml:"RollDaysBefore"`
+	WarnDaysBefore     int      `json:"WarnDaysBefore" yaml:"WarnDaysBefore"`
+	Adjustment         string   `json:"Adjustment" yaml:"Adjustment"`
+	AutoRoll           bool     `json:"AutoRoll" yaml:"AutoRoll"`
+	AutoRollCategories []string `json:"AutoRollCategories" yaml:"AutoRollCategories"`
+}
+
+// Scanner -.
+type Scanner struct {
+	Enabled          []string `json:"Enabled" yaml:"Enabled"`
+	DayCount         int64    `json:"DayCount" yaml:"DayCount"`
+	ScanDays         int64    `json:"ScanDays" yaml:"ScanDays"`
+	MAShort          int      `json:"MAShort" yaml:"MAShort"`
+	MALong           int      `json:"MALong" yaml:"MALong"`
+	GapRatio         float64  `json:"GapRatio" yaml:"GapRatio"`
+	BreakoutDays     int      `json:"BreakoutDays" yaml:"BreakoutDays"`
+	VolumeSpikeDays  int      `json:"VolumeSpikeDays" yaml:"VolumeSpikeDays"`
+	VolumeSpikeRatio float64  `json:"VolumeSpikeRatio" yaml:"VolumeSpikeRatio"`
+	QuaterMADays     int      `json:"QuaterMADays" yaml:"QuaterMADays"`
+}
+
+// Indicator -.
+type Indicator struct {
+	Enabled         []string `json:"Enabled" yaml:"Enabled"`
+	DayCount        int64    `json:"DayCount" yaml:"DayCount"`
+	RSIPeriod       int      `json:"RSIPeriod" yaml:"RSIPeriod"`
+	MACDFast        int      `json:"MACDFast" yaml:"MACDFast"`
+	MACDSlow        int      `json:"MACDSlow" yaml:"MACDSlow"`
+	MACDSignal      int      `json:"MACDSignal" yaml:"MACDSignal"`
+	BollingerPeriod int      `json:"BollingerPeriod" yaml:"BollingerPeriod"`
+	BollingerDev    float64  `json:"BollingerDev" yaml:"BollingerDev"`
+	ATRPeriod       int      `json:"ATRPeriod" yaml:"ATRPeriod"`
+	KDPeriod        int      `json:"KDPeriod" yaml:"KDPeriod"`
+	KDSlowK         int      `json:"KDSlowK" yaml:"KDSlowK"`
+	KDSlowD         int      `json:"KDSlowD" yaml:"KDSlowD"`
+}
+
 // TradeStock -.
 type TradeStock struct {
 	AllowTrade       bool    `json:"AllowTrade" yaml:"AllowTrade"`
@@ -71,3 +133,34 @@ type TradeTimeRange struct {
 	FirstPartDuration  int64 `json:"FirstPartDuration" yaml:"FirstPartDuration"`
 	SecondPartDuration int64 `json:"SecondPartDuration" yaml:"SecondPartDuration"`
 }
+
+// RateLimit -.
+type RateLimit struct {
+	Enabled bool `json:"Enabled" yaml:"Enabled"`
+
+	IPRate        float64 `json:"IPRate" yaml:"IPRate"`
+	IPBurst       int     `json:"IPBurst" yaml:"IPBurst"`
+	UsernameRate  float64 `json:"UsernameRate" yaml:"UsernameRate"`
+	UsernameBurst int     `json:"UsernameBurst" yaml:"UsernameBurst"`
+
+	MaxFailures     int   `json:"MaxFailures" yaml:"MaxFailures"`
+	FailureWindow   int64 `json:"FailureWindow" yaml:"FailureWindow"`
+	LockoutDuration int64 `json:"LockoutDuration" yaml:"LockoutDuration"`
+}
+
+// Notify -.
+type Notify struct {
+	// FilePath receives notifications of channels which are not configured, empty to drop them
+	FilePath string `json:"FilePath" yaml:"FilePath"`
+}
+
+// Partition is the retention of monthly partitions of stock tick, kbar and close history, 0 months keeps all.
+type Partition struct {
+	PremakeMonths        int `json:"PremakeMonths" yaml:"PremakeMonths"`
+	TickRetentionMonths  int `json:"TickRetentionMonths" yaml:"TickRetentionMonths"`
+	KbarRetentionMonths  int `json:"KbarRetentionMonths" yaml:"KbarRetentionMonths"`
+	CloseRetentionMonths int `json:"CloseRetentionMonths" yaml:"CloseRetentionMonths"`
+
+	// ArchiveDir keeps expired partitions as gzip csv before they are dropped, empty to drop only
+	ArchiveDir string `json:"ArchiveDir" yaml:"ArchiveDir"`
+}
@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+
+	jwt "github.com/appleboy/gin-jwt/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/toc-taiwan/toc-machine-trading/internal/controller/http/resp"
+	"github.com/toc-taiwan/toc-machine-trading/internal/usecase"
+)
+
+const (
+	apiKeyHeaderName = "X-API-Key"
+	apiKeyClaimName  = "api_key"
+)
+
+// apiKeyMiddleware authenticates by api key, and sets the same claims as jwt, so RequirePermission works for both.
+func apiKeyMiddleware(c *gin.Context, system usecase.System, key string) {
+	user, apiKey, err := system.AuthenticateAPIKey(c.Request.Context(), key, c.ClientIP())
+	if err != nil {
+		var ucErr *usecase.UseCaseError
+		if errors.As(err, &ucErr) {
+			resp.ErrorResponse(c, http.StatusUnauthorized, err)
+			return
+		}
+		resp.ErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	// key can only use permissions both granted by its scopes and the owner's current role
+	var permissions []interface{}
+	if role := system.GetRole(user.Role); role != nil {
+		for _, p := range apiKey.Permissions() {
+			if role.HasPermission(p) {
+				permissions = append(permissions, string(p))
+			}
+		}
+	}
+
+	c.Set("JWT_PAYLOAD", jwt.MapClaims{
+		"username":      user.Username,
+		"role":          user.Role,
+		"permissions":   permissions,
+		apiKeyClaimName: apiKey.KeyID,
+	})
+	c.Next()
+}
+
+// ExtractAPIKeyID returns the key id if the request is authenticated by api key.
+func ExtractAPIKeyID(c *gin.Context) string {
+	claims := jwt.ExtractClaims(c)
+	if v, ok := claims[apiKeyClaimName].(string); ok {
+		return v
+	}
+	return ""
+}
+
+// RequireSession aborts with 403 if the request is authenticated by api key.
+func RequireSession() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if ExtractAPIKeyID(c) != "" {
+			resp.ErrorResponse(c, http.StatusForbidden, usecase.ErrPermissionDenied)
+			return
+		}
+		c.Next()
+	}
+}
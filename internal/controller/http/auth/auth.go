@@ -2,7 +2,6 @@
 package auth
 
 import (
-	"context"
 	"fmt"
 	"net/http"
 	"time"
@@ -10,38 +9,27 @@ import (
 	jwt "github.com/appleboy/gin-jwt/v2"
 	"github.com/gin-gonic/gin"
 	v4jwt "github.com/golang-jwt/jwt/v4"
-	"github.com/google/uuid"
 	"github.com/toc-taiwan/toc-machine-trading/internal/controller/http/resp"
+	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
 	"github.com/toc-taiwan/toc-machine-trading/internal/usecase"
 )
 
 const (
 	tokenHeaderName = "Bearer"
 	identityKey     = "tmt_identity"
+	refreshTokenKey = "tmt_refresh_token"
 	timeOut         = 24 * time.Hour
 )
 
-func NewAuthMiddleware(system usecase.System) (*jwt.GinJWTMiddleware, error) {
-	key, err := system.GetLastJWT(context.Background())
-	if err != nil {
-		return nil, err
-	} else if key == "" {
-		key = uuid.New().String()
-		err = system.InsertJWT(context.Background(), key)
-		if err != nil {
-			return nil, err
-		}
-	}
-
+// newGinJWT signs with key, and verifies by keyFunc so tokens signed by retiring keys are still accepted.
+func newGinJWT(system usecase.System, key []byte, keyFunc func(*v4jwt.Token) (interface{}, error)) (*jwt.GinJWTMiddleware, error) {
 	m := jwt.GinJWTMiddleware{
-		TokenLookup:      "header:Authorization, query:token",
-		SigningAlgorithm: "HS256",
-		Timeout:          timeOut,
-		TimeFunc:         time.Now,
-		TokenHeadName:    tokenHeaderName,
-		Authorizator: func(interface{}, *gin.Context) bool {
-			return true
-		},
+		TokenLookup:           "header:Authorization, query:token",
+		SigningAlgorithm:      "HS256",
+		Timeout:               timeOut,
+		TimeFunc:              time.Now,
+		TokenHeadName:         tokenHeaderName,
+		Authorizator:          authorizator(system),
 		Unauthorized:          unauthorized,
 		LoginResponse:         loginResponse,
 		LogoutResponse:        logoutResponse,
@@ -53,7 +41,8 @@ func NewAuthMiddleware(system usecase.System) (*jwt.GinJWTMiddleware, error) {
 		CookieMaxAge:          timeOut,
 		CookieName:            "tmt",
 
-		Key:           []byte(key),
+		Key:           key,
+		KeyFunc:       keyFunc,
 		MaxRefresh:    timeOut,
 		Authenticator: authenticator(system),
 		PayloadFunc:   payloadFunc,
@@ -96,9 +85,10 @@ func unauthorized(c *gin.Context, code int, message string) {
 
 func loginResponse(c *gin.Context, code int, token string, expire time.Time) {
 	c.JSON(http.StatusOK, LoginResponseBody{
-		Token:  fmt.Sprintf("%s %s", tokenHeaderName, token),
-		Expire: expire.Format(time.RFC3339),
-		Code:   http.StatusOK,
+		Token:        fmt.Sprintf("%s %s", tokenHeaderName, token),
+		RefreshToken: c.GetString(refreshTokenKey),
+		Expire:       expire.Format(time.RFC3339),
+		Code:         http.StatusOK,
 	})
 }
 
@@ -119,24 +109,68 @@ func identityHandler(c *gin.Context) interface{} {
 	return claims[identityKey]
 }
 
+func authorizator(system usecase.System) func(interface{}, *gin.Context) bool {
+	return func(_ interface{}, c *gin.Context) bool {
+		return !IsTokenRevoked(c, system, jwt.ExtractClaims(c))
+	}
+}
+
+// IsTokenRevoked checks the session and login time in claims, token without them is treated as revoked.
+func IsTokenRevoked(c *gin.Context, system usecase.System, claims map[string]interface{}) bool {
+	username, ok := claims["username"].(string)
+	if !ok {
+		return true
+	}
+	sessionID, ok := claims["sid"].(string)
+	if !ok {
+		return true
+	}
+	loginAt, ok := claims["login_at"].(float64)
+	if !ok {
+		return true
+	}
+	return system.IsTokenRevoked(c.Request.Context(), username, sessionID, time.Unix(int64(loginAt), 0))
+}
+
 func authenticator(system usecase.System) func(c *gin.Context) (interface{}, error) {
 	return func(c *gin.Context) (interface{}, error) {
 		var loginVals LoginBody
 		if err := c.ShouldBind(&loginVals); err != nil {
 			return "", jwt.ErrMissingLoginValues
 		}
-		err := system.Login(c, loginVals.Username, loginVals.Password)
+		user, err := system.Login(c, loginVals.Username, loginVals.Password, loginVals.TOTP)
 		if err != nil {
 			return nil, err
 		}
-		return loginVals, nil
+		session, refreshToken, err := system.CreateSession(c.Request.Context(), user, c.Request.UserAgent(), c.ClientIP())
+		if err != nil {
+			return nil, err
+		}
+		c.Set(refreshTokenKey, refreshToken)
+		return newTokenIdentity(system, user, session), nil
+	}
+}
+
+func newTokenIdentity(system usecase.System, user *entity.User, session *entity.Session) tokenIdentity {
+	identity := tokenIdentity{
+		Username:  user.Username,
+		Role:      user.Role,
+		SessionID: session.SessionID,
+	}
+	if role := system.GetRole(user.Role); role != nil {
+		identity.Permissions = role.Permissions
 	}
+	return identity
 }
 
 func payloadFunc(data interface{}) jwt.MapClaims {
-	if v, ok := data.(LoginBody); ok {
+	if v, ok := data.(tokenIdentity); ok {
 		return jwt.MapClaims{
-			"username": v.Username,
+			"username":    v.Username,
+			"role":        v.Role,
+			"permissions": v.Permissions,
+			"sid":         v.SessionID,
+			"login_at":    time.Now().Unix(),
 		}
 	}
 	return nil
@@ -149,3 +183,51 @@ func ExtractUsername(c *gin.Context) string {
 	}
 	return ""
 }
+
+func ExtractSessionID(c *gin.Context) string {
+	claims := jwt.ExtractClaims(c)
+	if v, ok := claims["sid"].(string); ok {
+		return v
+	}
+	return ""
+}
+
+func ExtractRole(c *gin.Context) string {
+	claims := jwt.ExtractClaims(c)
+	if v, ok := claims["role"].(string); ok {
+		return v
+	}
+	return ""
+}
+
+func ExtractPermissions(c *gin.Context) []entity.Permission {
+	claims := jwt.ExtractClaims(c)
+	arr, ok := claims["permissions"].([]interface{})
+	if !ok {
+		return nil
+	}
+	var result []entity.Permission
+	for _, v := range arr {
+		if p, ok := v.(string); ok {
+			result = append(result, entity.Permission(p))
+		}
+	}
+	return result
+}
+
+// RequirePermission aborts with 403 if the token does not carry all the permissions.
+func RequirePermission(permissions ...entity.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		owned := make(map[entity.Permission]struct{})
+		for _, v := range ExtractPermissions(c) {
+			owned[v] = struct{}{}
+		}
+		for _, p := range permissions {
+			if _, ok := owned[p]; !ok {
+				resp.ErrorResponse(c, http.StatusForbidden, usecase.ErrPermissionDenied)
+				return
+			}
+		}
+		c.Next()
+	}
+}
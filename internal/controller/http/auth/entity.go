@@ -1,12 +1,28 @@
 package auth
 
+import "github.com/toc-taiwan/toc-machine-trading/internal/entity"
+
 type LoginBody struct {
 	Username string `json:"username"`
 	Password string `json:"password"`
+	// TOTP is required if the user enabled totp, backup code is also accepted
+	TOTP string `json:"totp"`
 }
 
 type LoginResponseBody struct {
-	Token  string `json:"token"`
-	Expire string `json:"expire"`
-	Code   int    `json:"code"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	Expire       string `json:"expire"`
+	Code         int    `json:"code"`
+}
+
+type RefreshBody struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type tokenIdentity struct {
+	Username    string
+	Role        string
+	SessionID   string
+	Permissions []entity.Permission
 }
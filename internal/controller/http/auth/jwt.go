@@ -0,0 +1,158 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	jwt "github.com/appleboy/gin-jwt/v2"
+	"github.com/gin-gonic/gin"
+	v4jwt "github.com/golang-jwt/jwt/v4"
+	"github.com/toc-taiwan/toc-machine-trading/internal/controller/http/resp"
+	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
+	"github.com/toc-taiwan/toc-machine-trading/internal/usecase"
+)
+
+var errUnknownSigningKey = errors.New("token is not signed by any valid key")
+
+// JWT wraps gin-jwt middleware, the signing key can be rotated without restart.
+type JWT struct {
+	system usecase.System
+	mw     atomic.Pointer[jwt.GinJWTMiddleware]
+	keys   atomic.Pointer[[]*entity.JWTKey]
+}
+
+func NewAuthMiddleware(system usecase.System) (*JWT, error) {
+	j := &JWT{system: system}
+	if err := j.reload(context.Background()); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+func (j *JWT) reload(ctx context.Context) error {
+	keys, err := j.system.GetJWTKeys(ctx)
+	if err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		return errUnknownSigningKey
+	}
+
+	mw, err := newGinJWT(j.system, []byte(keys[len(keys)-1].Key), j.keyFunc)
+	if err != nil {
+		return err
+	}
+	j.keys.Store(&keys)
+	j.mw.Store(mw)
+	return nil
+}
+
+// keyFunc finds the key which signed the token, newest first.
+func (j *JWT) keyFunc(token *v4jwt.Token) (interface{}, error) {
+	if token.Method.Alg() != v4jwt.SigningMethodHS256.Alg() {
+		return nil, jwt.ErrInvalidSigningAlgorithm
+	}
+
+	parts := strings.Split(token.Raw, ".")
+	if len(parts) != 3 {
+		return nil, errUnknownSigningKey
+	}
+
+	now := time.Now()
+	keys := *j.keys.Load()
+	for i := len(keys) - 1; i >= 0; i-- {
+		if keys[i].RetireAt != nil && now.After(*keys[i].RetireAt) {
+			continue
+		}
+		key := []byte(keys[i].Key)
+		if token.Method.Verify(strings.Join(parts[0:2], "."), parts[2], key) == nil {
+			return key, nil
+		}
+	}
+	return nil, errUnknownSigningKey
+}
+
+// Rotate signs new tokens with a fresh key, old keys are accepted until grace passed.
+func (j *JWT) Rotate(ctx context.Context, grace time.Duration) error {
+	if err := j.system.RotateJWTKey(ctx, grace); err != nil {
+		return err
+	}
+	return j.reload(ctx)
+}
+
+// MiddlewareFunc accepts api key in header, otherwise jwt.
+func (j *JWT) MiddlewareFunc() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if key := c.GetHeader(apiKeyHeaderName); key != "" {
+			apiKeyMiddleware(c, j.system, key)
+			return
+		}
+		j.mw.Load().MiddlewareFunc()(c)
+	}
+}
+
+func (j *JWT) LoginHandler(c *gin.Context) {
+	j.mw.Load().LoginHandler(c)
+}
+
+// LogoutHandler revokes the session of the token, then clears the cookie.
+func (j *JWT) LogoutHandler(c *gin.Context) {
+	if sessionID := ExtractSessionID(c); sessionID != "" {
+		if err := j.system.RevokeSession(c.Request.Context(), ExtractUsername(c), sessionID); err != nil {
+			resp.ErrorResponse(c, http.StatusInternalServerError, err)
+			return
+		}
+	}
+	j.mw.Load().LogoutHandler(c)
+}
+
+// RefreshHandler refreshes a not revoked token in the max refresh window.
+func (j *JWT) RefreshHandler(c *gin.Context) {
+	mw := j.mw.Load()
+	claims, err := mw.CheckIfTokenExpire(c)
+	if err != nil {
+		resp.ErrorResponse(c, http.StatusUnauthorized, err)
+		return
+	}
+	if IsTokenRevoked(c, j.system, claims) {
+		resp.ErrorResponse(c, http.StatusUnauthorized, jwt.ErrForbidden)
+		return
+	}
+	mw.RefreshHandler(c)
+}
+
+// RefreshSessionHandler exchanges the refresh token of a session for a new access token and refresh token.
+func (j *JWT) RefreshSessionHandler(c *gin.Context) {
+	body := RefreshBody{}
+	if err := c.ShouldBindJSON(&body); err != nil {
+		resp.ErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if body.RefreshToken == "" {
+		resp.ErrorResponse(c, http.StatusBadRequest, "refresh_token is required")
+		return
+	}
+
+	user, session, refreshToken, err := j.system.RefreshSession(c.Request.Context(), body.RefreshToken, c.Request.UserAgent(), c.ClientIP())
+	if err != nil {
+		if errors.Is(err, usecase.ErrRefreshTokenInvalid) || errors.Is(err, usecase.ErrUserNotFound) {
+			resp.ErrorResponse(c, http.StatusUnauthorized, err)
+			return
+		}
+		resp.ErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	token, expire, err := j.mw.Load().TokenGenerator(newTokenIdentity(j.system, user, session))
+	if err != nil {
+		resp.ErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.Set(refreshTokenKey, refreshToken)
+	loginResponse(c, http.StatusOK, token, expire)
+}
@@ -0,0 +1,171 @@
+// Package limiter package limiter throttles public endpoints per ip and per username
+package limiter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/toc-taiwan/toc-machine-trading/internal/config"
+	"github.com/toc-taiwan/toc-machine-trading/internal/controller/http/resp"
+	"github.com/toc-taiwan/toc-machine-trading/pkg/log"
+	"github.com/toc-taiwan/toc-machine-trading/pkg/ratelimit"
+)
+
+const (
+	cleanupInterval = 10 * time.Minute
+	maxPeekBodySize = 1 << 16
+)
+
+// UsernameFunc extracts the account the request is targeting, empty if none.
+type UsernameFunc func(c *gin.Context) string
+
+// Limiter -.
+type Limiter struct {
+	enabled  bool
+	ip       *ratelimit.Limiter
+	username *ratelimit.Limiter
+	logger   *log.Log
+}
+
+// State -.
+type State struct {
+	Enabled  bool              `json:"enabled"`
+	IP       []ratelimit.State `json:"ip"`
+	Username []ratelimit.State `json:"username"`
+}
+
+func New(cfg config.RateLimit) *Limiter {
+	failureWindow := time.Duration(cfg.FailureWindow) * time.Second
+	lockout := time.Duration(cfg.LockoutDuration) * time.Second
+	l := &Limiter{
+		enabled: cfg.Enabled,
+		ip: ratelimit.New(ratelimit.Config{
+			Rate:          cfg.IPRate,
+			Burst:         cfg.IPBurst,
+			MaxFailures:   cfg.MaxFailures,
+			FailureWindow: failureWindow,
+			Lockout:       lockout,
+		}),
+		username: ratelimit.New(ratelimit.Config{
+			Rate:          cfg.UsernameRate,
+			Burst:         cfg.UsernameBurst,
+			MaxFailures:   cfg.MaxFailures,
+			FailureWindow: failureWindow,
+			Lockout:       lockout,
+		}),
+		logger: log.Get(),
+	}
+
+	idle := failureWindow
+	if idle < cleanupInterval {
+		idle = cleanupInterval
+	}
+	go func() {
+		for range time.NewTicker(cleanupInterval).C {
+			l.ip.Cleanup(idle)
+			l.username.Cleanup(idle)
+		}
+	}()
+	return l
+}
+
+// Protect limits requests by ip and username, 4xx responses except 429 are counted as failures.
+func (l *Limiter) Protect(usernameFunc UsernameFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !l.enabled {
+			c.Next()
+			return
+		}
+
+		// X-Forwarded-For is only read from the trusted proxies set by router
+		ip := c.ClientIP()
+		var username string
+		if usernameFunc != nil {
+			username = usernameFunc(c)
+		}
+
+		if ok, wait := l.ip.Allow(ip); !ok {
+			l.reject(c, wait)
+			return
+		}
+		if username != "" {
+			if ok, wait := l.username.Allow(username); !ok {
+				l.reject(c, wait)
+				return
+			}
+		}
+
+		c.Next()
+
+		status := c.Writer.Status()
+		switch {
+		case status < http.StatusBadRequest:
+			if username != "" {
+				l.username.Reset(username)
+			}
+		case status < http.StatusInternalServerError && status != http.StatusTooManyRequests:
+			if l.ip.Fail(ip) {
+				l.logger.Warnf("rate limit: ip %s is locked by repeated failures on %s", ip, c.FullPath())
+			}
+			if username != "" && l.username.Fail(username) {
+				l.logger.Warnf("rate limit: username %s is locked by repeated failures on %s", username, c.FullPath())
+			}
+		}
+	}
+}
+
+func (l *Limiter) reject(c *gin.Context, wait time.Duration) {
+	c.Header("Retry-After", fmt.Sprintf("%d", int64(math.Ceil(wait.Seconds()))))
+	resp.ErrorResponse(c, http.StatusTooManyRequests, "too many requests")
+}
+
+// State returns the current state of all buckets.
+func (l *Limiter) State() State {
+	return State{
+		Enabled:  l.enabled,
+		IP:       l.ip.Snapshot(),
+		Username: l.username.Snapshot(),
+	}
+}
+
+// Unlock clears failures and lockout of the key in both ip and username buckets.
+func (l *Limiter) Unlock(key string) {
+	l.ip.Reset(key)
+	l.username.Reset(key)
+}
+
+// Param extracts username from path param.
+func Param(name string) UsernameFunc {
+	return func(c *gin.Context) string {
+		return c.Param(name)
+	}
+}
+
+// JSONField extracts username from a string field of json body, the body is restored for handlers.
+func JSONField(name string) UsernameFunc {
+	return func(c *gin.Context) string {
+		if c.Request.Body == nil {
+			return ""
+		}
+		body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxPeekBodySize))
+		if err != nil {
+			return ""
+		}
+		c.Request.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), c.Request.Body))
+
+		fields := make(map[string]interface{})
+		if err := json.Unmarshal(body, &fields); err != nil {
+			return ""
+		}
+		if v, ok := fields[name].(string); ok {
+			return v
+		}
+		return ""
+	}
+}
@@ -4,11 +4,12 @@ package router
 import (
 	"fmt"
 
-	jwt "github.com/appleboy/gin-jwt/v2"
 	"github.com/gin-gonic/gin"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/toc-taiwan/toc-machine-trading/docs"
+	"github.com/toc-taiwan/toc-machine-trading/internal/config"
 	"github.com/toc-taiwan/toc-machine-trading/internal/controller/http/auth"
+	"github.com/toc-taiwan/toc-machine-trading/internal/controller/http/limiter"
 	v1 "github.com/toc-taiwan/toc-machine-trading/internal/controller/http/v1"
 	"github.com/toc-taiwan/toc-machine-trading/internal/usecase"
 )
@@ -21,7 +22,7 @@ const (
 type Router struct {
 	rootHandler *gin.Engine
 	v1Group     *gin.RouterGroup
-	jwtHandler  *jwt.GinJWTMiddleware
+	jwtHandler  *auth.JWT
 }
 
 var swagHandler gin.HandlerFunc
@@ -34,11 +35,13 @@ var swagHandler gin.HandlerFunc
 //	@securityDefinitions.apikey	JWT
 //	@in							header
 //	@name						Authorization
+//	@securityDefinitions.apikey	APIKey
+//	@in							header
+//	@name						X-API-Key
 //	@license.name				GPLv3
 //	@license.url				https://www.gnu.org/licenses/gpl-3.0.html#license-text
 func NewRouter(system usecase.System) *Router {
-	g := gin.New()
-	g.Use(gin.Recovery())
+	g := newEngine(config.Get().Server.TrustedProxies)
 	g.GET("/metrics", gin.WrapH(promhttp.Handler()))
 
 	if swagHandler != nil {
@@ -52,13 +55,15 @@ func NewRouter(system usecase.System) *Router {
 		panic(err)
 	}
 
+	rateLimiter := limiter.New(config.Get().RateLimit)
 	v1Prefix := fmt.Sprintf("%s/v1", prefix)
 
 	v1Public := g.Group(v1Prefix)
 	v1Private := g.Group(v1Prefix)
 
 	v1Private.Use(jwtHandler.MiddlewareFunc())
-	v1.NewUserRoutes(v1Public, v1Private, jwtHandler, system)
+	v1.NewUserRoutes(v1Public, v1Private, jwtHandler, rateLimiter, system)
+	v1.NewAdminRoutes(v1Private, jwtHandler, rateLimiter, system)
 
 	return &Router{
 		rootHandler: g,
@@ -67,6 +72,16 @@ func NewRouter(system usecase.System) *Router {
 	}
 }
 
+// newEngine only reads X-Forwarded-For from trusted proxies, so ClientIP used by rate limit and api key allowlist can not be forged.
+func newEngine(trustedProxies []string) *gin.Engine {
+	g := gin.New()
+	if err := g.SetTrustedProxies(trustedProxies); err != nil {
+		panic(err)
+	}
+	g.Use(gin.Recovery())
+	return g
+}
+
 func (r *Router) GetHandler() *gin.Engine {
 	return r.rootHandler
 }
@@ -86,8 +101,8 @@ func (r *Router) AddV1BasicRoutes(basic usecase.Basic) *Router {
 	return r
 }
 
-func (r *Router) AddV1AnalyzeRoutes(analyze usecase.Analyze) *Router {
-	v1.NewAnalyzeRoutes(r.v1Group, analyze)
+func (r *Router) AddV1AnalyzeRoutes(analyze usecase.Analyze, history usecase.History) *Router {
+	v1.NewAnalyzeRoutes(r.v1Group, analyze, history)
 	return r
 }
 
@@ -106,8 +121,33 @@ func (r *Router) AddV1HistoryRoutes(history usecase.History) *Router {
 	return r
 }
 
-func (r *Router) AddV1RealTimeRoutes(basic usecase.Basic, realTime usecase.RealTime, history usecase.History) *Router {
-	v1.NewRealTimeRoutes(r.v1Group, basic, realTime, history)
+func (r *Router) AddV1RealTimeRoutes(basic usecase.Basic, realTime usecase.RealTime, history usecase.History, watchlist usecase.Watchlist) *Router {
+	v1.NewRealTimeRoutes(r.v1Group, basic, realTime, history, watchlist)
+	return r
+}
+
+func (r *Router) AddV1AlertRoutes(alert usecase.Alert) *Router {
+	v1.NewAlertRoutes(r.v1Group, alert)
+	return r
+}
+
+func (r *Router) AddV1NotifyRoutes(notify usecase.Notify) *Router {
+	v1.NewNotifyRoutes(r.v1Group, notify)
+	return r
+}
+
+func (r *Router) AddV1ReportRoutes(report usecase.Report) *Router {
+	v1.NewReportRoutes(r.v1Group, report)
+	return r
+}
+
+func (r *Router) AddV1OptionRoutes(option usecase.Option) *Router {
+	v1.NewOptionRoutes(r.v1Group, option)
+	return r
+}
+
+func (r *Router) AddV1WatchlistRoutes(watchlist usecase.Watchlist) *Router {
+	v1.NewWatchlistRoutes(r.v1Group, watchlist)
 	return r
 }
 
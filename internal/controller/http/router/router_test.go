@@ -0,0 +1,78 @@
+package router
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/toc-taiwan/toc-machine-trading/internal/controller/http/auth"
+	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
+	"github.com/toc-taiwan/toc-machine-trading/internal/usecase"
+)
+
+const allowedIP = "10.0.0.8"
+
+// apiKeySystem only allows the api key from allowedIP, other methods are not used.
+type apiKeySystem struct {
+	usecase.System
+}
+
+func (s *apiKeySystem) GetJWTKeys(context.Context) ([]*entity.JWTKey, error) {
+	return []*entity.JWTKey{{ID: 1, Key: "test"}}, nil
+}
+
+func (s *apiKeySystem) AuthenticateAPIKey(_ context.Context, _, ip string) (*entity.User, *entity.APIKey, error) {
+	if ip != allowedIP {
+		return nil, nil, usecase.ErrAPIKeyIPNotAllowed
+	}
+	return &entity.User{Username: "test"}, &entity.APIKey{KeyID: "test"}, nil
+}
+
+func (s *apiKeySystem) GetRole(string) *entity.Role {
+	return nil
+}
+
+func TestAPIKeyAllowlistForwardedFor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name           string
+		trustedProxies []string
+		remoteAddr     string
+		forwardedFor   string
+		want           int
+	}{
+		{"direct", nil, allowedIP + ":5000", "", http.StatusOK},
+		{"forged header", nil, "203.0.113.9:5000", allowedIP, http.StatusUnauthorized},
+		{"forged header from untrusted proxy", []string{"198.51.100.0/24"}, "203.0.113.9:5000", allowedIP, http.StatusUnauthorized},
+		{"trusted proxy", []string{"198.51.100.0/24"}, "198.51.100.2:5000", allowedIP, http.StatusOK},
+		{"trusted proxy forwards other ip", []string{"198.51.100.0/24"}, "198.51.100.2:5000", "203.0.113.9", http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jwtHandler, err := auth.NewAuthMiddleware(&apiKeySystem{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			g := newEngine(tt.trustedProxies)
+			g.GET("/", jwtHandler.MiddlewareFunc(), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			req.Header.Set("X-API-Key", "tmt_test")
+			if tt.forwardedFor != "" {
+				req.Header.Set("X-Forwarded-For", tt.forwardedFor)
+			}
+
+			w := httptest.NewRecorder()
+			g.ServeHTTP(w, req)
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,269 @@
+package v1
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/toc-taiwan/toc-machine-trading/internal/controller/http/auth"
+	"github.com/toc-taiwan/toc-machine-trading/internal/controller/http/limiter"
+	"github.com/toc-taiwan/toc-machine-trading/internal/controller/http/resp"
+	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
+	"github.com/toc-taiwan/toc-machine-trading/internal/usecase"
+)
+
+type adminRoutes struct {
+	system      usecase.System
+	jwtHandler  *auth.JWT
+	rateLimiter *limiter.Limiter
+}
+
+func NewAdminRoutes(handler *gin.RouterGroup, jwtHandler *auth.JWT, rateLimiter *limiter.Limiter, system usecase.System) {
+	r := &adminRoutes{
+		system:      system,
+		jwtHandler:  jwtHandler,
+		rateLimiter: rateLimiter,
+	}
+
+	h := handler.Group("/admin", auth.RequirePermission(entity.PermissionAdmin))
+	{
+		h.GET("/users", r.getAllUser)
+		h.GET("/roles", r.getAllRole)
+		h.PUT("/user/role", r.updateUserRole)
+		h.POST("/jwt/rotate", r.rotateJWTKey)
+		h.PUT("/user/auth-trade", r.updateUserAuthTrade)
+		h.GET("/setting", r.getSetting)
+		h.PUT("/setting", r.updateSetting)
+		h.GET("/rate-limit", r.getRateLimitState)
+		h.DELETE("/rate-limit/:key", r.unlockRateLimit)
+	}
+}
+
+// getAllUser -.
+//
+//	@Tags		Admin V1
+//	@Summary	Get all users with role
+//	@security	JWT
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{object}	[]entity.User{}
+//	@Failure	401	{object}	resp.Response{}
+//	@Failure	403	{object}	resp.Response{}
+//	@Failure	500	{object}	resp.Response{}
+//	@Router		/v1/admin/users [get]
+func (r *adminRoutes) getAllUser(c *gin.Context) {
+	users, err := r.system.GetAllUser(c.Request.Context())
+	if err != nil {
+		resp.ErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, users)
+}
+
+// getAllRole -.
+//
+//	@Tags		Admin V1
+//	@Summary	Get all roles and permissions
+//	@security	JWT
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{object}	[]entity.Role{}
+//	@Failure	401	{object}	resp.Response{}
+//	@Failure	403	{object}	resp.Response{}
+//	@Router		/v1/admin/roles [get]
+func (r *adminRoutes) getAllRole(c *gin.Context) {
+	c.JSON(http.StatusOK, r.system.GetAllRole())
+}
+
+type userRoleRequest struct {
+	Username string `json:"username"`
+	Role     string `json:"role"`
+}
+
+// updateUserRole -.
+//
+//	@Tags		Admin V1
+//	@Summary	Assign role to user
+//	@security	JWT
+//	@Accept		json
+//	@Produce	json
+//	@param		body	body	userRoleRequest{}	true	"Body"
+//	@Success	200
+//	@Failure	400	{object}	resp.Response{}
+//	@Failure	401	{object}	resp.Response{}
+//	@Failure	403	{object}	resp.Response{}
+//	@Router		/v1/admin/user/role [put]
+func (r *adminRoutes) updateUserRole(c *gin.Context) {
+	p := userRoleRequest{}
+	if err := c.ShouldBindJSON(&p); err != nil {
+		resp.ErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if p.Username == "" || p.Role == "" {
+		resp.ErrorResponse(c, http.StatusBadRequest, "username and role are required")
+		return
+	}
+
+	if err := r.system.UpdateUserRole(c.Request.Context(), p.Username, p.Role); err != nil {
+		resp.ErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+	c.JSON(http.StatusOK, nil)
+}
+
+type rotateJWTKeyRequest struct {
+	// GraceMinutes is how long tokens signed by old keys are still accepted
+	GraceMinutes int `json:"grace_minutes"`
+}
+
+// rotateJWTKey -.
+//
+//	@Tags		Admin V1
+//	@Summary	Rotate JWT signing key
+//	@security	JWT
+//	@Accept		json
+//	@Produce	json
+//	@param		body	body	rotateJWTKeyRequest{}	true	"Body"
+//	@Success	200
+//	@Failure	400	{object}	resp.Response{}
+//	@Failure	401	{object}	resp.Response{}
+//	@Failure	403	{object}	resp.Response{}
+//	@Failure	500	{object}	resp.Response{}
+//	@Router		/v1/admin/jwt/rotate [post]
+func (r *adminRoutes) rotateJWTKey(c *gin.Context) {
+	p := rotateJWTKeyRequest{}
+	if err := c.ShouldBindJSON(&p); err != nil {
+		resp.ErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if p.GraceMinutes < 0 {
+		resp.ErrorResponse(c, http.StatusBadRequest, "grace_minutes should not be negative")
+		return
+	}
+
+	if err := r.jwtHandler.Rotate(c.Request.Context(), time.Duration(p.GraceMinutes)*time.Minute); err != nil {
+		resp.ErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, nil)
+}
+
+type userAuthTradeRequest struct {
+	Username  string `json:"username"`
+	AuthTrade bool   `json:"auth_trade"`
+}
+
+// updateUserAuthTrade -.
+//
+//	@Tags		Admin V1
+//	@Summary	Grant or revoke trade authorization of user
+//	@security	JWT
+//	@Accept		json
+//	@Produce	json
+//	@param		body	body	userAuthTradeRequest{}	true	"Body"
+//	@Success	200
+//	@Failure	400	{object}	resp.Response{}
+//	@Failure	401	{object}	resp.Response{}
+//	@Failure	403	{object}	resp.Response{}
+//	@Router		/v1/admin/user/auth-trade [put]
+func (r *adminRoutes) updateUserAuthTrade(c *gin.Context) {
+	p := userAuthTradeRequest{}
+	if err := c.ShouldBindJSON(&p); err != nil {
+		resp.ErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if p.Username == "" {
+		resp.ErrorResponse(c, http.StatusBadRequest, "username is required")
+		return
+	}
+
+	if err := r.system.UpdateUserAuthTrade(c.Request.Context(), p.Username, p.AuthTrade); err != nil {
+		resp.ErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+	c.JSON(http.StatusOK, nil)
+}
+
+// getSetting -.
+//
+//	@Tags		Admin V1
+//	@Summary	Get system setting
+//	@security	JWT
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{object}	entity.SystemSetting{}
+//	@Failure	401	{object}	resp.Response{}
+//	@Failure	403	{object}	resp.Response{}
+//	@Failure	500	{object}	resp.Response{}
+//	@Router		/v1/admin/setting [get]
+func (r *adminRoutes) getSetting(c *gin.Context) {
+	setting, err := r.system.GetSetting(c.Request.Context())
+	if err != nil {
+		resp.ErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, setting)
+}
+
+// updateSetting -.
+//
+//	@Tags		Admin V1
+//	@Summary	Update system setting
+//	@security	JWT
+//	@Accept		json
+//	@Produce	json
+//	@param		body	body	entity.SystemSetting{}	true	"Body"
+//	@Success	200
+//	@Failure	400	{object}	resp.Response{}
+//	@Failure	401	{object}	resp.Response{}
+//	@Failure	403	{object}	resp.Response{}
+//	@Failure	500	{object}	resp.Response{}
+//	@Router		/v1/admin/setting [put]
+func (r *adminRoutes) updateSetting(c *gin.Context) {
+	p := entity.SystemSetting{}
+	if err := c.ShouldBindJSON(&p); err != nil {
+		resp.ErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := r.system.UpdateSetting(c.Request.Context(), &p); err != nil {
+		resp.ErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, nil)
+}
+
+// getRateLimitState -.
+//
+//	@Tags		Admin V1
+//	@Summary	Get rate limit state of public endpoints
+//	@security	JWT
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{object}	limiter.State{}
+//	@Failure	401	{object}	resp.Response{}
+//	@Failure	403	{object}	resp.Response{}
+//	@Router		/v1/admin/rate-limit [get]
+func (r *adminRoutes) getRateLimitState(c *gin.Context) {
+	c.JSON(http.StatusOK, r.rateLimiter.State())
+}
+
+// unlockRateLimit -.
+//
+//	@Tags		Admin V1
+//	@Summary	Clear failures and lockout of an ip or username
+//	@security	JWT
+//	@Accept		json
+//	@Produce	json
+//	@param		key	path	string	true	"ip or username"
+//	@Success	200
+//	@Failure	401	{object}	resp.Response{}
+//	@Failure	403	{object}	resp.Response{}
+//	@Router		/v1/admin/rate-limit/{key} [delete]
+func (r *adminRoutes) unlockRateLimit(c *gin.Context) {
+	r.rateLimiter.Unlock(c.Param("key"))
+	c.JSON(http.StatusOK, nil)
+}
@@ -0,0 +1,218 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/toc-taiwan/toc-machine-trading/internal/controller/http/auth"
+	"github.com/toc-taiwan/toc-machine-trading/internal/controller/http/resp"
+	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
+	"github.com/toc-taiwan/toc-machine-trading/internal/usecase"
+)
+
+type alertRoutes struct {
+	t usecase.Alert
+}
+
+func NewAlertRoutes(handler *gin.RouterGroup, t usecase.Alert) {
+	r := &alertRoutes{t}
+
+	h := handler.Group("/alert", auth.RequirePermission(entity.PermissionView))
+	{
+		h.GET("/rules", r.getAlertRules)
+		h.POST("/rules", r.createAlertRule)
+		h.PUT("/rules/:id", r.updateAlertRule)
+		h.DELETE("/rules/:id", r.deleteAlertRule)
+		h.GET("/history", r.getAlertHistory)
+	}
+}
+
+// getAlertRules -.
+//
+//	@Tags		Alert V1
+//	@Summary	List alert rules of user
+//	@security	JWT
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{object}	[]entity.AlertRule{}
+//	@Failure	400	{object}	resp.Response{}
+//	@Failure	401	{object}	resp.Response{}
+//	@Failure	500	{object}	resp.Response{}
+//	@Router		/v1/alert/rules [get]
+func (r *alertRoutes) getAlertRules(c *gin.Context) {
+	username := auth.ExtractUsername(c)
+	if username == "" {
+		resp.ErrorResponse(c, http.StatusBadRequest, "username is required in token")
+		return
+	}
+
+	rules, err := r.t.GetAlertRules(c.Request.Context(), username)
+	if err != nil {
+		resp.ErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, rules)
+}
+
+// createAlertRule -.
+//
+//	@Tags		Alert V1
+//	@Summary	Create alert rule, cooldown is in seconds
+//	@security	JWT
+//	@Accept		json
+//	@Produce	json
+//	@param		body	body		entity.NewAlertRule{}	true	"Body"
+//	@Success	200		{object}	entity.AlertRule{}
+//	@Failure	400		{object}	resp.Response{}
+//	@Failure	401		{object}	resp.Response{}
+//	@Failure	500		{object}	resp.Response{}
+//	@Router		/v1/alert/rules [post]
+func (r *alertRoutes) createAlertRule(c *gin.Context) {
+	username := auth.ExtractUsername(c)
+	if username == "" {
+		resp.ErrorResponse(c, http.StatusBadRequest, "username is required in token")
+		return
+	}
+
+	p := entity.NewAlertRule{}
+	if err := c.ShouldBindJSON(&p); err != nil {
+		resp.ErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	rule, err := r.t.CreateAlertRule(c.Request.Context(), username, &p)
+	if err != nil {
+		r.alertError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, rule)
+}
+
+// updateAlertRule -.
+//
+//	@Tags		Alert V1
+//	@Summary	Update alert rule
+//	@security	JWT
+//	@Accept		json
+//	@Produce	json
+//	@param		id		path		int						true	"id"
+//	@param		body	body		entity.NewAlertRule{}	true	"Body"
+//	@Success	200		{object}	entity.AlertRule{}
+//	@Failure	400		{object}	resp.Response{}
+//	@Failure	401		{object}	resp.Response{}
+//	@Failure	404		{object}	resp.Response{}
+//	@Failure	500		{object}	resp.Response{}
+//	@Router		/v1/alert/rules/{id} [put]
+func (r *alertRoutes) updateAlertRule(c *gin.Context) {
+	username := auth.ExtractUsername(c)
+	if username == "" {
+		resp.ErrorResponse(c, http.StatusBadRequest, "username is required in token")
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		resp.ErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	p := entity.NewAlertRule{}
+	if err = c.ShouldBindJSON(&p); err != nil {
+		resp.ErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	rule, err := r.t.UpdateAlertRule(c.Request.Context(), username, id, &p)
+	if err != nil {
+		r.alertError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, rule)
+}
+
+// deleteAlertRule -.
+//
+//	@Tags		Alert V1
+//	@Summary	Delete alert rule, history is kept
+//	@security	JWT
+//	@Accept		json
+//	@Produce	json
+//	@param		id	path	int	true	"id"
+//	@Success	200
+//	@Failure	400	{object}	resp.Response{}
+//	@Failure	401	{object}	resp.Response{}
+//	@Failure	404	{object}	resp.Response{}
+//	@Failure	500	{object}	resp.Response{}
+//	@Router		/v1/alert/rules/{id} [delete]
+func (r *alertRoutes) deleteAlertRule(c *gin.Context) {
+	username := auth.ExtractUsername(c)
+	if username == "" {
+		resp.ErrorResponse(c, http.StatusBadRequest, "username is required in token")
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		resp.ErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err = r.t.DeleteAlertRule(c.Request.Context(), username, id); err != nil {
+		r.alertError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, nil)
+}
+
+// getAlertHistory -.
+//
+//	@Tags		Alert V1
+//	@Summary	List triggered alerts of user, newest first
+//	@security	JWT
+//	@Accept		json
+//	@Produce	json
+//	@param		limit	query		int	false	"limit, max 200"
+//	@param		offset	query		int	false	"offset"
+//	@Success	200		{object}	[]entity.AlertHistory{}
+//	@Failure	400		{object}	resp.Response{}
+//	@Failure	401		{object}	resp.Response{}
+//	@Failure	500		{object}	resp.Response{}
+//	@Router		/v1/alert/history [get]
+func (r *alertRoutes) getAlertHistory(c *gin.Context) {
+	username := auth.ExtractUsername(c)
+	if username == "" {
+		resp.ErrorResponse(c, http.StatusBadRequest, "username is required in token")
+		return
+	}
+
+	limit, err := strconv.ParseUint(c.DefaultQuery("limit", "50"), 10, 64)
+	if err != nil {
+		resp.ErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+	offset, err := strconv.ParseUint(c.DefaultQuery("offset", "0"), 10, 64)
+	if err != nil {
+		resp.ErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	history, err := r.t.GetAlertHistory(c.Request.Context(), username, limit, offset)
+	if err != nil {
+		resp.ErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, history)
+}
+
+func (r *alertRoutes) alertError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrAlertRuleNotFound):
+		resp.ErrorResponse(c, http.StatusNotFound, err)
+	case errors.Is(err, usecase.ErrAlertRuleInvalid), errors.Is(err, usecase.ErrAlertCodeNotFound):
+		resp.ErrorResponse(c, http.StatusBadRequest, err)
+	default:
+		resp.ErrorResponse(c, http.StatusInternalServerError, err)
+	}
+}
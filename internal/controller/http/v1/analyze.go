@@ -2,59 +2,97 @@
 package v1
 
 import (
+	"errors"
 	"net/http"
-	"sort"
-	"time"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/toc-taiwan/toc-machine-trading/internal/controller/http/auth"
+	"github.com/toc-taiwan/toc-machine-trading/internal/controller/http/resp"
 	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
 	"github.com/toc-taiwan/toc-machine-trading/internal/usecase"
 )
 
 type analyzeRoutes struct {
-	t usecase.Analyze
+	t       usecase.Analyze
+	history usecase.History
 }
 
-func NewAnalyzeRoutes(handler *gin.RouterGroup, t usecase.Analyze) {
-	r := &analyzeRoutes{t}
+func NewAnalyzeRoutes(handler *gin.RouterGroup, t usecase.Analyze, history usecase.History) {
+	r := &analyzeRoutes{t, history}
 
-	h := handler.Group("/analyze")
+	h := handler.Group("/analyze", auth.RequirePermission(entity.PermissionView))
 	{
-		h.GET("/reborn", r.getRebornTargets)
+		h.GET("/indicators/:code", r.getIndicators)
+		h.GET("/patterns", r.getPatternHits)
 	}
 }
 
-type reborn struct {
-	Date   string         `json:"date"`
-	Stocks []entity.Stock `json:"stocks"`
+// getIndicators -.
+//
+//	@Tags		Analyze V1
+//	@Summary	Get technical indicators of stock, day series end at date, minute series are bars of date
+//	@security	JWT
+//	@Accept		json
+//	@Produce	json
+//	@param		code		path		string	true	"code"
+//	@param		interval	query		string	false	"day or minute, default day"
+//	@param		date		query		string	false	"2006-01-02, default today"
+//	@Success	200			{object}	entity.Indicators{}
+//	@Failure	400			{object}	resp.Response{}
+//	@Failure	500			{object}	resp.Response{}
+//	@Router		/v1/analyze/indicators/{code} [get]
+func (r *analyzeRoutes) getIndicators(c *gin.Context) {
+	interval := entity.IndicatorInterval(c.DefaultQuery("interval", string(entity.IndicatorIntervalDay)))
+	result, err := r.history.GetIndicators(c.Request.Context(), c.Param("code"), interval, c.Query("date"))
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrIndicatorIntervalInvalid), errors.Is(err, usecase.ErrIndicatorCodeNotFound), errors.Is(err, usecase.ErrIndicatorDateInvalid):
+			resp.ErrorResponse(c, http.StatusBadRequest, err)
+		default:
+			resp.ErrorResponse(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+	c.JSON(http.StatusOK, result)
 }
 
-// getRebornTargets -.
+// getPatternHits -.
 //
 //	@Tags		Analyze V1
-//	@Summary	Get reborn targets
+//	@Summary	List pattern scanner hits on date
 //	@security	JWT
 //	@Accept		json
 //	@Produce	json
-//	@Success	200	{object}	[]reborn{}
-//	@Router		/v1/analyze/reborn [get]
-func (r *analyzeRoutes) getRebornTargets(c *gin.Context) {
-	mapData := r.t.GetRebornMap(c.Request.Context())
-	result := []reborn{}
-	dateArr := []time.Time{}
-	for date := range mapData {
-		dateArr = append(dateArr, date)
+//	@param		date	query		string	true	"2006-01-02"
+//	@param		pattern	query		string	false	"ma_cross, gap, breakout, volume_spike, inside_bar or quater_ma_reborn"
+//	@param		limit	query		int		false	"limit, max 200"
+//	@param		offset	query		int		false	"offset"
+//	@Success	200		{object}	entity.PatternHitList{}
+//	@Failure	400		{object}	resp.Response{}
+//	@Failure	500		{object}	resp.Response{}
+//	@Router		/v1/analyze/patterns [get]
+func (r *analyzeRoutes) getPatternHits(c *gin.Context) {
+	limit, err := strconv.ParseUint(c.DefaultQuery("limit", "50"), 10, 64)
+	if err != nil {
+		resp.ErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+	offset, err := strconv.ParseUint(c.DefaultQuery("offset", "0"), 10, 64)
+	if err != nil {
+		resp.ErrorResponse(c, http.StatusBadRequest, err)
+		return
 	}
 
-	sort.SliceStable(dateArr, func(i, j int) bool {
-		return dateArr[i].After(dateArr[j])
-	})
-
-	for _, date := range dateArr {
-		result = append(result, reborn{
-			Date:   date.Format(entity.ShortTimeLayout),
-			Stocks: mapData[date],
-		})
+	result, err := r.t.GetPatternHits(c.Request.Context(), c.Query("date"), c.Query("pattern"), limit, offset)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrPatternDateInvalid), errors.Is(err, usecase.ErrPatternInvalid):
+			resp.ErrorResponse(c, http.StatusBadRequest, err)
+		default:
+			resp.ErrorResponse(c, http.StatusInternalServerError, err)
+		}
+		return
 	}
 	c.JSON(http.StatusOK, result)
 }
@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/toc-taiwan/toc-machine-trading/internal/controller/http/auth"
 	"github.com/toc-taiwan/toc-machine-trading/internal/controller/http/resp"
 	"github.com/toc-taiwan/toc-machine-trading/internal/controller/http/websocket/pick"
 	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
@@ -19,12 +20,13 @@ type basicRoutes struct {
 func NewBasicRoutes(handler *gin.RouterGroup, t usecase.Basic) {
 	r := &basicRoutes{t}
 
-	h := handler.Group("/basic")
+	h := handler.Group("/basic", auth.RequirePermission(entity.PermissionView))
 	{
 		h.PUT("/stock", r.getStockDetail)
 		h.GET("/usage/shioaji", r.getShioajiUsage)
 		h.GET("/search/stock", r.serveStockSerchWS)
 		h.GET("/search/future", r.serveFutureSerchWS)
+		h.GET("/search/option", r.serveOptionSerchWS)
 		h.GET("/search/future/mxf", r.getNearestMXF)
 	}
 }
@@ -103,6 +105,11 @@ func (r *basicRoutes) serveFutureSerchWS(c *gin.Context) {
 	pick.StartWSTargetSearcher(c, r.t, pick.Future)
 }
 
+// serveOptionSerchWS messages are keyword or json of entity.OptionSearchFilter.
+func (r *basicRoutes) serveOptionSerchWS(c *gin.Context) {
+	pick.StartWSTargetSearcher(c, r.t, pick.Option)
+}
+
 // getNearestMXF -.
 //
 //	@Tags		Basic V1
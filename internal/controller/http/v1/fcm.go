@@ -4,7 +4,9 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/toc-taiwan/toc-machine-trading/internal/controller/http/auth"
 	"github.com/toc-taiwan/toc-machine-trading/internal/controller/http/resp"
+	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
 	"github.com/toc-taiwan/toc-machine-trading/internal/usecase"
 )
 
@@ -15,7 +17,7 @@ type fcmRoutes struct {
 func NewFCMRoutes(handler *gin.RouterGroup, t usecase.FCM) {
 	r := &fcmRoutes{t}
 
-	h := handler.Group("/fcm")
+	h := handler.Group("/fcm", auth.RequirePermission(entity.PermissionAdmin))
 	{
 		h.POST("/announcement", r.announceMessage)
 		h.POST("/push", r.pushMessage)
@@ -36,6 +38,7 @@ type announceRequest struct {
 //	@param		body	body	announceRequest{}	true	"Body"
 //	@Success	200
 //	@Failure	400	{object}	resp.Response{}
+//	@Failure	403	{object}	resp.Response{}
 //	@Failure	500	{object}	resp.Response{}
 //	@Router		/v1/fcm/announcement [post]
 func (r *fcmRoutes) announceMessage(c *gin.Context) {
@@ -68,6 +71,7 @@ type pushRequest struct {
 //	@param		body	body	pushRequest{}	true	"Body"
 //	@Success	200
 //	@Failure	400	{object}	resp.Response{}
+//	@Failure	403	{object}	resp.Response{}
 //	@Failure	500	{object}	resp.Response{}
 //	@Router		/v1/fcm/push [post]
 func (r *fcmRoutes) pushMessage(c *gin.Context) {
@@ -2,8 +2,14 @@
 package v1
 
 import (
+	"errors"
+	"net/http"
+
 	"github.com/gin-gonic/gin"
+	"github.com/toc-taiwan/toc-machine-trading/internal/controller/http/auth"
+	"github.com/toc-taiwan/toc-machine-trading/internal/controller/http/resp"
 	"github.com/toc-taiwan/toc-machine-trading/internal/controller/http/websocket/history"
+	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
 	"github.com/toc-taiwan/toc-machine-trading/internal/usecase"
 )
 
@@ -14,12 +20,107 @@ type historyRoutes struct {
 func NewHistoryRoutes(handler *gin.RouterGroup, t usecase.History) {
 	r := &historyRoutes{t}
 
-	h := handler.Group("/history")
+	h := handler.Group("/history", auth.RequirePermission(entity.PermissionView))
 	{
 		h.GET("/ws", r.serveWS)
+		h.GET("/kbar/:code", r.getKbarRange)
+		h.GET("/continuous/:code", r.getContinuousContract)
+		h.GET("/close/:code", r.getStockCloseSeries)
 	}
 }
 
 func (r *historyRoutes) serveWS(c *gin.Context) {
 	history.StartWSHistory(c, r.t)
 }
+
+// getKbarRange -.
+//
+//	@Tags			History V1
+//	@Summary		Get kbars of stock or future between dates, minute resolutions up to 31 days, 1d up to 366 days
+//	@Description	Virtual future codes like MXF1! are stitched from the front contracts and adjusted to the latest one
+//	@security		JWT
+//	@Accept			json
+//	@Produce		json
+//	@param			code		path		string	true	"code"
+//	@param			from		query		string	true	"2006-01-02"
+//	@param			to			query		string	true	"2006-01-02"
+//	@param			resolution	query		string	false	"1m, 5m, 15m, 60m or 1d, default 1m"
+//	@Success		200			{object}	entity.KbarRange{}
+//	@Failure		400			{object}	resp.Response{}
+//	@Failure		500			{object}	resp.Response{}
+//	@Router			/v1/history/kbar/{code} [get]
+func (r *historyRoutes) getKbarRange(c *gin.Context) {
+	resolution := entity.KbarResolution(c.DefaultQuery("resolution", string(entity.KbarResolution1m)))
+	result, err := r.t.GetKbarRange(c.Request.Context(), c.Param("code"), c.Query("from"), c.Query("to"), resolution)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrKbarRangeInvalid), errors.Is(err, usecase.ErrKbarRangeTooLong), errors.Is(err, usecase.ErrKbarRangeCodeNotFound):
+			resp.ErrorResponse(c, http.StatusBadRequest, err)
+		case errors.Is(err, usecase.ErrContinuousContractNotFound):
+			resp.ErrorResponse(c, http.StatusNotFound, err)
+		default:
+			resp.ErrorResponse(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// getContinuousContract -.
+//
+//	@Tags		History V1
+//	@Summary	Get real contracts and roll adjustments of a virtual future code like MXF1! between dates, up to 366 days
+//	@security	JWT
+//	@Accept		json
+//	@Produce	json
+//	@param		code	path		string	true	"code"
+//	@param		from	query		string	true	"2006-01-02"
+//	@param		to		query		string	true	"2006-01-02"
+//	@Success	200		{object}	entity.ContinuousContract{}
+//	@Failure	400		{object}	resp.Response{}
+//	@Failure	404		{object}	resp.Response{}
+//	@Failure	500		{object}	resp.Response{}
+//	@Router		/v1/history/continuous/{code} [get]
+func (r *historyRoutes) getContinuousContract(c *gin.Context) {
+	result, err := r.t.GetContinuousContract(c.Request.Context(), c.Param("code"), c.Query("from"), c.Query("to"))
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrContinuousCodeInvalid), errors.Is(err, usecase.ErrKbarRangeInvalid), errors.Is(err, usecase.ErrKbarRangeTooLong):
+			resp.ErrorResponse(c, http.StatusBadRequest, err)
+		case errors.Is(err, usecase.ErrContinuousContractNotFound):
+			resp.ErrorResponse(c, http.StatusNotFound, err)
+		default:
+			resp.ErrorResponse(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// getStockCloseSeries -.
+//
+//	@Tags		History V1
+//	@Summary	Get raw and corporate action adjusted closes of stock between dates, up to 366 days
+//	@security	JWT
+//	@Accept		json
+//	@Produce	json
+//	@param		code	path		string	true	"code"
+//	@param		from	query		string	true	"2006-01-02"
+//	@param		to		query		string	true	"2006-01-02"
+//	@Success	200		{object}	entity.StockCloseSeries{}
+//	@Failure	400		{object}	resp.Response{}
+//	@Failure	500		{object}	resp.Response{}
+//	@Router		/v1/history/close/{code} [get]
+func (r *historyRoutes) getStockCloseSeries(c *gin.Context) {
+	result, err := r.t.GetStockCloseSeries(c.Request.Context(), c.Param("code"), c.Query("from"), c.Query("to"))
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrCloseRangeInvalid), errors.Is(err, usecase.ErrCloseRangeTooLong), errors.Is(err, usecase.ErrCloseCodeNotFound):
+			resp.ErrorResponse(c, http.StatusBadRequest, err)
+		default:
+			resp.ErrorResponse(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,93 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/toc-taiwan/toc-machine-trading/internal/controller/http/auth"
+	"github.com/toc-taiwan/toc-machine-trading/internal/controller/http/resp"
+	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
+	"github.com/toc-taiwan/toc-machine-trading/internal/usecase"
+)
+
+type notifyRoutes struct {
+	t usecase.Notify
+}
+
+func NewNotifyRoutes(handler *gin.RouterGroup, t usecase.Notify) {
+	r := &notifyRoutes{t}
+
+	// notify targets decide where the server sends requests, api keys cannot change them
+	h := handler.Group("/notify", auth.RequireSession())
+	{
+		h.GET("/preference", r.getNotifyPreference)
+		h.PUT("/preference", r.updateNotifyPreference)
+	}
+}
+
+// getNotifyPreference -.
+//
+//	@Tags		Notify V1
+//	@Summary	Get channels of each notify event, events not set use default channels
+//	@security	JWT
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{object}	entity.NotifyPreference{}
+//	@Failure	400	{object}	resp.Response{}
+//	@Failure	401	{object}	resp.Response{}
+//	@Failure	403	{object}	resp.Response{}
+//	@Failure	500	{object}	resp.Response{}
+//	@Router		/v1/notify/preference [get]
+func (r *notifyRoutes) getNotifyPreference(c *gin.Context) {
+	username := auth.ExtractUsername(c)
+	if username == "" {
+		resp.ErrorResponse(c, http.StatusBadRequest, "username is required in token")
+		return
+	}
+
+	pref, err := r.t.GetNotifyPreference(c.Request.Context(), username)
+	if err != nil {
+		resp.ErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, pref)
+}
+
+// updateNotifyPreference -.
+//
+//	@Tags		Notify V1
+//	@Summary	Replace notify preference, event keys are order_fill, alert, daily_report
+//	@security	JWT
+//	@Accept		json
+//	@Produce	json
+//	@param		body	body	entity.NotifyPreference{}	true	"Body"
+//	@Success	200
+//	@Failure	400	{object}	resp.Response{}
+//	@Failure	401	{object}	resp.Response{}
+//	@Failure	403	{object}	resp.Response{}
+//	@Failure	500	{object}	resp.Response{}
+//	@Router		/v1/notify/preference [put]
+func (r *notifyRoutes) updateNotifyPreference(c *gin.Context) {
+	username := auth.ExtractUsername(c)
+	if username == "" {
+		resp.ErrorResponse(c, http.StatusBadRequest, "username is required in token")
+		return
+	}
+
+	p := entity.NotifyPreference{}
+	if err := c.ShouldBindJSON(&p); err != nil {
+		resp.ErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := r.t.UpdateNotifyPreference(c.Request.Context(), username, &p); err != nil {
+		if errors.Is(err, usecase.ErrNotifyChannelInvalid) || errors.Is(err, usecase.ErrNotifyAddressInvalid) {
+			resp.ErrorResponse(c, http.StatusBadRequest, err)
+			return
+		}
+		resp.ErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, nil)
+}
@@ -0,0 +1,102 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/toc-taiwan/toc-machine-trading/internal/controller/http/auth"
+	"github.com/toc-taiwan/toc-machine-trading/internal/controller/http/resp"
+	"github.com/toc-taiwan/toc-machine-trading/internal/controller/http/websocket/option"
+	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
+	"github.com/toc-taiwan/toc-machine-trading/internal/usecase"
+)
+
+type optionRoutes struct {
+	t usecase.Option
+}
+
+func NewOptionRoutes(handler *gin.RouterGroup, t usecase.Option) {
+	r := &optionRoutes{t}
+
+	h := handler.Group("/option", auth.RequirePermission(entity.PermissionView))
+	{
+		h.GET("/chains", r.getOptionChains)
+		h.GET("/chain/:category/:month", r.getOptionChain)
+		h.GET("/snapshot/:code", r.getOptionSnapshot)
+		h.GET("/ws/chain", r.serveChainWS)
+	}
+}
+
+// getOptionChains -.
+//
+//	@Tags		Option V1
+//	@Summary	List option chains by category and delivery month
+//	@security	JWT
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{object}	[]entity.OptionChainInfo{}
+//	@Failure	401	{object}	resp.Response{}
+//	@Router		/v1/option/chains [get]
+func (r *optionRoutes) getOptionChains(c *gin.Context) {
+	c.JSON(http.StatusOK, r.t.GetOptionChains(c.Request.Context()))
+}
+
+// getOptionChain -.
+//
+//	@Tags		Option V1
+//	@Summary	Get option chain by strike with live quotes, implied volatility and greeks
+//	@security	JWT
+//	@Accept		json
+//	@Produce	json
+//	@param		category	path		string	true	"category, e.g. TXO"
+//	@param		month		path		string	true	"delivery month, e.g. 202611"
+//	@param		underlying	query		string	false	"future code, default nearest future of category"
+//	@Success	200			{object}	entity.OptionChain{}
+//	@Failure	401			{object}	resp.Response{}
+//	@Failure	404			{object}	resp.Response{}
+//	@Failure	500			{object}	resp.Response{}
+//	@Router		/v1/option/chain/{category}/{month} [get]
+func (r *optionRoutes) getOptionChain(c *gin.Context) {
+	chain, err := r.t.GetOptionChain(c.Request.Context(), c.Param("category"), c.Param("month"), c.Query("underlying"))
+	if err != nil {
+		r.optionError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, chain)
+}
+
+// getOptionSnapshot -.
+//
+//	@Tags		Option V1
+//	@Summary	Get live quote of option with implied volatility and greeks
+//	@security	JWT
+//	@Accept		json
+//	@Produce	json
+//	@param		code	path		string	true	"code"
+//	@Success	200		{object}	entity.OptionQuote{}
+//	@Failure	401		{object}	resp.Response{}
+//	@Failure	404		{object}	resp.Response{}
+//	@Failure	500		{object}	resp.Response{}
+//	@Router		/v1/option/snapshot/{code} [get]
+func (r *optionRoutes) getOptionSnapshot(c *gin.Context) {
+	quote, err := r.t.GetOptionSnapshot(c.Request.Context(), c.Param("code"))
+	if err != nil {
+		r.optionError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, quote)
+}
+
+func (r *optionRoutes) serveChainWS(c *gin.Context) {
+	option.StartWSOptionChain(c, r.t)
+}
+
+func (r *optionRoutes) optionError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrOptionChainNotFound), errors.Is(err, usecase.ErrOptionNotFound), errors.Is(err, usecase.ErrOptionUnderlyingNotFound):
+		resp.ErrorResponse(c, http.StatusNotFound, err)
+	default:
+		resp.ErrorResponse(c, http.StatusInternalServerError, err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/toc-taiwan/toc-machine-trading/internal/controller/http/auth"
 	"github.com/toc-taiwan/toc-machine-trading/internal/controller/http/resp"
 	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
 	"github.com/toc-taiwan/toc-machine-trading/internal/usecase"
@@ -17,7 +18,7 @@ type orderRoutes struct {
 func NewOrderRoutes(handler *gin.RouterGroup, t usecase.Trade) {
 	r := &orderRoutes{t}
 
-	h := handler.Group("/order")
+	h := handler.Group("/order", auth.RequirePermission(entity.PermissionTrade))
 	{
 		h.GET("/balance", r.getAllTradeBalance)
 		h.GET("/future/all", r.getAllFutureOrder)
@@ -28,6 +29,7 @@ func NewOrderRoutes(handler *gin.RouterGroup, t usecase.Trade) {
 type allOrder struct {
 	Stock  []*entity.StockOrder  `json:"stock"`
 	Future []*entity.FutureOrder `json:"future"`
+	Option []*entity.OptionOrder `json:"option"`
 }
 
 // getAllOrder -.
@@ -52,9 +54,16 @@ func (r *orderRoutes) getAllFutureOrder(c *gin.Context) {
 		resp.ErrorResponse(c, http.StatusInternalServerError, err)
 		return
 	}
+
+	optionOrderArr, err := r.t.GetAllOptionOrder(c.Request.Context())
+	if err != nil {
+		resp.ErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
 	c.JSON(http.StatusOK, allOrder{
 		Stock:  stockOrderArr,
 		Future: futureOrderArr,
+		Option: optionOrderArr,
 	})
 }
 
@@ -90,6 +99,7 @@ func (r *orderRoutes) getAllFutureOrderByTradeDay(c *gin.Context) {
 type tradeBalance struct {
 	Stock  []*entity.StockTradeBalance  `json:"stock"`
 	Future []*entity.FutureTradeBalance `json:"future"`
+	Option []*entity.OptionTradeBalance `json:"option"`
 }
 
 // getAllTradeBalance -.
@@ -115,8 +125,15 @@ func (r *orderRoutes) getAllTradeBalance(c *gin.Context) {
 		return
 	}
 
+	allOptionArr, err := r.t.GetAllOptionTradeBalance(c.Request.Context())
+	if err != nil {
+		resp.ErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
 	c.JSON(http.StatusOK, tradeBalance{
 		Stock:  allStockArr,
 		Future: allFutureArr,
+		Option: allOptionArr,
 	})
 }
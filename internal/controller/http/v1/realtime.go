@@ -5,25 +5,29 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/toc-taiwan/toc-machine-trading/internal/controller/http/auth"
 	"github.com/toc-taiwan/toc-machine-trading/internal/controller/http/resp"
 	"github.com/toc-taiwan/toc-machine-trading/internal/controller/http/websocket/pick"
+	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
 	"github.com/toc-taiwan/toc-machine-trading/internal/usecase"
 )
 
 type realTimeRoutes struct {
-	t     usecase.RealTime
-	h     usecase.History
-	basic usecase.Basic
+	t         usecase.RealTime
+	h         usecase.History
+	basic     usecase.Basic
+	watchlist usecase.Watchlist
 }
 
-func NewRealTimeRoutes(handler *gin.RouterGroup, basic usecase.Basic, t usecase.RealTime, history usecase.History) {
+func NewRealTimeRoutes(handler *gin.RouterGroup, basic usecase.Basic, t usecase.RealTime, history usecase.History, watchlist usecase.Watchlist) {
 	r := &realTimeRoutes{
-		t:     t,
-		h:     history,
-		basic: basic,
+		t:         t,
+		h:         history,
+		basic:     basic,
+		watchlist: watchlist,
 	}
 
-	h := handler.Group("/stream")
+	h := handler.Group("/stream", auth.RequirePermission(entity.PermissionView))
 	{
 		h.PUT("/snapshot", r.getSnapshots)
 		h.GET("/ws/pick-future/:code", r.servePickFutureWS)
@@ -67,11 +71,11 @@ func (r *realTimeRoutes) getSnapshots(c *gin.Context) {
 }
 
 func (r *realTimeRoutes) servePickStockWS(c *gin.Context) {
-	pick.StartWSPickStock(c, r.t, false)
+	pick.StartWSPickStock(c, r.t, r.watchlist, false)
 }
 
 func (r *realTimeRoutes) servePickStockOddsWS(c *gin.Context) {
-	pick.StartWSPickStock(c, r.t, true)
+	pick.StartWSPickStock(c, r.t, r.watchlist, true)
 }
 
 func (r *realTimeRoutes) servePickFutureWS(c *gin.Context) {
@@ -0,0 +1,53 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/toc-taiwan/toc-machine-trading/internal/controller/http/auth"
+	"github.com/toc-taiwan/toc-machine-trading/internal/controller/http/resp"
+	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
+	"github.com/toc-taiwan/toc-machine-trading/internal/usecase"
+)
+
+type reportRoutes struct {
+	t usecase.Report
+}
+
+func NewReportRoutes(handler *gin.RouterGroup, t usecase.Report) {
+	r := &reportRoutes{t}
+
+	h := handler.Group("/report", auth.RequirePermission(entity.PermissionTrade))
+	{
+		h.GET("/daily/:date", r.getDailyReport)
+	}
+}
+
+// getDailyReport -.
+//
+//	@Tags		Report V1
+//	@Summary	Get end-of-day report by date
+//	@security	JWT
+//	@Accept		json
+//	@Produce	json
+//	@param		date	path		string	true	"date, 2006-01-02"
+//	@Success	200		{object}	entity.DailyReport{}
+//	@Failure	400		{object}	resp.Response{}
+//	@Failure	401		{object}	resp.Response{}
+//	@Failure	404		{object}	resp.Response{}
+//	@Failure	500		{object}	resp.Response{}
+//	@Router		/v1/report/daily/{date} [get]
+func (r *reportRoutes) getDailyReport(c *gin.Context) {
+	report, err := r.t.GetDailyReport(c.Request.Context(), c.Param("date"))
+	switch {
+	case errors.Is(err, usecase.ErrReportDateInvalid):
+		resp.ErrorResponse(c, http.StatusBadRequest, err)
+	case errors.Is(err, usecase.ErrReportNotFound):
+		resp.ErrorResponse(c, http.StatusNotFound, err)
+	case err != nil:
+		resp.ErrorResponse(c, http.StatusInternalServerError, err)
+	default:
+		c.JSON(http.StatusOK, report)
+	}
+}
@@ -2,10 +2,14 @@
 package v1
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/toc-taiwan/toc-machine-trading/internal/controller/http/auth"
+	"github.com/toc-taiwan/toc-machine-trading/internal/controller/http/resp"
 	"github.com/toc-taiwan/toc-machine-trading/internal/controller/http/websocket/target"
+	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
 	"github.com/toc-taiwan/toc-machine-trading/internal/usecase"
 )
 
@@ -16,10 +20,12 @@ type targetRoutes struct {
 func NewTargetRoutes(handler *gin.RouterGroup, t usecase.Target) {
 	r := &targetRoutes{t}
 
-	h := handler.Group("/targets")
+	h := handler.Group("/targets", auth.RequirePermission(entity.PermissionView))
 	{
 		h.GET("", r.getTargets)
 		h.GET("/ws", r.serveWS)
+		h.GET("/rule", r.getTargetRule)
+		h.PUT("/rule", auth.RequirePermission(entity.PermissionAdmin), r.updateTargetRule)
 	}
 }
 
@@ -39,3 +45,48 @@ func (r *targetRoutes) getTargets(c *gin.Context) {
 func (r *targetRoutes) serveWS(c *gin.Context) {
 	target.StartWSTargetStock(c, r.t)
 }
+
+// getTargetRule -.
+//
+//	@Tags		Targets V1
+//	@Summary	Get target selection rule
+//	@security	JWT
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{object}	entity.TargetRule{}
+//	@Router		/v1/targets/rule [get]
+func (r *targetRoutes) getTargetRule(c *gin.Context) {
+	c.JSON(http.StatusOK, r.t.GetTargetRule(c.Request.Context()))
+}
+
+// updateTargetRule -.
+//
+//	@Tags		Targets V1
+//	@Summary	Update target selection rule, applies from the next target search
+//	@security	JWT
+//	@Accept		json
+//	@Produce	json
+//	@param		body	body	entity.TargetRule{}	true	"Body"
+//	@Success	200
+//	@Failure	400	{object}	resp.Response{}
+//	@Failure	401	{object}	resp.Response{}
+//	@Failure	403	{object}	resp.Response{}
+//	@Failure	500	{object}	resp.Response{}
+//	@Router		/v1/targets/rule [put]
+func (r *targetRoutes) updateTargetRule(c *gin.Context) {
+	p := entity.TargetRule{}
+	if err := c.ShouldBindJSON(&p); err != nil {
+		resp.ErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := r.t.UpdateTargetRule(c.Request.Context(), &p); err != nil {
+		if errors.Is(err, usecase.ErrTargetRuleInvalid) {
+			resp.ErrorResponse(c, http.StatusBadRequest, err)
+			return
+		}
+		resp.ErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, nil)
+}
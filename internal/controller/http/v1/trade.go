@@ -2,11 +2,13 @@
 package v1
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/toc-taiwan/toc-machine-trading/internal/controller/http/auth"
 	"github.com/toc-taiwan/toc-machine-trading/internal/controller/http/resp"
+	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
 	"github.com/toc-taiwan/toc-machine-trading/internal/usecase"
 )
 
@@ -17,12 +19,15 @@ type tradeRoutes struct {
 func NewTradeRoutes(handler *gin.RouterGroup, t usecase.Trade) {
 	r := &tradeRoutes{t}
 
-	h := handler.Group("/trade")
+	h := handler.Group("/trade", auth.RequirePermission(entity.PermissionTrade))
 	{
 		h.PUT("/stock/buy/odd", r.checkUserAuth, r.buyOddStock)
 		h.PUT("/stock/sell/odd", r.checkUserAuth, r.sellOddStock)
+		h.PUT("/option/buy", r.checkUserAuth, r.buyOption)
+		h.PUT("/option/sell", r.checkUserAuth, r.sellOption)
 		h.PUT("/cancel", r.checkUserAuth, r.cancelOrder)
 		h.GET("/inventory/stock", r.getLatestInventoryStock)
+		h.GET("/future/expiry", r.getFutureExpiryWarnings)
 	}
 }
 
@@ -36,6 +41,12 @@ type oddStockRequest struct {
 	Share int64   `json:"share"`
 }
 
+type optionRequest struct {
+	Code     string  `json:"code"`
+	Price    float64 `json:"price"`
+	Quantity int64   `json:"quantity"`
+}
+
 type tradeResponse struct {
 	OrderID string `json:"order_id"`
 	Status  string `json:"status"`
@@ -59,6 +70,7 @@ func (r *tradeRoutes) checkUserAuth(c *gin.Context) {
 //	@param		body	body		oddStockRequest{}	true	"Body"
 //	@Success	200		{object}	tradeResponse{}
 //	@failure	401		{object}	resp.Response{}
+//	@failure	403		{object}	resp.Response{}
 //	@Router		/v1/trade/stock/buy/odd [put]
 func (r *tradeRoutes) buyOddStock(c *gin.Context) {
 	p := oddStockRequest{}
@@ -88,6 +100,7 @@ func (r *tradeRoutes) buyOddStock(c *gin.Context) {
 //	@param		body	body		oddStockRequest{}	true	"Body"
 //	@Success	200		{object}	tradeResponse{}
 //	@failure	401		{object}	resp.Response{}
+//	@failure	403		{object}	resp.Response{}
 //	@Router		/v1/trade/stock/sell/odd [put]
 func (r *tradeRoutes) sellOddStock(c *gin.Context) {
 	p := oddStockRequest{}
@@ -107,6 +120,98 @@ func (r *tradeRoutes) sellOddStock(c *gin.Context) {
 	})
 }
 
+// buyOption -.
+//
+//	@Tags		Trade V1
+//	@Summary	Buy option
+//	@security	JWT
+//	@Accept		json
+//	@Produce	json
+//	@param		body	body		optionRequest{}	true	"Body"
+//	@Success	200		{object}	tradeResponse{}
+//	@failure	400		{object}	resp.Response{}
+//	@failure	401		{object}	resp.Response{}
+//	@failure	403		{object}	resp.Response{}
+//	@Router		/v1/trade/option/buy [put]
+func (r *tradeRoutes) buyOption(c *gin.Context) {
+	p := optionRequest{}
+	if err := c.ShouldBindJSON(&p); err != nil {
+		resp.ErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+	if p.Price <= 0 || p.Quantity <= 0 {
+		resp.ErrorResponse(c, http.StatusBadRequest, usecase.ErrOptionOrderInvalid)
+		return
+	}
+
+	id, status, err := r.t.BuyOption(&entity.OptionOrder{
+		Code:     p.Code,
+		Quantity: p.Quantity,
+		OrderDetail: entity.OrderDetail{
+			Price: p.Price,
+		},
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrOptionNotFound), errors.Is(err, usecase.ErrOptionNotSupported), errors.Is(err, usecase.ErrOptionOrderInvalid):
+			resp.ErrorResponse(c, http.StatusBadRequest, err)
+		default:
+			resp.ErrorResponse(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+	c.JSON(http.StatusOK, tradeResponse{
+		OrderID: id,
+		Status:  status.String(),
+	})
+}
+
+// sellOption -.
+//
+//	@Tags		Trade V1
+//	@Summary	Sell option
+//	@security	JWT
+//	@Accept		json
+//	@Produce	json
+//	@param		body	body		optionRequest{}	true	"Body"
+//	@Success	200		{object}	tradeResponse{}
+//	@failure	400		{object}	resp.Response{}
+//	@failure	401		{object}	resp.Response{}
+//	@failure	403		{object}	resp.Response{}
+//	@Router		/v1/trade/option/sell [put]
+func (r *tradeRoutes) sellOption(c *gin.Context) {
+	p := optionRequest{}
+	if err := c.ShouldBindJSON(&p); err != nil {
+		resp.ErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+	if p.Price <= 0 || p.Quantity <= 0 {
+		resp.ErrorResponse(c, http.StatusBadRequest, usecase.ErrOptionOrderInvalid)
+		return
+	}
+
+	id, status, err := r.t.SellOption(&entity.OptionOrder{
+		Code:     p.Code,
+		Quantity: p.Quantity,
+		OrderDetail: entity.OrderDetail{
+			Price: p.Price,
+		},
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrOptionNotFound), errors.Is(err, usecase.ErrOptionNotSupported), errors.Is(err, usecase.ErrOptionOrderInvalid):
+			resp.ErrorResponse(c, http.StatusBadRequest, err)
+		default:
+			resp.ErrorResponse(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+	c.JSON(http.StatusOK, tradeResponse{
+		OrderID: id,
+		Status:  status.String(),
+	})
+}
+
 // cancelOrder -.
 //
 //	@Tags		Trade V1
@@ -117,6 +222,7 @@ func (r *tradeRoutes) sellOddStock(c *gin.Context) {
 //	@param		body	body	cancelRequest{}	true	"Body"
 //	@Success	200
 //	@failure	401	{object}	resp.Response{}
+//	@failure	403	{object}	resp.Response{}
 //	@Router		/v1/trade/cancel [put]
 func (r *tradeRoutes) cancelOrder(c *gin.Context) {
 	p := cancelRequest{}
@@ -141,6 +247,7 @@ func (r *tradeRoutes) cancelOrder(c *gin.Context) {
 //	@Produce	json
 //	@Success	200	{object}	[]entity.InventoryStock{}
 //	@failure	401	{object}	resp.Response{}
+//	@failure	403	{object}	resp.Response{}
 //	@Router		/v1/trade/inventory/stock [get]
 func (r *tradeRoutes) getLatestInventoryStock(c *gin.Context) {
 	stocks, err := r.t.GetLatestInventoryStock()
@@ -150,3 +257,24 @@ func (r *tradeRoutes) getLatestInventoryStock(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, stocks)
 }
+
+// getFutureExpiryWarnings -.
+//
+//	@Tags		Trade V1
+//	@Summary	Get open future positions close to delivery and the contract to roll to
+//	@security	JWT
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{object}	[]entity.FutureExpiryWarning{}
+//	@failure	401	{object}	resp.Response{}
+//	@failure	403	{object}	resp.Response{}
+//	@failure	500	{object}	resp.Response{}
+//	@Router		/v1/trade/future/expiry [get]
+func (r *tradeRoutes) getFutureExpiryWarnings(c *gin.Context) {
+	warnings, err := r.t.GetFutureExpiryWarnings(c.Request.Context())
+	if err != nil {
+		resp.ErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, warnings)
+}
@@ -1,11 +1,13 @@
 package v1
 
 import (
+	"context"
+	"errors"
 	"net/http"
 
-	jwt "github.com/appleboy/gin-jwt/v2"
 	"github.com/gin-gonic/gin"
 	"github.com/toc-taiwan/toc-machine-trading/internal/controller/http/auth"
+	"github.com/toc-taiwan/toc-machine-trading/internal/controller/http/limiter"
 	"github.com/toc-taiwan/toc-machine-trading/internal/controller/http/resp"
 	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
 	"github.com/toc-taiwan/toc-machine-trading/internal/usecase"
@@ -13,26 +15,45 @@ import (
 
 type userRoutes struct {
 	system     usecase.System
-	jwtHandler *jwt.GinJWTMiddleware
+	jwtHandler *auth.JWT
 }
 
-func NewUserRoutes(public *gin.RouterGroup, private *gin.RouterGroup, jwtHandler *jwt.GinJWTMiddleware, system usecase.System) {
+func NewUserRoutes(public *gin.RouterGroup, private *gin.RouterGroup, jwtHandler *auth.JWT, rateLimiter *limiter.Limiter, system usecase.System) {
 	r := &userRoutes{
 		system:     system,
 		jwtHandler: jwtHandler,
 	}
 
-	public.POST("/login", r.loginHandler)
-	public.GET("/refresh", r.refreshTokenHandler)
+	public.POST("/login", rateLimiter.Protect(limiter.JSONField("username")), r.loginHandler)
+	public.GET("/refresh", rateLimiter.Protect(nil), r.refreshTokenHandler)
+	public.POST("/refresh", rateLimiter.Protect(nil), r.refreshSessionHandler)
 
-	public.POST("/user", r.newUserHandler)
-	public.POST("/user/verify/:user/:code", r.verifyEmailHandler)
+	public.POST("/user", rateLimiter.Protect(limiter.JSONField("username")), r.newUserHandler)
+	public.POST("/user/verify/:user/:code", rateLimiter.Protect(limiter.Param("user")), r.verifyEmailHandler)
+	public.POST("/user/verify/resend", rateLimiter.Protect(limiter.JSONField("email")), r.resendVerificationHandler)
+	public.POST("/user/password/forgot", rateLimiter.Protect(limiter.JSONField("email")), r.forgotPasswordHandler)
+	public.POST("/user/password/reset", rateLimiter.Protect(limiter.JSONField("email")), r.resetPasswordHandler)
 
 	private.GET("/user/info", r.getUserInfo)
-	private.PUT("/user/auth", r.updateAuthTradeUser)
+
+	// account security can only be managed by login session, not api key
+	session := private.Group("", auth.RequireSession())
+	{
+		session.PUT("/user/password", r.changePasswordHandler)
+		session.GET("/user/sessions", r.getUserSessions)
+		session.DELETE("/user/sessions/:session_id", r.revokeUserSession)
+		session.GET("/user/totp", r.getTOTPStatus)
+		session.POST("/user/totp/enroll", r.enrollTOTP)
+		session.POST("/user/totp/confirm", r.confirmTOTP)
+		session.POST("/user/totp/disable", r.disableTOTP)
+		session.GET("/user/api-keys", r.getAPIKeys)
+		session.POST("/user/api-keys", r.createAPIKey)
+		session.DELETE("/user/api-keys/:key_id", r.revokeAPIKey)
+	}
+	private.PUT("/user/auth", auth.RequirePermission(entity.PermissionAdmin), r.updateAuthTradeUser)
 	private.GET("/user/push-token", r.getUserPushTokenStatus)
 	private.PUT("/user/push-token", r.updateUserPushToken)
-	private.DELETE("/user/push-token", r.clearAllPushToken)
+	private.DELETE("/user/push-token", auth.RequirePermission(entity.PermissionAdmin), r.clearAllPushToken)
 
 	private.GET("/logout", r.logutHandler)
 }
@@ -46,6 +67,7 @@ func NewUserRoutes(public *gin.RouterGroup, private *gin.RouterGroup, jwtHandler
 //	@param		body	body	entity.NewUser{}	true	"Body"
 //	@success	200
 //	@failure	400	{object}	resp.Response{}
+//	@failure	429	{object}	resp.Response{}
 //	@failure	500	{object}	resp.Response{}
 //	@router		/v1/user [post]
 func (u *userRoutes) newUserHandler(c *gin.Context) {
@@ -71,6 +93,7 @@ func (u *userRoutes) newUserHandler(c *gin.Context) {
 //	@param		code	path	string	true	"code"
 //	@success	200
 //	@failure	400	{string}	string
+//	@failure	429	{object}	resp.Response{}
 //	@failure	500	{string}	string
 //	@router		/v1/user/verify/{user}/{code} [post]
 func (u *userRoutes) verifyEmailHandler(c *gin.Context) {
@@ -91,6 +114,45 @@ func (u *userRoutes) verifyEmailHandler(c *gin.Context) {
 	c.JSON(http.StatusOK, nil)
 }
 
+type resendVerificationRequest struct {
+	Email string `json:"email"`
+}
+
+// resendVerificationHandler _.
+//
+//	@tags		User V1
+//	@Summary	Resend verification email
+//	@accept		json
+//	@produce	json
+//	@param		body	body	resendVerificationRequest{}	true	"Body"
+//	@success	200
+//	@failure	400	{object}	resp.Response{}
+//	@failure	429	{object}	resp.Response{}
+//	@failure	500	{object}	resp.Response{}
+//	@router		/v1/user/verify/resend [post]
+func (u *userRoutes) resendVerificationHandler(c *gin.Context) {
+	p := resendVerificationRequest{}
+	if err := c.ShouldBindJSON(&p); err != nil {
+		resp.ErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if p.Email == "" {
+		resp.ErrorResponse(c, http.StatusBadRequest, "email is required")
+		return
+	}
+
+	if err := u.system.ResendVerification(c.Request.Context(), p.Email); err != nil {
+		if errors.Is(err, usecase.ErrResendTooFrequent) {
+			resp.ErrorResponse(c, http.StatusTooManyRequests, err)
+			return
+		}
+		resp.ErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, nil)
+}
+
 // loginHandler _.
 //
 //	@tags		User V1
@@ -99,6 +161,7 @@ func (u *userRoutes) verifyEmailHandler(c *gin.Context) {
 //	@produce	json
 //	@param		body	body		auth.LoginBody{}	true	"Body"
 //	@success	200		{object}	auth.LoginResponseBody{}
+//	@failure	429		{object}	resp.Response{}
 //	@router		/v1/login [post]
 func (u *userRoutes) loginHandler(c *gin.Context) {
 	u.jwtHandler.LoginHandler(c)
@@ -126,14 +189,34 @@ func (u *userRoutes) logutHandler(c *gin.Context) {
 //	@produce	json
 //	@success	200	{object}	auth.LoginResponseBody{}
 //	@failure	401	{object}	resp.Response{}
+//	@failure	429	{object}	resp.Response{}
 //	@router		/v1/refresh [get]
 func (u *userRoutes) refreshTokenHandler(c *gin.Context) {
 	u.jwtHandler.RefreshHandler(c)
 }
 
+// refreshSessionHandler _.
+//
+//	@tags		User V1
+//	@Summary	Refresh token by session refresh token
+//	@accept		json
+//	@produce	json
+//	@param		body	body		auth.RefreshBody{}	true	"Body"
+//	@success	200		{object}	auth.LoginResponseBody{}
+//	@failure	400		{object}	resp.Response{}
+//	@failure	401		{object}	resp.Response{}
+//	@failure	429		{object}	resp.Response{}
+//	@failure	500		{object}	resp.Response{}
+//	@router		/v1/refresh [post]
+func (u *userRoutes) refreshSessionHandler(c *gin.Context) {
+	u.jwtHandler.RefreshSessionHandler(c)
+}
+
 type userPushTokenRequest struct {
 	PushToken string `json:"push_token"`
 	Enabled   bool   `json:"enabled"`
+	// OrderPush receives order updates even if the user is not authorized to trade
+	OrderPush bool `json:"order_push"`
 }
 
 // updateUserPushToken _.
@@ -167,7 +250,7 @@ func (u *userRoutes) updateUserPushToken(c *gin.Context) {
 		return
 	}
 
-	if err := u.system.InsertPushToken(c.Request.Context(), p.PushToken, username, p.Enabled); err != nil {
+	if err := u.system.InsertPushToken(c.Request.Context(), p.PushToken, username, p.Enabled, p.OrderPush); err != nil {
 		resp.ErrorResponse(c, http.StatusInternalServerError, err)
 		return
 	}
@@ -214,6 +297,7 @@ func (u *userRoutes) getUserPushTokenStatus(c *gin.Context) {
 //	@accept		json
 //	@produce	json
 //	@success	200
+//	@failure	403	{object}	resp.Response{}
 //	@failure	500	{object}	resp.Response{}
 //	@router		/v1/user/push-token [delete]
 func (u *userRoutes) clearAllPushToken(c *gin.Context) {
@@ -233,6 +317,7 @@ func (u *userRoutes) clearAllPushToken(c *gin.Context) {
 //	@produce	json
 //	@success	200
 //	@failure	401	{object}	resp.Response{}
+//	@failure	403	{object}	resp.Response{}
 //	@router		/v1/user/auth [put]
 func (u *userRoutes) updateAuthTradeUser(c *gin.Context) {
 	u.system.UpdateAuthTradeUser()
@@ -262,3 +347,421 @@ func (u *userRoutes) getUserInfo(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, info)
 }
+
+type changePasswordRequest struct {
+	OldPassword string `json:"old_password"`
+	NewPassword string `json:"new_password"`
+}
+
+// changePasswordHandler _.
+//
+//	@tags		User V1
+//	@Summary	Change password
+//	@security	JWT
+//	@accept		json
+//	@produce	json
+//	@param		body	body	changePasswordRequest{}	true	"Body"
+//	@success	200
+//	@failure	400	{object}	resp.Response{}
+//	@failure	401	{object}	resp.Response{}
+//	@failure	500	{object}	resp.Response{}
+//	@router		/v1/user/password [put]
+func (u *userRoutes) changePasswordHandler(c *gin.Context) {
+	p := changePasswordRequest{}
+	if err := c.ShouldBindJSON(&p); err != nil {
+		resp.ErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	username := auth.ExtractUsername(c)
+	if username == "" {
+		resp.ErrorResponse(c, http.StatusBadRequest, "username is required in token")
+		return
+	}
+
+	if err := u.system.ChangePassword(c.Request.Context(), username, p.OldPassword, p.NewPassword); err != nil {
+		if errors.Is(err, usecase.ErrPasswordNotMatch) || errors.Is(err, usecase.ErrPasswordInvalid) {
+			resp.ErrorResponse(c, http.StatusBadRequest, err)
+			return
+		}
+		resp.ErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, nil)
+}
+
+type forgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+// forgotPasswordHandler _.
+//
+//	@tags		User V1
+//	@Summary	Send password reset code to email
+//	@accept		json
+//	@produce	json
+//	@param		body	body	forgotPasswordRequest{}	true	"Body"
+//	@success	200
+//	@failure	400	{object}	resp.Response{}
+//	@failure	429	{object}	resp.Response{}
+//	@failure	500	{object}	resp.Response{}
+//	@router		/v1/user/password/forgot [post]
+func (u *userRoutes) forgotPasswordHandler(c *gin.Context) {
+	p := forgotPasswordRequest{}
+	if err := c.ShouldBindJSON(&p); err != nil {
+		resp.ErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if p.Email == "" {
+		resp.ErrorResponse(c, http.StatusBadRequest, "email is required")
+		return
+	}
+
+	if err := u.system.SendPasswordResetCode(c.Request.Context(), p.Email); err != nil {
+		resp.ErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, nil)
+}
+
+type resetPasswordRequest struct {
+	Email    string `json:"email"`
+	Code     string `json:"code"`
+	Password string `json:"password"`
+}
+
+// resetPasswordHandler _.
+//
+//	@tags		User V1
+//	@Summary	Reset password by code
+//	@accept		json
+//	@produce	json
+//	@param		body	body	resetPasswordRequest{}	true	"Body"
+//	@success	200
+//	@failure	400	{object}	resp.Response{}
+//	@failure	429	{object}	resp.Response{}
+//	@failure	500	{object}	resp.Response{}
+//	@router		/v1/user/password/reset [post]
+func (u *userRoutes) resetPasswordHandler(c *gin.Context) {
+	p := resetPasswordRequest{}
+	if err := c.ShouldBindJSON(&p); err != nil {
+		resp.ErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if p.Email == "" || p.Code == "" {
+		resp.ErrorResponse(c, http.StatusBadRequest, "email and code are required")
+		return
+	}
+
+	if err := u.system.ResetPassword(c.Request.Context(), p.Email, p.Code, p.Password); err != nil {
+		if errors.Is(err, usecase.ErrResetCodeInvalid) || errors.Is(err, usecase.ErrPasswordInvalid) {
+			resp.ErrorResponse(c, http.StatusBadRequest, err)
+			return
+		}
+		resp.ErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, nil)
+}
+
+// getUserSessions _.
+//
+//	@tags		User V1
+//	@Summary	List active sessions of user
+//	@security	JWT
+//	@accept		json
+//	@produce	json
+//	@success	200	{object}	[]entity.Session{}
+//	@failure	400	{object}	resp.Response{}
+//	@failure	401	{object}	resp.Response{}
+//	@failure	500	{object}	resp.Response{}
+//	@router		/v1/user/sessions [get]
+func (u *userRoutes) getUserSessions(c *gin.Context) {
+	username := auth.ExtractUsername(c)
+	if username == "" {
+		resp.ErrorResponse(c, http.StatusBadRequest, "username is required in token")
+		return
+	}
+
+	sessions, err := u.system.GetActiveSessions(c.Request.Context(), username, auth.ExtractSessionID(c))
+	if err != nil {
+		resp.ErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, sessions)
+}
+
+// revokeUserSession _.
+//
+//	@tags		User V1
+//	@Summary	Revoke a session of user
+//	@security	JWT
+//	@accept		json
+//	@produce	json
+//	@param		session_id	path	string	true	"session_id"
+//	@success	200
+//	@failure	400	{object}	resp.Response{}
+//	@failure	401	{object}	resp.Response{}
+//	@failure	404	{object}	resp.Response{}
+//	@failure	500	{object}	resp.Response{}
+//	@router		/v1/user/sessions/{session_id} [delete]
+func (u *userRoutes) revokeUserSession(c *gin.Context) {
+	username := auth.ExtractUsername(c)
+	if username == "" {
+		resp.ErrorResponse(c, http.StatusBadRequest, "username is required in token")
+		return
+	}
+
+	sessionID := c.Param("session_id")
+	if sessionID == "" {
+		resp.ErrorResponse(c, http.StatusBadRequest, "session_id is required")
+		return
+	}
+
+	if err := u.system.RevokeSession(c.Request.Context(), username, sessionID); err != nil {
+		if errors.Is(err, usecase.ErrSessionNotFound) {
+			resp.ErrorResponse(c, http.StatusNotFound, err)
+			return
+		}
+		resp.ErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, nil)
+}
+
+// getTOTPStatus _.
+//
+//	@tags		User V1
+//	@Summary	Get totp status of user
+//	@security	JWT
+//	@accept		json
+//	@produce	json
+//	@success	200	{object}	entity.TOTPStatus{}
+//	@failure	400	{object}	resp.Response{}
+//	@failure	401	{object}	resp.Response{}
+//	@failure	500	{object}	resp.Response{}
+//	@router		/v1/user/totp [get]
+func (u *userRoutes) getTOTPStatus(c *gin.Context) {
+	username := auth.ExtractUsername(c)
+	if username == "" {
+		resp.ErrorResponse(c, http.StatusBadRequest, "username is required in token")
+		return
+	}
+
+	status, err := u.system.GetTOTPStatus(c.Request.Context(), username)
+	if err != nil {
+		resp.ErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, status)
+}
+
+// enrollTOTP _.
+//
+//	@tags		User V1
+//	@Summary	Enroll totp, secret and backup codes are only shown once
+//	@security	JWT
+//	@accept		json
+//	@produce	json
+//	@success	200	{object}	entity.TOTPEnrollment{}
+//	@failure	400	{object}	resp.Response{}
+//	@failure	401	{object}	resp.Response{}
+//	@failure	500	{object}	resp.Response{}
+//	@router		/v1/user/totp/enroll [post]
+func (u *userRoutes) enrollTOTP(c *gin.Context) {
+	username := auth.ExtractUsername(c)
+	if username == "" {
+		resp.ErrorResponse(c, http.StatusBadRequest, "username is required in token")
+		return
+	}
+
+	enrollment, err := u.system.EnrollTOTP(c.Request.Context(), username)
+	if err != nil {
+		if errors.Is(err, usecase.ErrTOTPAlreadyEnabled) {
+			resp.ErrorResponse(c, http.StatusBadRequest, err)
+			return
+		}
+		resp.ErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, enrollment)
+}
+
+type totpCodeRequest struct {
+	Code string `json:"code"`
+}
+
+// confirmTOTP _.
+//
+//	@tags		User V1
+//	@Summary	Confirm totp enrollment by code
+//	@security	JWT
+//	@accept		json
+//	@produce	json
+//	@param		body	body	totpCodeRequest{}	true	"Body"
+//	@success	200
+//	@failure	400	{object}	resp.Response{}
+//	@failure	401	{object}	resp.Response{}
+//	@failure	500	{object}	resp.Response{}
+//	@router		/v1/user/totp/confirm [post]
+func (u *userRoutes) confirmTOTP(c *gin.Context) {
+	u.handleTOTPCode(c, u.system.ConfirmTOTP)
+}
+
+// disableTOTP _.
+//
+//	@tags		User V1
+//	@Summary	Disable totp by code or backup code
+//	@security	JWT
+//	@accept		json
+//	@produce	json
+//	@param		body	body	totpCodeRequest{}	true	"Body"
+//	@success	200
+//	@failure	400	{object}	resp.Response{}
+//	@failure	401	{object}	resp.Response{}
+//	@failure	500	{object}	resp.Response{}
+//	@router		/v1/user/totp/disable [post]
+func (u *userRoutes) disableTOTP(c *gin.Context) {
+	u.handleTOTPCode(c, u.system.DisableTOTP)
+}
+
+func (u *userRoutes) handleTOTPCode(c *gin.Context, fn func(ctx context.Context, username, code string) error) {
+	p := totpCodeRequest{}
+	if err := c.ShouldBindJSON(&p); err != nil {
+		resp.ErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if p.Code == "" {
+		resp.ErrorResponse(c, http.StatusBadRequest, "code is required")
+		return
+	}
+
+	username := auth.ExtractUsername(c)
+	if username == "" {
+		resp.ErrorResponse(c, http.StatusBadRequest, "username is required in token")
+		return
+	}
+
+	if err := fn(c.Request.Context(), username, p.Code); err != nil {
+		var ucErr *usecase.UseCaseError
+		if errors.As(err, &ucErr) {
+			resp.ErrorResponse(c, http.StatusBadRequest, err)
+			return
+		}
+		resp.ErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, nil)
+}
+
+// getAPIKeys _.
+//
+//	@tags		User V1
+//	@Summary	List api keys of user
+//	@security	JWT
+//	@accept		json
+//	@produce	json
+//	@success	200	{object}	[]entity.APIKey{}
+//	@failure	400	{object}	resp.Response{}
+//	@failure	401	{object}	resp.Response{}
+//	@failure	403	{object}	resp.Response{}
+//	@failure	500	{object}	resp.Response{}
+//	@router		/v1/user/api-keys [get]
+func (u *userRoutes) getAPIKeys(c *gin.Context) {
+	username := auth.ExtractUsername(c)
+	if username == "" {
+		resp.ErrorResponse(c, http.StatusBadRequest, "username is required in token")
+		return
+	}
+
+	keys, err := u.system.GetAPIKeys(c.Request.Context(), username)
+	if err != nil {
+		resp.ErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, keys)
+}
+
+// createAPIKey _.
+//
+//	@tags		User V1
+//	@Summary	Create api key, the key is only shown once
+//	@security	JWT
+//	@accept		json
+//	@produce	json
+//	@param		body	body		entity.NewAPIKey{}	true	"Body"
+//	@success	200		{object}	entity.CreatedAPIKey{}
+//	@failure	400		{object}	resp.Response{}
+//	@failure	401		{object}	resp.Response{}
+//	@failure	403		{object}	resp.Response{}
+//	@failure	500		{object}	resp.Response{}
+//	@router		/v1/user/api-keys [post]
+func (u *userRoutes) createAPIKey(c *gin.Context) {
+	p := entity.NewAPIKey{}
+	if err := c.ShouldBindJSON(&p); err != nil {
+		resp.ErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	username := auth.ExtractUsername(c)
+	if username == "" {
+		resp.ErrorResponse(c, http.StatusBadRequest, "username is required in token")
+		return
+	}
+
+	key, err := u.system.CreateAPIKey(c.Request.Context(), username, &p)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrPermissionDenied):
+			resp.ErrorResponse(c, http.StatusForbidden, err)
+		case errors.As(err, new(*usecase.UseCaseError)):
+			resp.ErrorResponse(c, http.StatusBadRequest, err)
+		default:
+			resp.ErrorResponse(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+	c.JSON(http.StatusOK, key)
+}
+
+// revokeAPIKey _.
+//
+//	@tags		User V1
+//	@Summary	Revoke api key
+//	@security	JWT
+//	@accept		json
+//	@produce	json
+//	@param		key_id	path	string	true	"key_id"
+//	@success	200
+//	@failure	400	{object}	resp.Response{}
+//	@failure	401	{object}	resp.Response{}
+//	@failure	403	{object}	resp.Response{}
+//	@failure	404	{object}	resp.Response{}
+//	@failure	500	{object}	resp.Response{}
+//	@router		/v1/user/api-keys/{key_id} [delete]
+func (u *userRoutes) revokeAPIKey(c *gin.Context) {
+	username := auth.ExtractUsername(c)
+	if username == "" {
+		resp.ErrorResponse(c, http.StatusBadRequest, "username is required in token")
+		return
+	}
+
+	keyID := c.Param("key_id")
+	if keyID == "" {
+		resp.ErrorResponse(c, http.StatusBadRequest, "key_id is required")
+		return
+	}
+
+	if err := u.system.RevokeAPIKey(c.Request.Context(), username, keyID); err != nil {
+		if errors.Is(err, usecase.ErrAPIKeyNotFound) {
+			resp.ErrorResponse(c, http.StatusNotFound, err)
+			return
+		}
+		resp.ErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, nil)
+}
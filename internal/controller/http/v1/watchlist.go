@@ -0,0 +1,304 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/toc-taiwan/toc-machine-trading/internal/controller/http/auth"
+	"github.com/toc-taiwan/toc-machine-trading/internal/controller/http/resp"
+	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
+	"github.com/toc-taiwan/toc-machine-trading/internal/usecase"
+)
+
+type watchlistRoutes struct {
+	t usecase.Watchlist
+}
+
+func NewWatchlistRoutes(handler *gin.RouterGroup, t usecase.Watchlist) {
+	r := &watchlistRoutes{t}
+
+	h := handler.Group("/watchlist", auth.RequirePermission(entity.PermissionView))
+	{
+		h.GET("", r.getWatchlists)
+		h.POST("", r.createWatchlist)
+		h.PUT("/:id", r.updateWatchlist)
+		h.DELETE("/:id", r.deleteWatchlist)
+		h.PUT("/:id/items", r.putWatchlistItem)
+		h.DELETE("/:id/items/:code", r.deleteWatchlistItem)
+		h.PUT("/:id/sort", r.sortWatchlistItem)
+	}
+}
+
+// getWatchlists -.
+//
+//	@Tags		Watchlist V1
+//	@Summary	List watchlists of user with items
+//	@security	JWT
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{object}	[]entity.Watchlist{}
+//	@Failure	400	{object}	resp.Response{}
+//	@Failure	401	{object}	resp.Response{}
+//	@Failure	500	{object}	resp.Response{}
+//	@Router		/v1/watchlist [get]
+func (r *watchlistRoutes) getWatchlists(c *gin.Context) {
+	username := auth.ExtractUsername(c)
+	if username == "" {
+		resp.ErrorResponse(c, http.StatusBadRequest, "username is required in token")
+		return
+	}
+
+	lists, err := r.t.GetWatchlists(c.Request.Context(), username)
+	if err != nil {
+		resp.ErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, lists)
+}
+
+// createWatchlist -.
+//
+//	@Tags		Watchlist V1
+//	@Summary	Create watchlist, the first one is default
+//	@security	JWT
+//	@Accept		json
+//	@Produce	json
+//	@param		body	body		entity.NewWatchlist{}	true	"Body"
+//	@Success	200		{object}	entity.Watchlist{}
+//	@Failure	400		{object}	resp.Response{}
+//	@Failure	401		{object}	resp.Response{}
+//	@Failure	500		{object}	resp.Response{}
+//	@Router		/v1/watchlist [post]
+func (r *watchlistRoutes) createWatchlist(c *gin.Context) {
+	username := auth.ExtractUsername(c)
+	if username == "" {
+		resp.ErrorResponse(c, http.StatusBadRequest, "username is required in token")
+		return
+	}
+
+	p := entity.NewWatchlist{}
+	if err := c.ShouldBindJSON(&p); err != nil {
+		resp.ErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	list, err := r.t.CreateWatchlist(c.Request.Context(), username, &p)
+	if err != nil {
+		r.watchlistError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+// updateWatchlist -.
+//
+//	@Tags		Watchlist V1
+//	@Summary	Update watchlist name, sort or make it default
+//	@security	JWT
+//	@Accept		json
+//	@Produce	json
+//	@param		id		path		int						true	"id"
+//	@param		body	body		entity.NewWatchlist{}	true	"Body"
+//	@Success	200		{object}	entity.Watchlist{}
+//	@Failure	400		{object}	resp.Response{}
+//	@Failure	401		{object}	resp.Response{}
+//	@Failure	404		{object}	resp.Response{}
+//	@Failure	500		{object}	resp.Response{}
+//	@Router		/v1/watchlist/{id} [put]
+func (r *watchlistRoutes) updateWatchlist(c *gin.Context) {
+	username := auth.ExtractUsername(c)
+	if username == "" {
+		resp.ErrorResponse(c, http.StatusBadRequest, "username is required in token")
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		resp.ErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	p := entity.NewWatchlist{}
+	if err = c.ShouldBindJSON(&p); err != nil {
+		resp.ErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	list, err := r.t.UpdateWatchlist(c.Request.Context(), username, id, &p)
+	if err != nil {
+		r.watchlistError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+// deleteWatchlist -.
+//
+//	@Tags		Watchlist V1
+//	@Summary	Delete watchlist with its items
+//	@security	JWT
+//	@Accept		json
+//	@Produce	json
+//	@param		id	path	int	true	"id"
+//	@Success	200
+//	@Failure	400	{object}	resp.Response{}
+//	@Failure	401	{object}	resp.Response{}
+//	@Failure	404	{object}	resp.Response{}
+//	@Failure	500	{object}	resp.Response{}
+//	@Router		/v1/watchlist/{id} [delete]
+func (r *watchlistRoutes) deleteWatchlist(c *gin.Context) {
+	username := auth.ExtractUsername(c)
+	if username == "" {
+		resp.ErrorResponse(c, http.StatusBadRequest, "username is required in token")
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		resp.ErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err = r.t.DeleteWatchlist(c.Request.Context(), username, id); err != nil {
+		r.watchlistError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, nil)
+}
+
+// putWatchlistItem -.
+//
+//	@Tags		Watchlist V1
+//	@Summary	Add stock to watchlist or update its note and sort
+//	@security	JWT
+//	@Accept		json
+//	@Produce	json
+//	@param		id		path		int							true	"id"
+//	@param		body	body		entity.NewWatchlistItem{}	true	"Body"
+//	@Success	200		{object}	entity.Watchlist{}
+//	@Failure	400		{object}	resp.Response{}
+//	@Failure	401		{object}	resp.Response{}
+//	@Failure	404		{object}	resp.Response{}
+//	@Failure	500		{object}	resp.Response{}
+//	@Router		/v1/watchlist/{id}/items [put]
+func (r *watchlistRoutes) putWatchlistItem(c *gin.Context) {
+	username := auth.ExtractUsername(c)
+	if username == "" {
+		resp.ErrorResponse(c, http.StatusBadRequest, "username is required in token")
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		resp.ErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	p := entity.NewWatchlistItem{}
+	if err = c.ShouldBindJSON(&p); err != nil {
+		resp.ErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	list, err := r.t.PutWatchlistItem(c.Request.Context(), username, id, &p)
+	if err != nil {
+		r.watchlistError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+// deleteWatchlistItem -.
+//
+//	@Tags		Watchlist V1
+//	@Summary	Remove stock from watchlist
+//	@security	JWT
+//	@Accept		json
+//	@Produce	json
+//	@param		id		path		int		true	"id"
+//	@param		code	path		string	true	"code"
+//	@Success	200		{object}	entity.Watchlist{}
+//	@Failure	400		{object}	resp.Response{}
+//	@Failure	401		{object}	resp.Response{}
+//	@Failure	404		{object}	resp.Response{}
+//	@Failure	500		{object}	resp.Response{}
+//	@Router		/v1/watchlist/{id}/items/{code} [delete]
+func (r *watchlistRoutes) deleteWatchlistItem(c *gin.Context) {
+	username := auth.ExtractUsername(c)
+	if username == "" {
+		resp.ErrorResponse(c, http.StatusBadRequest, "username is required in token")
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		resp.ErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	list, err := r.t.DeleteWatchlistItem(c.Request.Context(), username, id, c.Param("code"))
+	if err != nil {
+		r.watchlistError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+type watchlistSortRequest struct {
+	Codes []string `json:"codes" binding:"required"`
+}
+
+// sortWatchlistItem -.
+//
+//	@Tags		Watchlist V1
+//	@Summary	Reorder items, codes must contain every stock of the watchlist once
+//	@security	JWT
+//	@Accept		json
+//	@Produce	json
+//	@param		id		path		int						true	"id"
+//	@param		body	body		watchlistSortRequest{}	true	"Body"
+//	@Success	200		{object}	entity.Watchlist{}
+//	@Failure	400		{object}	resp.Response{}
+//	@Failure	401		{object}	resp.Response{}
+//	@Failure	404		{object}	resp.Response{}
+//	@Failure	500		{object}	resp.Response{}
+//	@Router		/v1/watchlist/{id}/sort [put]
+func (r *watchlistRoutes) sortWatchlistItem(c *gin.Context) {
+	username := auth.ExtractUsername(c)
+	if username == "" {
+		resp.ErrorResponse(c, http.StatusBadRequest, "username is required in token")
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		resp.ErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	p := watchlistSortRequest{}
+	if err = c.ShouldBindJSON(&p); err != nil {
+		resp.ErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	list, err := r.t.SortWatchlistItem(c.Request.Context(), username, id, p.Codes)
+	if err != nil {
+		r.watchlistError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+func (r *watchlistRoutes) watchlistError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrWatchlistNotFound):
+		resp.ErrorResponse(c, http.StatusNotFound, err)
+	case errors.Is(err, usecase.ErrWatchlistInvalid), errors.Is(err, usecase.ErrWatchlistCodeNotFound), errors.Is(err, usecase.ErrWatchlistSortInvalid):
+		resp.ErrorResponse(c, http.StatusBadRequest, err)
+	default:
+		resp.ErrorResponse(c, http.StatusInternalServerError, err)
+	}
+}
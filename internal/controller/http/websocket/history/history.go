@@ -18,6 +18,7 @@ type WSHistory struct {
 	s        usecase.History
 	reqChan  chan []byte
 	dataChan chan []byte
+	textChan chan []byte
 }
 
 // StartWSHistory -.
@@ -26,6 +27,7 @@ func StartWSHistory(c *gin.Context, s usecase.History) {
 		s:        s,
 		WSRouter: ginws.NewWSRouter(c),
 		dataChan: make(chan []byte),
+		textChan: make(chan []byte),
 		reqChan:  make(chan []byte),
 	}
 	forwardChan := make(chan []byte)
@@ -52,14 +54,29 @@ func (w *WSHistory) sender() {
 
 		case v := <-w.dataChan:
 			w.SendBinaryBytesToClient(v)
+
+		case v := <-w.textChan:
+			w.SendStringBytesToClient(v)
 		}
 	}
 }
 
+// kbarReq -. If indicators is true, day indicators ending at start date follow the kbars in a json text frame.
+// If resolution is set, kbars of code from and to in the resolution are sent instead of day kbars.
 type kbarReq struct {
-	StockNum  string `json:"stock_num"`
-	StartDate string `json:"start_date"`
-	Interval  int64  `json:"interval"`
+	StockNum   string `json:"stock_num"`
+	StartDate  string `json:"start_date"`
+	Interval   int64  `json:"interval"`
+	Indicators bool   `json:"indicators"`
+
+	Code       string                `json:"code"`
+	From       string                `json:"from"`
+	To         string                `json:"to"`
+	Resolution entity.KbarResolution `json:"resolution"`
+}
+
+type indicatorsResponse struct {
+	Indicators *entity.Indicators `json:"indicators"`
 }
 
 func (w *WSHistory) getData() {
@@ -73,6 +90,10 @@ func (w *WSHistory) getData() {
 			if err := json.Unmarshal(req, &r); err != nil {
 				continue
 			}
+			if r.Resolution != "" {
+				w.sendKbarRange(r)
+				continue
+			}
 			startDateTime, err := time.ParseInLocation(entity.ShortTimeLayout, r.StartDate, time.Local)
 			if err != nil {
 				continue
@@ -98,6 +119,46 @@ func (w *WSHistory) getData() {
 				continue
 			}
 			w.dataChan <- b
+
+			if r.Indicators {
+				w.sendIndicators(r.StockNum, r.StartDate)
+			}
 		}
 	}
 }
+
+func (w *WSHistory) sendIndicators(stockNum, date string) {
+	indicators, err := w.s.GetIndicators(w.Ctx(), stockNum, entity.IndicatorIntervalDay, date)
+	if err != nil {
+		return
+	}
+	b, err := json.Marshal(indicatorsResponse{Indicators: indicators})
+	if err != nil {
+		return
+	}
+	w.textChan <- b
+}
+
+func (w *WSHistory) sendKbarRange(r kbarReq) {
+	data, err := w.s.GetKbarRange(w.Ctx(), r.Code, r.From, r.To, r.Resolution)
+	if err != nil {
+		return
+	}
+	result := &pb.HistoryKbarResponse{}
+	for _, v := range data.Kbars {
+		result.Data = append(result.Data, &pb.HistoryKbarMessage{
+			Open:   v.Open,
+			Close:  v.Close,
+			High:   v.High,
+			Low:    v.Low,
+			Volume: v.Volume,
+			Ts:     v.KbarTime.UnixNano(),
+			Code:   data.Code,
+		})
+	}
+	b, err := proto.Marshal(result)
+	if err != nil {
+		return
+	}
+	w.dataChan <- b
+}
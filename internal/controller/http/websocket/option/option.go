@@ -0,0 +1,88 @@
+// Package option package option
+package option
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/toc-taiwan/toc-machine-trading/internal/controller/http/websocket/ginws"
+	"github.com/toc-taiwan/toc-machine-trading/internal/usecase"
+)
+
+const chainInterval = 5 * time.Second
+
+type WSOptionChain struct {
+	*ginws.WSRouter
+	s       usecase.Option
+	reqChan chan chainReq
+}
+
+// chainReq selects the chain to push, a new request replaces the last one.
+type chainReq struct {
+	Category      string `json:"category"`
+	DeliveryMonth string `json:"delivery_month"`
+	Underlying    string `json:"underlying"`
+}
+
+// StartWSOptionChain pushes the requested chain with quotes, iv and greeks as json text every 5 seconds.
+func StartWSOptionChain(c *gin.Context, s usecase.Option) {
+	w := &WSOptionChain{
+		s:        s,
+		WSRouter: ginws.NewWSRouter(c),
+		reqChan:  make(chan chainReq),
+	}
+	forwardChan := make(chan []byte)
+	go w.sender()
+	go func() {
+		for {
+			req, ok := <-forwardChan
+			if !ok {
+				return
+			}
+			var r chainReq
+			if err := json.Unmarshal(req, &r); err != nil {
+				continue
+			}
+			select {
+			case w.reqChan <- r:
+			case <-w.Ctx().Done():
+				return
+			}
+		}
+	}()
+	w.ReadFromClient(forwardChan)
+}
+
+func (w *WSOptionChain) sender() {
+	ticker := time.NewTicker(chainInterval)
+	defer ticker.Stop()
+	var req *chainReq
+	for {
+		select {
+		case <-w.Ctx().Done():
+			return
+
+		case r := <-w.reqChan:
+			req = &r
+			w.sendChain(req)
+
+		case <-ticker.C:
+			if req != nil {
+				w.sendChain(req)
+			}
+		}
+	}
+}
+
+func (w *WSOptionChain) sendChain(req *chainReq) {
+	chain, err := w.s.GetOptionChain(w.Ctx(), req.Category, req.DeliveryMonth, req.Underlying)
+	if err != nil {
+		return
+	}
+	b, err := json.Marshal(chain)
+	if err != nil {
+		return
+	}
+	w.SendStringBytesToClient(b)
+}
@@ -24,6 +24,11 @@ type WSPickRealFuture struct {
 
 // StartWSPickRealFuture -.
 func StartWSPickRealFuture(c *gin.Context, code string, s usecase.RealTime, h usecase.History, b usecase.Basic) {
+	// virtual codes like MXF1! follow the contract of the current trade day
+	if future := b.GetFutureDetail(code); future != nil {
+		code = future.Code
+	}
+
 	w := &WSPickRealFuture{
 		code:     code,
 		s:        s,
@@ -68,11 +73,14 @@ func (w *WSPickRealFuture) generateTradeIndex() *pb.WSMessage {
 	}
 }
 
+// fetchKbarMaxLookBack covers long holidays, empty days are stored so looking back is cheap after the first time.
+const fetchKbarMaxLookBack = 14
+
 func (w *WSPickRealFuture) fetchKbar() *pb.WSMessage {
 	if w.fetchTime.IsZero() {
 		w.fetchTime = time.Now()
 	}
-	for {
+	for i := 0; i < fetchKbarMaxLookBack; i++ {
 		fetch, err := w.h.GetFutureHistoryPBKbarByDate(w.code, w.fetchTime)
 		if err != nil {
 			return nil
@@ -98,6 +106,9 @@ func (w *WSPickRealFuture) fetchKbar() *pb.WSMessage {
 			},
 		}
 	}
+	// start from today again on the next fetch
+	w.fetchTime = time.Time{}
+	return nil
 }
 
 func (w *WSPickRealFuture) getFutureDetail() *pb.WSMessage {
@@ -4,6 +4,7 @@ package pick
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/toc-taiwan/toc-machine-trading/internal/controller/http/auth"
 	"github.com/toc-taiwan/toc-machine-trading/internal/controller/http/websocket/ginws"
 	"github.com/toc-taiwan/toc-machine-trading/internal/usecase"
 	"github.com/toc-taiwan/toc-trade-protobuf/golang/pb"
@@ -13,14 +14,18 @@ import (
 type WSPickRealStock struct {
 	*ginws.WSRouter
 	s        usecase.RealTime
+	wl       usecase.Watchlist
+	username string
 	mapChan  chan *pb.PickRealMap
 	tickChan chan []byte
 }
 
-// StartWSPickStock -.
-func StartWSPickStock(c *gin.Context, s usecase.RealTime, odd bool) {
+// StartWSPickStock starts with the default watchlist of the user, add and remove are saved back to it.
+func StartWSPickStock(c *gin.Context, s usecase.RealTime, wl usecase.Watchlist, odd bool) {
 	w := &WSPickRealStock{
 		s:        s,
+		wl:       wl,
+		username: auth.ExtractUsername(c),
 		WSRouter: ginws.NewWSRouter(c),
 		mapChan:  make(chan *pb.PickRealMap),
 		tickChan: make(chan []byte),
@@ -30,6 +35,7 @@ func StartWSPickStock(c *gin.Context, s usecase.RealTime, odd bool) {
 	go w.sendRealStock()
 	go w.s.CreateRealTimePick(connectionID, odd, w.mapChan, w.tickChan)
 	go func() {
+		w.seedWatchlist()
 		for {
 			msg, ok := <-forwardChan
 			if !ok {
@@ -40,6 +46,7 @@ func StartWSPickStock(c *gin.Context, s usecase.RealTime, odd bool) {
 			if err := proto.Unmarshal(msg, &pickRequest); err != nil {
 				continue
 			}
+			w.syncWatchlist(&pickRequest)
 			w.mapChan <- &pickRequest
 		}
 	}()
@@ -57,3 +64,34 @@ func (w *WSPickRealStock) sendRealStock() {
 		w.SendBinaryBytesToClient(tick)
 	}
 }
+
+func (w *WSPickRealStock) seedWatchlist() {
+	if w.username == "" {
+		return
+	}
+	codes, err := w.wl.GetDefaultWatchlistCodes(w.Ctx(), w.username)
+	if err != nil || len(codes) == 0 {
+		return
+	}
+	pickMap := make(map[string]pb.PickListType)
+	for _, v := range codes {
+		pickMap[v] = pb.PickListType_TYPE_ADD
+	}
+	w.mapChan <- &pb.PickRealMap{PickMap: pickMap}
+}
+
+func (w *WSPickRealStock) syncWatchlist(pickRequest *pb.PickRealMap) {
+	if w.username == "" {
+		return
+	}
+	var add, remove []string
+	for k, v := range pickRequest.GetPickMap() {
+		switch v {
+		case pb.PickListType_TYPE_ADD:
+			add = append(add, k)
+		case pb.PickListType_TYPE_REMOVE:
+			remove = append(remove, k)
+		}
+	}
+	_ = w.wl.SyncDefaultWatchlist(w.Ctx(), w.username, add, remove)
+}
@@ -17,6 +17,7 @@ const (
 	Unknown TargetType = iota
 	Stock
 	Future
+	Option
 )
 
 type WSTargetSearcher struct {
@@ -25,6 +26,7 @@ type WSTargetSearcher struct {
 	mapChan    chan string
 	stockChan  chan []*entity.Stock
 	futureChan chan []*entity.Future
+	optionChan chan []*entity.Option
 }
 
 // StartWSTargetSearcher -.
@@ -35,6 +37,7 @@ func StartWSTargetSearcher(c *gin.Context, s usecase.Basic, t TargetType) {
 		mapChan:    make(chan string),
 		stockChan:  make(chan []*entity.Stock),
 		futureChan: make(chan []*entity.Future),
+		optionChan: make(chan []*entity.Option),
 	}
 	forwardChan := make(chan []byte)
 	go w.sendData(c.Request.Context())
@@ -43,6 +46,8 @@ func StartWSTargetSearcher(c *gin.Context, s usecase.Basic, t TargetType) {
 		go w.s.CreateStockSearchRoom(w.mapChan, w.stockChan)
 	case Future:
 		go w.s.CreateFutureSearchRoom(w.mapChan, w.futureChan)
+	case Option:
+		go w.s.CreateOptionSearchRoom(w.mapChan, w.optionChan)
 	default:
 		return
 	}
@@ -69,6 +74,11 @@ type futureResponse struct {
 	Total   int              `json:"total"`
 }
 
+type optionResponse struct {
+	Options []*entity.Option `json:"options"`
+	Total   int              `json:"total"`
+}
+
 func (w *WSTargetSearcher) sendData(ctx context.Context) {
 	for {
 		var response any
@@ -85,6 +95,11 @@ func (w *WSTargetSearcher) sendData(ctx context.Context) {
 				Futures: data,
 				Total:   len(data),
 			}
+		case data := <-w.optionChan:
+			response = optionResponse{
+				Options: data,
+				Total:   len(data),
+			}
 		default:
 			continue
 		}
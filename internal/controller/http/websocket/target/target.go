@@ -2,10 +2,12 @@
 package target
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/toc-taiwan/toc-machine-trading/internal/controller/http/websocket/ginws"
+	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
 	"github.com/toc-taiwan/toc-machine-trading/internal/usecase"
 	"google.golang.org/protobuf/proto"
 )
@@ -51,13 +53,29 @@ func (w *WSTargetStock) getRank() ([]byte, error) {
 	return m, nil
 }
 
+// newTargetsMessage is sent as text, volume rank stays in binary.
+type newTargetsMessage struct {
+	NewTargets []*entity.StockTarget `json:"new_targets"`
+}
+
 func (w *WSTargetStock) sender() {
 	ticker := time.NewTicker(time.Second * 10)
+	newTargets := w.s.SubscribeNewTargets(w.Ctx())
 	for {
 		select {
 		case <-w.Ctx().Done():
 			return
 
+		case targets, ok := <-newTargets:
+			if !ok {
+				return
+			}
+			m, err := json.Marshal(newTargetsMessage{NewTargets: targets})
+			if err != nil {
+				continue
+			}
+			w.SendStringBytesToClient(m)
+
 		case v := <-w.dataChan:
 			w.SendBinaryBytesToClient(v)
 
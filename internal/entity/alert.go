@@ -0,0 +1,108 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+// AlertType is the condition an alert rule watches.
+type AlertType string
+
+const (
+	// AlertTypePriceCross triggers when the price crosses the threshold in either direction.
+	AlertTypePriceCross AlertType = "price_cross"
+	// AlertTypePctChange triggers when the absolute percent change reaches the threshold.
+	AlertTypePctChange AlertType = "pct_change"
+	// AlertTypeVolumeRatio triggers when the volume ratio reaches the threshold, stock only.
+	AlertTypeVolumeRatio AlertType = "volume_ratio"
+	// AlertTypeFutureBasis triggers when the absolute basis of future and underlying reaches the threshold, future only.
+	AlertTypeFutureBasis AlertType = "future_basis"
+)
+
+// ForStock -.
+func (t AlertType) ForStock() bool {
+	switch t {
+	case AlertTypePriceCross, AlertTypePctChange, AlertTypeVolumeRatio:
+		return true
+	default:
+		return false
+	}
+}
+
+// ForFuture -.
+func (t AlertType) ForFuture() bool {
+	switch t {
+	case AlertTypePriceCross, AlertTypePctChange, AlertTypeFutureBasis:
+		return true
+	default:
+		return false
+	}
+}
+
+// AlertRule is a condition on a stock or future owned by one user.
+type AlertRule struct {
+	ID            int        `json:"id"`
+	UserID        int        `json:"-"`
+	Type          AlertType  `json:"type"`
+	Code          string     `json:"code"`
+	Threshold     float64    `json:"threshold"`
+	Cooldown      int64      `json:"cooldown"`
+	Enabled       bool       `json:"enabled"`
+	LastTriggered *time.Time `json:"last_triggered"`
+	Created       time.Time  `json:"created"`
+}
+
+// InCooldown -.
+func (r *AlertRule) InCooldown(now time.Time) bool {
+	if r.LastTriggered == nil {
+		return false
+	}
+	return now.Before(r.LastTriggered.Add(time.Duration(r.Cooldown) * time.Second))
+}
+
+// NewAlertRule is the user input of an alert rule, cooldown is in seconds.
+type NewAlertRule struct {
+	Type      AlertType `json:"type"`
+	Code      string    `json:"code"`
+	Threshold float64   `json:"threshold"`
+	Cooldown  int64     `json:"cooldown"`
+	Enabled   bool      `json:"enabled"`
+}
+
+// AlertHistory is one delivered alert.
+type AlertHistory struct {
+	ID        int       `json:"id"`
+	RuleID    int       `json:"rule_id"`
+	UserID    int       `json:"-"`
+	Type      AlertType `json:"type"`
+	Code      string    `json:"code"`
+	Threshold float64   `json:"threshold"`
+	Value     float64   `json:"value"`
+	Message   string    `json:"message"`
+	Triggered time.Time `json:"triggered"`
+}
+
+// NewAlertHistory -.
+func NewAlertHistory(rule *AlertRule, name string, value float64, triggered time.Time) *AlertHistory {
+	var msg string
+	switch rule.Type {
+	case AlertTypePriceCross:
+		msg = fmt.Sprintf("%s %s price %.2f crossed %.2f", rule.Code, name, value, rule.Threshold)
+	case AlertTypePctChange:
+		msg = fmt.Sprintf("%s %s changed %.2f%%, threshold %.2f%%", rule.Code, name, value, rule.Threshold)
+	case AlertTypeVolumeRatio:
+		msg = fmt.Sprintf("%s %s volume ratio %.2f over %.2f", rule.Code, name, value, rule.Threshold)
+	case AlertTypeFutureBasis:
+		msg = fmt.Sprintf("%s %s basis %.2f beyond %.2f", rule.Code, name, value, rule.Threshold)
+	}
+	return &AlertHistory{
+		RuleID:    rule.ID,
+		UserID:    rule.UserID,
+		Type:      rule.Type,
+		Code:      rule.Code,
+		Threshold: rule.Threshold,
+		Value:     value,
+		Message:   msg,
+		Triggered: triggered,
+	}
+}
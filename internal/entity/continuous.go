@@ -0,0 +1,115 @@
+package entity
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ContinuousCodeSuffix marks a virtual code, MXF1! is the front month of MXF and MXF2! the next one.
+const ContinuousCodeSuffix string = "!"
+
+// ContinuousCode -.
+func ContinuousCode(category string, nth int) string {
+	return fmt.Sprintf("%s%d%s", strings.ToUpper(category), nth, ContinuousCodeSuffix)
+}
+
+// ParseContinuousCode returns the category and nth contract of a virtual code, ok is false for real codes.
+func ParseContinuousCode(code string) (string, int, bool) {
+	if !strings.HasSuffix(code, ContinuousCodeSuffix) {
+		return "", 0, false
+	}
+	body := strings.TrimSuffix(code, ContinuousCodeSuffix)
+	i := len(body)
+	for i > 0 && body[i-1] >= '0' && body[i-1] <= '9' {
+		i--
+	}
+	if i == 0 || i == len(body) {
+		return "", 0, false
+	}
+	nth, err := strconv.Atoi(body[i:])
+	if err != nil || nth < 1 {
+		return "", 0, false
+	}
+	return strings.ToUpper(body[:i]), nth, true
+}
+
+// ContinuousAdjustment is how history of earlier contracts is shifted to the latest one.
+type ContinuousAdjustment string
+
+const (
+	ContinuousAdjustmentNone       ContinuousAdjustment = "none"
+	ContinuousAdjustmentDifference ContinuousAdjustment = "difference"
+	ContinuousAdjustmentRatio      ContinuousAdjustment = "ratio"
+)
+
+// Valid -.
+func (a ContinuousAdjustment) Valid() bool {
+	switch a {
+	case ContinuousAdjustmentNone, ContinuousAdjustmentDifference, ContinuousAdjustmentRatio:
+		return true
+	}
+	return false
+}
+
+// ContinuousContract is the real contracts behind a virtual code, segments are in ascending order.
+type ContinuousContract struct {
+	Code       string               `json:"code"`
+	Category   string               `json:"category"`
+	Nth        int                  `json:"nth"`
+	Adjustment ContinuousAdjustment `json:"adjustment"`
+	Segments   []*ContinuousSegment `json:"segments"`
+}
+
+// Current is the segment of the latest trade day.
+func (c *ContinuousContract) Current() *ContinuousSegment {
+	if len(c.Segments) == 0 {
+		return nil
+	}
+	return c.Segments[len(c.Segments)-1]
+}
+
+// ContinuousSegment is the trade days a contract stands for the virtual code, both ends included.
+// Adjustment is added to, or for ratio multiplied with, prices of the segment, the last one is always unadjusted.
+type ContinuousSegment struct {
+	Code         string    `json:"code"`
+	DeliveryDate time.Time `json:"delivery_date"`
+	From         time.Time `json:"from"`
+	To           time.Time `json:"to"`
+	Adjustment   float64   `json:"adjustment"`
+}
+
+// FutureExpiryWarning is an open position whose contract is about to expire.
+type FutureExpiryWarning struct {
+	Code          string    `json:"code"`
+	Category      string    `json:"category"`
+	DeliveryDate  time.Time `json:"delivery_date"`
+	TradeDaysLeft int       `json:"trade_days_left"`
+	Direction     string    `json:"direction"`
+	Position      int64     `json:"position"`
+	RollTo        string    `json:"roll_to"`
+	Rolled        bool      `json:"rolled"`
+	RollError     string    `json:"roll_error,omitempty"`
+}
+
+func (w *FutureExpiryWarning) String() string {
+	msg := fmt.Sprintf("%s %s x %d expires on %s, %d trade days left", w.Direction, w.Code, w.Position, w.DeliveryDate.Format(ShortTimeLayout), w.TradeDaysLeft)
+	if w.Rolled {
+		msg += fmt.Sprintf(", rolled to %s", w.RollTo)
+	} else if w.RollError != "" {
+		msg += fmt.Sprintf(", roll to %s fail, check the position: %s", w.RollTo, w.RollError)
+	} else if w.RollTo != "" {
+		msg += fmt.Sprintf(", next contract is %s", w.RollTo)
+	}
+	return msg
+}
+
+// FutureRoll records a contract is rolled, a code is rolled once only.
+type FutureRoll struct {
+	Code      string
+	RollTo    string
+	Direction string
+	Position  int64
+	Created   time.Time
+}
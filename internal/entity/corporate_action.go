@@ -0,0 +1,55 @@
+package entity
+
+import "time"
+
+// CorporateActionType -.
+type CorporateActionType string
+
+const (
+	CorporateActionCashDividend     CorporateActionType = "cash_dividend"
+	CorporateActionStockDividend    CorporateActionType = "stock_dividend"
+	CorporateActionSplit            CorporateActionType = "split"
+	CorporateActionCapitalReduction CorporateActionType = "capital_reduction"
+)
+
+// Valid -.
+func (t CorporateActionType) Valid() bool {
+	switch t {
+	case CorporateActionCashDividend, CorporateActionStockDividend, CorporateActionSplit, CorporateActionCapitalReduction:
+		return true
+	}
+	return false
+}
+
+// CorporateAction changes the price of a stock on the ex date without any trade.
+// Cash is paid per share before the action, dividend or capital refund, Ratio is shares after the action per share before it.
+type CorporateAction struct {
+	StockNum string              `json:"stock_num"`
+	ExDate   time.Time           `json:"ex_date"`
+	Type     CorporateActionType `json:"type"`
+	Cash     float64             `json:"cash"`
+	Ratio    float64             `json:"ratio"`
+}
+
+// Factor is the reference price on the ex date over the close before it, prices before the ex date are multiplied by it.
+// It is 1 if the close is unknown or the action would make the reference price not positive.
+func (a *CorporateAction) Factor(prevClose float64) float64 {
+	if prevClose <= 0 || a.Ratio <= 0 {
+		return 1
+	}
+	factor := (prevClose - a.Cash) / (a.Ratio * prevClose)
+	if factor <= 0 {
+		return 1
+	}
+	return factor
+}
+
+// StockCloseSeries is the raw closes of a stock and the ones adjusted to the price level of the last close.
+type StockCloseSeries struct {
+	StockNum string              `json:"stock_num"`
+	From     time.Time           `json:"from"`
+	To       time.Time           `json:"to"`
+	Raw      []*HistoryCloseBase `json:"raw"`
+	Adjusted []*HistoryCloseBase `json:"adjusted"`
+	Actions  []*CorporateAction  `json:"actions"`
+}
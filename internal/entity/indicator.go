@@ -0,0 +1,57 @@
+package entity
+
+import "time"
+
+// IndicatorInterval is the bar size indicators are calculated on.
+type IndicatorInterval string
+
+const (
+	IndicatorIntervalDay    IndicatorInterval = "day"
+	IndicatorIntervalMinute IndicatorInterval = "minute"
+)
+
+// IndicatorName -.
+type IndicatorName string
+
+const (
+	IndicatorRSI       IndicatorName = "rsi"
+	IndicatorMACD      IndicatorName = "macd"
+	IndicatorBollinger IndicatorName = "bollinger"
+	IndicatorATR       IndicatorName = "atr"
+	IndicatorKD        IndicatorName = "kd"
+)
+
+// Indicators are series aligned with Time, values before the lookback of each indicator are 0.
+// An indicator is omitted if it is not enabled or there are not enough bars.
+type Indicators struct {
+	Code      string            `json:"code"`
+	Interval  IndicatorInterval `json:"interval"`
+	Date      time.Time         `json:"date"`
+	Time      []time.Time       `json:"time"`
+	RSI       []float64         `json:"rsi,omitempty"`
+	MACD      *MACDSeries       `json:"macd,omitempty"`
+	Bollinger *BollingerSeries  `json:"bollinger,omitempty"`
+	ATR       []float64         `json:"atr,omitempty"`
+	KD        *KDSeries         `json:"kd,omitempty"`
+	Created   time.Time         `json:"created"`
+}
+
+// MACDSeries -.
+type MACDSeries struct {
+	MACD   []float64 `json:"macd"`
+	Signal []float64 `json:"signal"`
+	Hist   []float64 `json:"hist"`
+}
+
+// BollingerSeries -.
+type BollingerSeries struct {
+	Upper  []float64 `json:"upper"`
+	Middle []float64 `json:"middle"`
+	Lower  []float64 `json:"lower"`
+}
+
+// KDSeries -.
+type KDSeries struct {
+	K []float64 `json:"k"`
+	D []float64 `json:"d"`
+}
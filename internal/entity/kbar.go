@@ -0,0 +1,48 @@
+package entity
+
+import "time"
+
+// KbarResolution -.
+type KbarResolution string
+
+const (
+	KbarResolution1m  KbarResolution = "1m"
+	KbarResolution5m  KbarResolution = "5m"
+	KbarResolution15m KbarResolution = "15m"
+	KbarResolution60m KbarResolution = "60m"
+	KbarResolution1d  KbarResolution = "1d"
+)
+
+// Minutes returns the bar size in minutes, 0 for day bars or unknown resolution.
+func (r KbarResolution) Minutes() int {
+	switch r {
+	case KbarResolution1m:
+		return 1
+	case KbarResolution5m:
+		return 5
+	case KbarResolution15m:
+		return 15
+	case KbarResolution60m:
+		return 60
+	default:
+		return 0
+	}
+}
+
+// Valid -.
+func (r KbarResolution) Valid() bool {
+	return r == KbarResolution1d || r.Minutes() != 0
+}
+
+// KbarRange is kbars of a stock or future from the first to the last trade day, inclusive.
+// Minute bars are labelled by bucket start, day bars by trade day.
+type KbarRange struct {
+	Code       string             `json:"code"`
+	Resolution KbarResolution     `json:"resolution"`
+	From       time.Time          `json:"from"`
+	To         time.Time          `json:"to"`
+	Kbars      []*HistoryKbarBase `json:"kbars"`
+
+	// Continuous is set when code is a virtual code like MXF1!.
+	Continuous *ContinuousContract `json:"continuous,omitempty"`
+}
@@ -0,0 +1,54 @@
+package entity
+
+// NotifyChannel -.
+type NotifyChannel string
+
+const (
+	NotifyChannelFCM     NotifyChannel = "fcm"
+	NotifyChannelEmail   NotifyChannel = "email"
+	NotifyChannelSlack   NotifyChannel = "slack"
+	NotifyChannelWebhook NotifyChannel = "webhook"
+)
+
+// AllNotifyChannel -.
+var AllNotifyChannel = []NotifyChannel{
+	NotifyChannelFCM,
+	NotifyChannelEmail,
+	NotifyChannelSlack,
+	NotifyChannelWebhook,
+}
+
+// NotifyEvent is the kind of notification a user can route.
+type NotifyEvent string
+
+const (
+	NotifyEventOrderFill    NotifyEvent = "order_fill"
+	NotifyEventAlert        NotifyEvent = "alert"
+	NotifyEventDailyReport  NotifyEvent = "daily_report"
+	NotifyEventFutureExpiry NotifyEvent = "future_expiry"
+)
+
+// DefaultNotifyChannels is used for events without user preference.
+var DefaultNotifyChannels = map[NotifyEvent][]NotifyChannel{
+	NotifyEventOrderFill:    {NotifyChannelFCM},
+	NotifyEventAlert:        {NotifyChannelFCM},
+	NotifyEventDailyReport:  {NotifyChannelEmail, NotifyChannelFCM},
+	NotifyEventFutureExpiry: {NotifyChannelFCM},
+}
+
+// NotifyPreference is where each event of the user goes, an empty list mutes the event.
+type NotifyPreference struct {
+	Channels map[NotifyEvent][]NotifyChannel `json:"channels"`
+	// SlackWebhook must be a https://hooks.slack.com incoming webhook
+	SlackWebhook string `json:"slack_webhook"`
+	// WebhookURL must resolve to public addresses only
+	WebhookURL string `json:"webhook_url"`
+}
+
+// ChannelsOf -.
+func (p *NotifyPreference) ChannelsOf(event NotifyEvent) []NotifyChannel {
+	if channels, ok := p.Channels[event]; ok {
+		return channels
+	}
+	return DefaultNotifyChannels[event]
+}
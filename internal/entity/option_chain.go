@@ -0,0 +1,80 @@
+package entity
+
+import (
+	"strings"
+	"time"
+)
+
+// OptionRightCall -. Option right from the broker is C or P.
+const OptionRightCall string = "C"
+
+// IsCall -.
+func (o *Option) IsCall() bool {
+	return strings.HasPrefix(strings.ToUpper(o.OptionRight), OptionRightCall)
+}
+
+// OptionSearchFilter is the search condition of option search room, empty fields are not filtered.
+type OptionSearchFilter struct {
+	Keyword       string  `json:"keyword"`
+	Underlying    string  `json:"underlying"`
+	Right         string  `json:"right"`
+	DeliveryMonth string  `json:"delivery_month"`
+	StrikeMin     float64 `json:"strike_min"`
+	StrikeMax     float64 `json:"strike_max"`
+}
+
+// IsEmpty -.
+func (f *OptionSearchFilter) IsEmpty() bool {
+	return f.Keyword == "" && f.Underlying == "" && f.Right == "" && f.DeliveryMonth == "" && f.StrikeMin == 0 && f.StrikeMax == 0
+}
+
+// OptionChainInfo is the options of the same category and delivery month.
+type OptionChainInfo struct {
+	Category       string    `json:"category"`
+	DeliveryMonth  string    `json:"delivery_month"`
+	DeliveryDate   time.Time `json:"delivery_date"`
+	UnderlyingKind string    `json:"underlying_kind"`
+	StrikeCount    int       `json:"strike_count"`
+}
+
+// OptionChain is a chain with live quotes, strikes are in ascending order.
+type OptionChain struct {
+	OptionChainInfo
+	Underlying *OptionUnderlying `json:"underlying"`
+	Strikes    []*OptionStrike   `json:"strikes"`
+	Updated    time.Time         `json:"updated"`
+}
+
+// OptionUnderlying is the future used as underlying price of implied volatility and greeks.
+type OptionUnderlying struct {
+	Code  string  `json:"code"`
+	Price float64 `json:"price"`
+}
+
+// OptionStrike -.
+type OptionStrike struct {
+	StrikePrice float64      `json:"strike_price"`
+	Call        *OptionQuote `json:"call,omitempty"`
+	Put         *OptionQuote `json:"put,omitempty"`
+}
+
+// OptionQuote is the snapshot of an option, iv and greeks are omitted if they can not be solved.
+type OptionQuote struct {
+	Code        string        `json:"code"`
+	Close       float64       `json:"close"`
+	BuyPrice    float64       `json:"buy_price"`
+	SellPrice   float64       `json:"sell_price"`
+	ChangePrice float64       `json:"change_price"`
+	TotalVolume int64         `json:"total_volume"`
+	IV          float64       `json:"iv,omitempty"`
+	Greeks      *OptionGreeks `json:"greeks,omitempty"`
+}
+
+// OptionGreeks -. Theta is per calendar day, vega and rho are per 1% change.
+type OptionGreeks struct {
+	Delta float64 `json:"delta"`
+	Gamma float64 `json:"gamma"`
+	Theta float64 `json:"theta"`
+	Vega  float64 `json:"vega"`
+	Rho   float64 `json:"rho"`
+}
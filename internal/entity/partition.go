@@ -0,0 +1,20 @@
+package entity
+
+import "time"
+
+// HistoryTable is a history table partitioned by month.
+type HistoryTable string
+
+const (
+	HistoryTableStockTick  HistoryTable = "history_stock_tick"
+	HistoryTableStockKbar  HistoryTable = "history_stock_kbar"
+	HistoryTableStockClose HistoryTable = "history_stock_close"
+)
+
+// HistoryPartition is one month of a partitioned history table, To is excluded.
+type HistoryPartition struct {
+	Table HistoryTable `json:"table"`
+	Name  string       `json:"name"`
+	From  time.Time    `json:"from"`
+	To    time.Time    `json:"to"`
+}
@@ -0,0 +1,32 @@
+package entity
+
+import "time"
+
+// PatternName -.
+type PatternName string
+
+const (
+	PatternMACross     PatternName = "ma_cross"
+	PatternGap         PatternName = "gap"
+	PatternBreakout    PatternName = "breakout"
+	PatternVolumeSpike PatternName = "volume_spike"
+	PatternInsideBar   PatternName = "inside_bar"
+	PatternQuaterMA    PatternName = "quater_ma_reborn"
+)
+
+// PatternHit is a stock matching a pattern on the date, detail tells the direction, value is pattern specific.
+type PatternHit struct {
+	Date     time.Time   `json:"date"`
+	StockNum string      `json:"stock_num"`
+	Stock    *Stock      `json:"stock,omitempty"`
+	Pattern  PatternName `json:"pattern"`
+	Detail   string      `json:"detail"`
+	Value    float64     `json:"value"`
+	Created  time.Time   `json:"created"`
+}
+
+// PatternHitList is one page of hits with the total count of the query.
+type PatternHitList struct {
+	Total int64         `json:"total"`
+	Hits  []*PatternHit `json:"hits"`
+}
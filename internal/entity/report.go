@@ -0,0 +1,66 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DailyReport is the summary of one trade day, generated after the close.
+type DailyReport struct {
+	Date            time.Time              `json:"date"`
+	Stock           DailyReportTrade       `json:"stock"`
+	Future          DailyReportTrade       `json:"future"`
+	NetPnL          int64                  `json:"net_pnl"`
+	StockPositions  []*DailyReportPosition `json:"stock_positions"`
+	FuturePositions []*DailyReportPosition `json:"future_positions"`
+	Account         *AccountBalance        `json:"account"`
+	Created         time.Time              `json:"created"`
+}
+
+// DailyReportTrade -.
+type DailyReportTrade struct {
+	OrderCount int64 `json:"order_count"`
+	TradeCount int64 `json:"trade_count"`
+	Forward    int64 `json:"forward"`
+	Reverse    int64 `json:"reverse"`
+	Fee        int64 `json:"fee"`
+	Tax        int64 `json:"tax"`
+	Discount   int64 `json:"discount"`
+	Net        int64 `json:"net"`
+}
+
+// DailyReportPosition is a position carried to the next trade day.
+type DailyReportPosition struct {
+	Code      string  `json:"code"`
+	Direction string  `json:"direction"`
+	Quantity  int64   `json:"quantity"`
+	Price     float64 `json:"price"`
+	LastPrice float64 `json:"last_price"`
+	Pnl       float64 `json:"pnl"`
+}
+
+// Summary is the plain text body used by mail and push.
+func (r *DailyReport) Summary() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Daily Report %s\n", r.Date.Format(ShortTimeLayout))
+	fmt.Fprintf(&b, "Net PnL: %d\n", r.NetPnL)
+	for _, v := range []struct {
+		name  string
+		trade DailyReportTrade
+	}{
+		{"Stock", r.Stock},
+		{"Future", r.Future},
+	} {
+		fmt.Fprintf(&b, "%s: %d trades of %d orders, forward %d, reverse %d, fee %d, tax %d, discount %d, net %d\n",
+			v.name, v.trade.TradeCount, v.trade.OrderCount, v.trade.Forward, v.trade.Reverse, v.trade.Fee, v.trade.Tax, v.trade.Discount, v.trade.Net)
+	}
+	for _, v := range append(r.StockPositions, r.FuturePositions...) {
+		fmt.Fprintf(&b, "Carry %s %s %d @ %.2f, last %.2f, pnl %.0f\n", v.Code, v.Direction, v.Quantity, v.Price, v.LastPrice, v.Pnl)
+	}
+	if r.Account != nil {
+		fmt.Fprintf(&b, "Balance %.0f, margin %.0f, available %.0f, risk indicator %.2f%%\n",
+			r.Account.Balance, r.Account.TodayMargin, r.Account.AvailableMargin, r.Account.RiskIndicator)
+	}
+	return b.String()
+}
@@ -1,6 +1,10 @@
 package entity
 
-import "time"
+import (
+	"fmt"
+	"strings"
+	"time"
+)
 
 // StockTarget -.
 type StockTarget struct {
@@ -10,4 +14,80 @@ type StockTarget struct {
 	TradeDay time.Time `json:"trade_day"`
 	StockNum string    `json:"stock_num"`
 	Stock    *Stock    `json:"stock"`
+	Reason   string    `json:"reason"`
+}
+
+// TargetRule selects stock targets from the volume rank, zero value or empty list means no limit.
+// RealTimeRank is how many top volume stocks are checked for intraday targets, 0 disables it.
+type TargetRule struct {
+	PriceLow        float64  `json:"price_low"`
+	PriceHigh       float64  `json:"price_high"`
+	MinVolume       int64    `json:"min_volume"`
+	IncludeExchange []string `json:"include_exchange"`
+	ExcludeExchange []string `json:"exclude_exchange"`
+	IncludeCategory []string `json:"include_category"`
+	ExcludeCategory []string `json:"exclude_category"`
+	DayTradeOnly    bool     `json:"day_trade_only"`
+	MinVolumeRatio  float64  `json:"min_volume_ratio"`
+	MaxCount        int      `json:"max_count"`
+	RealTimeRank    int      `json:"real_time_rank"`
+}
+
+// TargetCandidate is one stock of the volume rank to be checked by the rule.
+type TargetCandidate struct {
+	Stock       *Stock
+	Rank        int
+	Close       float64
+	Volume      int64
+	VolumeRatio float64
+}
+
+func containsString(arr []string, s string) bool {
+	for _, v := range arr {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Check returns why the candidate is selected, or false if any rule does not pass.
+func (r *TargetRule) Check(c *TargetCandidate) (string, bool) {
+	reason := []string{fmt.Sprintf("volume rank %d, volume %d", c.Rank, c.Volume)}
+
+	if r.PriceLow > 0 || r.PriceHigh > 0 {
+		if c.Close < r.PriceLow || (r.PriceHigh > 0 && c.Close > r.PriceHigh) {
+			return "", false
+		}
+		reason = append(reason, fmt.Sprintf("price %.2f in range", c.Close))
+	}
+
+	if r.MinVolume > 0 {
+		if c.Volume < r.MinVolume {
+			return "", false
+		}
+		reason = append(reason, fmt.Sprintf("volume over %d", r.MinVolume))
+	}
+
+	if (len(r.IncludeExchange) != 0 && !containsString(r.IncludeExchange, c.Stock.Exchange)) || containsString(r.ExcludeExchange, c.Stock.Exchange) {
+		return "", false
+	}
+	if (len(r.IncludeCategory) != 0 && !containsString(r.IncludeCategory, c.Stock.Category)) || containsString(r.ExcludeCategory, c.Stock.Category) {
+		return "", false
+	}
+
+	if r.DayTradeOnly {
+		if !c.Stock.DayTrade {
+			return "", false
+		}
+		reason = append(reason, "day trade")
+	}
+
+	if r.MinVolumeRatio > 0 {
+		if c.VolumeRatio < r.MinVolumeRatio {
+			return "", false
+		}
+		reason = append(reason, fmt.Sprintf("volume ratio %.2f", c.VolumeRatio))
+	}
+	return strings.Join(reason, ", "), true
 }
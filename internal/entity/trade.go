@@ -187,6 +187,23 @@ func (f *FutureOrder) String() string {
 // 	return f
 // }
 
+// OptionOrder -.
+type OptionOrder struct {
+	Code     string  `json:"code"`
+	Quantity int64   `json:"quantity"`
+	Option   *Option `json:"option"`
+
+	OrderDetail `json:"base_order"`
+}
+
+func (o *OptionOrder) OptionOrderStatusString() string {
+	return fmt.Sprintf("%s %s %s %.1f x %d", o.OrderDetail.Status.String(), o.OrderDetail.Action.String(), o.Code, o.OrderDetail.Price, o.Quantity)
+}
+
+func (o *OptionOrder) String() string {
+	return fmt.Sprintf("%s %s %.1f x %d", o.OrderDetail.Action.String(), o.Code, o.OrderDetail.Price, o.Quantity)
+}
+
 // StockTradeBalance -.
 type StockTradeBalance struct {
 	ID              int64     `json:"id"`
@@ -209,6 +226,16 @@ type FutureTradeBalance struct {
 	TradeDay   time.Time `json:"trade_day"`
 }
 
+// OptionTradeBalance -.
+type OptionTradeBalance struct {
+	ID         int64     `json:"id"`
+	TradeCount int64     `json:"trade_count"`
+	Forward    int64     `json:"forward"`
+	Reverse    int64     `json:"reverse"`
+	Total      int64     `json:"total"`
+	TradeDay   time.Time `json:"trade_day"`
+}
+
 // FuturePosition -.
 type FuturePosition struct {
 	Code      string  `json:"code"`
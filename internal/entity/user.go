@@ -9,6 +9,10 @@ type User struct {
 	Password      string `json:"-"`
 	EmailVerified bool   `json:"-"`
 	AuthTrade     bool   `json:"-"`
+	Role          string `json:"role"`
+
+	// TokenValidAfter tokens logged in before this time are revoked
+	TokenValidAfter time.Time `json:"-"`
 }
 
 type NewUser struct {
@@ -18,9 +22,180 @@ type NewUser struct {
 }
 
 type PushToken struct {
-	ID      int
-	Token   string
-	UserID  int
-	Enabled bool
-	Created time.Time
+	ID        int
+	Token     string
+	UserID    int
+	Enabled   bool
+	OrderPush bool
+	Created   time.Time
+}
+
+// PasswordReset -.
+type PasswordReset struct {
+	ID       int
+	UserID   int
+	CodeHash string
+	ExpireAt time.Time
+	Attempts int
+	Used     bool
+	Created  time.Time
+}
+
+// EmailVerification -.
+type EmailVerification struct {
+	ID       int
+	UserID   int
+	CodeHash string
+	ExpireAt time.Time
+	Attempts int
+	Used     bool
+	Created  time.Time
+}
+
+// Session is a logged in device, refreshed by its own refresh token.
+type Session struct {
+	ID               int       `json:"-"`
+	SessionID        string    `json:"session_id"`
+	UserID           int       `json:"-"`
+	RefreshTokenHash string    `json:"-"`
+	UserAgent        string    `json:"user_agent"`
+	IP               string    `json:"ip"`
+	Created          time.Time `json:"created"`
+	LastSeen         time.Time `json:"last_seen"`
+	ExpireAt         time.Time `json:"expire_at"`
+	Revoked          bool      `json:"-"`
+	Current          bool      `json:"current"`
+}
+
+// JWTKey is a signing key, still accepted for verifying until RetireAt.
+type JWTKey struct {
+	ID       int
+	Key      string
+	Created  time.Time
+	RetireAt *time.Time
+}
+
+// TOTP -.
+type TOTP struct {
+	UserID       int
+	Secret       string
+	Enabled      bool
+	LastUsedStep int64
+	Created      time.Time
+}
+
+// TOTPEnrollment is shown to user only once when enrolling.
+type TOTPEnrollment struct {
+	Secret      string   `json:"secret"`
+	URI         string   `json:"uri"`
+	BackupCodes []string `json:"backup_codes"`
+}
+
+// TOTPStatus -.
+type TOTPStatus struct {
+	Enabled         bool `json:"enabled"`
+	BackupCodesLeft int  `json:"backup_codes_left"`
+}
+
+// SystemSetting is runtime setting editable by admin.
+type SystemSetting struct {
+	TOTPRequiredForTrade bool `json:"totp_required_for_trade"`
+}
+
+// APIKeyScope limits what an api key can do, on top of the owner's role.
+type APIKeyScope string
+
+const (
+	APIKeyScopeMarket APIKeyScope = "market"
+	APIKeyScopeOrder  APIKeyScope = "order"
+	APIKeyScopeAdmin  APIKeyScope = "admin"
+)
+
+// Permission returns the permission granted by the scope, empty if unknown.
+func (s APIKeyScope) Permission() Permission {
+	switch s {
+	case APIKeyScopeMarket:
+		return PermissionView
+	case APIKeyScopeOrder:
+		return PermissionTrade
+	case APIKeyScopeAdmin:
+		return PermissionAdmin
+	default:
+		return ""
+	}
+}
+
+// APIKey is a long-lived credential for scripts, only the hash of the key is stored.
+type APIKey struct {
+	ID          int           `json:"-"`
+	KeyID       string        `json:"key_id"`
+	UserID      int           `json:"-"`
+	Name        string        `json:"name"`
+	KeyHash     string        `json:"-"`
+	Scopes      []APIKeyScope `json:"scopes"`
+	IPAllowlist []string      `json:"ip_allowlist"`
+	ExpireAt    *time.Time    `json:"expire_at"`
+	LastUsed    *time.Time    `json:"last_used"`
+	Revoked     bool          `json:"revoked"`
+	Created     time.Time     `json:"created"`
+}
+
+// Permissions returns permissions granted by scopes.
+func (k *APIKey) Permissions() []Permission {
+	var result []Permission
+	for _, v := range k.Scopes {
+		if p := v.Permission(); p != "" {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// NewAPIKey -.
+type NewAPIKey struct {
+	Name   string        `json:"name"`
+	Scopes []APIKeyScope `json:"scopes"`
+	// IPAllowlist accepts ip or cidr, empty means any ip
+	IPAllowlist []string   `json:"ip_allowlist"`
+	ExpireAt    *time.Time `json:"expire_at"`
+}
+
+// CreatedAPIKey is shown to user only once when creating.
+type CreatedAPIKey struct {
+	*APIKey
+	Key string `json:"key"`
+}
+
+const (
+	RoleAdmin  string = "admin"
+	RoleTrader string = "trader"
+	RoleViewer string = "viewer"
+)
+
+// Permission -.
+type Permission string
+
+const (
+	// PermissionView read market data, targets and history
+	PermissionView Permission = "view"
+	// PermissionTrade place and cancel orders, read inventory
+	PermissionTrade Permission = "trade"
+	// PermissionAdmin manage users, roles, push tokens and announcements
+	PermissionAdmin Permission = "admin"
+)
+
+// Role -.
+type Role struct {
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	Permissions []Permission `json:"permissions"`
+}
+
+func (r *Role) HasPermission(p Permission) bool {
+	for _, v := range r.Permissions {
+		if v == p {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,48 @@
+package entity
+
+import "time"
+
+// Watchlist is a named list of stocks of one user, the default one seeds the pick stock websocket.
+type Watchlist struct {
+	ID      int              `json:"id"`
+	UserID  int              `json:"-"`
+	Name    string           `json:"name"`
+	Default bool             `json:"default"`
+	Sort    int              `json:"sort"`
+	Items   []*WatchlistItem `json:"items"`
+	Created time.Time        `json:"created"`
+	Updated time.Time        `json:"updated"`
+}
+
+// Codes -.
+func (w *Watchlist) Codes() []string {
+	result := make([]string, 0, len(w.Items))
+	for _, v := range w.Items {
+		result = append(result, v.Code)
+	}
+	return result
+}
+
+// NewWatchlist is the body to create or update a watchlist.
+type NewWatchlist struct {
+	Name    string `json:"name" binding:"required"`
+	Default bool   `json:"default"`
+	Sort    int    `json:"sort"`
+}
+
+// WatchlistItem -.
+type WatchlistItem struct {
+	WatchlistID int       `json:"-"`
+	UserID      int       `json:"-"`
+	Code        string    `json:"code"`
+	Note        string    `json:"note"`
+	Sort        int       `json:"sort"`
+	Created     time.Time `json:"created"`
+}
+
+// NewWatchlistItem is the body to add or update an item, items are appended when sort is not set.
+type NewWatchlistItem struct {
+	Code string `json:"code" binding:"required"`
+	Note string `json:"note"`
+	Sort *int   `json:"sort"`
+}
@@ -19,6 +19,9 @@ const (
 	cacheCatagoryHistoryTickAnalyze
 	cacheCatagoryHistoryTickArr
 	cacheCatagoryDayKbar
+	cacheCatagoryIndicator
+	cacheCatagoryFutureKbarArr
+	cacheCatagoryOptionDetail
 )
 
 const (
@@ -96,6 +99,25 @@ func (c *Cache) GetAllFutureDetail() map[string]*entity.Future {
 	return result
 }
 
+func (c *Cache) SetOptionDetail(option *entity.Option) {
+	c.Set(c.key(cacheCatagoryOptionDetail, option.Code), option)
+}
+
+func (c *Cache) GetOptionDetail(code string) *entity.Option {
+	if value, ok := c.Get(c.key(cacheCatagoryOptionDetail, code)); ok {
+		return value.(*entity.Option)
+	}
+	return nil
+}
+
+func (c *Cache) GetAllOptionDetail() map[string]*entity.Option {
+	result := make(map[string]*entity.Option)
+	for k, v := range c.GetAll(cacheCatagoryOptionDetail) {
+		result[k] = v.(*entity.Option)
+	}
+	return result
+}
+
 func (c *Cache) SetHistoryOpen(stockNum string, date time.Time, open float64) {
 	c.Set(c.key(cacheCatagoryHistoryOpen, stockNum, date.Format("20060102")), open)
 }
@@ -173,3 +195,31 @@ func (c *Cache) GetHistoryTickArr(stockNum string, date time.Time) []*entity.Sto
 	}
 	return nil
 }
+
+func (c *Cache) SetIndicators(code string, interval entity.IndicatorInterval, date time.Time, indicators *entity.Indicators) {
+	c.Set(c.key(cacheCatagoryIndicator, code, string(interval), date.Format("20060102")), indicators)
+}
+
+func (c *Cache) GetIndicators(code string, interval entity.IndicatorInterval, date time.Time) *entity.Indicators {
+	if value, ok := c.Get(c.key(cacheCatagoryIndicator, code, string(interval), date.Format("20060102"))); ok {
+		return value.(*entity.Indicators)
+	}
+	return nil
+}
+
+func (c *Cache) SetFutureKbarArr(code string, tradeDay time.Time, kbarArr []*entity.FutureHistoryKbar) {
+	c.Set(c.key(cacheCatagoryFutureKbarArr, code, tradeDay.Format("20060102")), kbarArr)
+}
+
+// SetCurrentFutureKbarArr caches kbars of the trade day not completed yet, it expires after d.
+func (c *Cache) SetCurrentFutureKbarArr(code string, tradeDay time.Time, kbarArr []*entity.FutureHistoryKbar, d time.Duration) {
+	c.SetWithExpiration(c.key(cacheCatagoryFutureKbarArr, code, tradeDay.Format("20060102")), kbarArr, d)
+}
+
+// GetFutureKbarArr returns false if the trade day is not cached, completed days without kbars are cached as empty.
+func (c *Cache) GetFutureKbarArr(code string, tradeDay time.Time) ([]*entity.FutureHistoryKbar, bool) {
+	if value, ok := c.Get(c.key(cacheCatagoryFutureKbarArr, code, tradeDay.Format("20060102"))); ok {
+		return value.([]*entity.FutureHistoryKbar), true
+	}
+	return nil, false
+}
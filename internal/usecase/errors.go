@@ -20,3 +20,109 @@ var (
 	ErrUsernameAlreadyExists = &UseCaseError{Code: -1005, Message: "username already exists"}
 	ErrEmailFormatInvalid    = &UseCaseError{Code: -1006, Message: "email format invalid"}
 )
+
+var (
+	ErrRoleNotFound      = &UseCaseError{Code: -1007, Message: "role not found"}
+	ErrPermissionDenied  = &UseCaseError{Code: -1008, Message: "permission denied"}
+	ErrCannotRemoveAdmin = &UseCaseError{Code: -1009, Message: "cannot remove the last admin"}
+)
+
+var (
+	ErrResetCodeInvalid      = &UseCaseError{Code: -1010, Message: "reset code invalid or expired"}
+	ErrPasswordInvalid       = &UseCaseError{Code: -1011, Message: "password must be at least 8 characters"}
+	ErrResetAttemptsExceeded = &UseCaseError{Code: -1059, Message: "too many reset attempts, request a new code"}
+)
+
+var (
+	ErrVerifyCodeInvalid      = &UseCaseError{Code: -1012, Message: "verification code invalid"}
+	ErrVerifyCodeExpired      = &UseCaseError{Code: -1013, Message: "verification code expired"}
+	ErrVerifyAttemptsExceeded = &UseCaseError{Code: -1014, Message: "too many verification attempts"}
+	ErrResendTooFrequent      = &UseCaseError{Code: -1015, Message: "resend too frequent"}
+	ErrEmailAlreadyVerified   = &UseCaseError{Code: -1016, Message: "email already verified"}
+)
+
+var (
+	ErrSessionNotFound     = &UseCaseError{Code: -1017, Message: "session not found"}
+	ErrRefreshTokenInvalid = &UseCaseError{Code: -1018, Message: "refresh token invalid or expired"}
+)
+
+var (
+	ErrTOTPRequired         = &UseCaseError{Code: -1019, Message: "totp code required"}
+	ErrTOTPInvalid          = &UseCaseError{Code: -1020, Message: "totp code invalid"}
+	ErrTOTPAlreadyEnabled   = &UseCaseError{Code: -1021, Message: "totp already enabled"}
+	ErrTOTPNotEnrolled      = &UseCaseError{Code: -1022, Message: "totp not enrolled"}
+	ErrTOTPRequiredForTrade = &UseCaseError{Code: -1023, Message: "totp must be enabled for trade authorized user"}
+)
+
+var (
+	ErrAPIKeyInvalid      = &UseCaseError{Code: -1024, Message: "api key invalid"}
+	ErrAPIKeyExpired      = &UseCaseError{Code: -1025, Message: "api key expired"}
+	ErrAPIKeyIPNotAllowed = &UseCaseError{Code: -1026, Message: "api key is not allowed from this ip"}
+	ErrAPIKeyNotFound     = &UseCaseError{Code: -1027, Message: "api key not found"}
+	ErrAPIKeyScopeInvalid = &UseCaseError{Code: -1028, Message: "api key name and valid scopes are required"}
+	ErrAPIKeyIPInvalid    = &UseCaseError{Code: -1029, Message: "api key ip allowlist invalid"}
+)
+
+var (
+	ErrAlertRuleInvalid  = &UseCaseError{Code: -1030, Message: "alert rule type, threshold or cooldown invalid"}
+	ErrAlertRuleNotFound = &UseCaseError{Code: -1031, Message: "alert rule not found"}
+	ErrAlertCodeNotFound = &UseCaseError{Code: -1032, Message: "alert code is not a stock or future"}
+)
+
+var (
+	ErrFCMNotConfigured     = &UseCaseError{Code: -1033, Message: "fcm is not configured"}
+	ErrNotifyChannelInvalid = &UseCaseError{Code: -1034, Message: "notify event or channel invalid"}
+	ErrNotifyAddressInvalid = &UseCaseError{Code: -1035, Message: "notify channel address missing or invalid"}
+)
+
+var (
+	ErrReportNotFound    = &UseCaseError{Code: -1036, Message: "report not found"}
+	ErrReportDateInvalid = &UseCaseError{Code: -1037, Message: "report date invalid"}
+)
+
+var (
+	ErrWatchlistNotFound     = &UseCaseError{Code: -1038, Message: "watchlist not found"}
+	ErrWatchlistInvalid      = &UseCaseError{Code: -1039, Message: "watchlist name empty or duplicated"}
+	ErrWatchlistCodeNotFound = &UseCaseError{Code: -1040, Message: "watchlist code is not a stock"}
+	ErrWatchlistSortInvalid  = &UseCaseError{Code: -1041, Message: "watchlist sort must contain every code once"}
+)
+
+var (
+	ErrTargetRuleInvalid = &UseCaseError{Code: -1042, Message: "target rule max count, price, volume or ratio invalid"}
+)
+
+var (
+	ErrIndicatorIntervalInvalid = &UseCaseError{Code: -1043, Message: "indicator interval must be day or minute"}
+	ErrIndicatorCodeNotFound    = &UseCaseError{Code: -1044, Message: "indicator code is not a stock"}
+	ErrIndicatorDateInvalid     = &UseCaseError{Code: -1045, Message: "indicator date invalid"}
+)
+
+var (
+	ErrPatternDateInvalid = &UseCaseError{Code: -1046, Message: "pattern date invalid"}
+	ErrPatternInvalid     = &UseCaseError{Code: -1047, Message: "pattern not supported"}
+)
+
+var (
+	ErrKbarRangeInvalid      = &UseCaseError{Code: -1048, Message: "kbar range from, to or resolution invalid"}
+	ErrKbarRangeTooLong      = &UseCaseError{Code: -1049, Message: "kbar range exceeds max days of resolution"}
+	ErrKbarRangeCodeNotFound = &UseCaseError{Code: -1050, Message: "kbar code is not a stock or future"}
+)
+
+var (
+	ErrOptionChainNotFound      = &UseCaseError{Code: -1051, Message: "option chain not found"}
+	ErrOptionNotFound           = &UseCaseError{Code: -1052, Message: "option not found"}
+	ErrOptionUnderlyingNotFound = &UseCaseError{Code: -1053, Message: "option underlying future not found"}
+	ErrOptionNotSupported       = &UseCaseError{Code: -1060, Message: "only TAIEX options can be traded"}
+	ErrOptionOrderInvalid       = &UseCaseError{Code: -1061, Message: "option price and quantity must be positive"}
+)
+
+var (
+	ErrContinuousCodeInvalid      = &UseCaseError{Code: -1054, Message: "continuous code must be category, nth and !, e.g. MXF1!"}
+	ErrContinuousContractNotFound = &UseCaseError{Code: -1055, Message: "continuous contract has no future in range"}
+)
+
+var (
+	ErrCloseRangeInvalid = &UseCaseError{Code: -1056, Message: "close range from or to invalid"}
+	ErrCloseRangeTooLong = &UseCaseError{Code: -1057, Message: "close range exceeds 366 days"}
+	ErrCloseCodeNotFound = &UseCaseError{Code: -1058, Message: "close code is not a stock"}
+)
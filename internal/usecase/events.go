@@ -13,6 +13,8 @@ const (
 
 const (
 	topicInsertOrUpdateFutureOrder string = "insert_or_update_future_order"
+
+	topicInsertOrUpdateOptionOrder string = "insert_or_update_option_order"
 )
 
 const (
@@ -20,3 +22,15 @@ const (
 
 	topicUpdatePushUser string = "update_push_user"
 )
+
+const (
+	topicAlertTriggered string = "alert_triggered"
+)
+
+const (
+	topicDailyReportCreated string = "daily_report_created"
+)
+
+const (
+	topicFutureExpiryWarning string = "future_expiry_warning"
+)
@@ -32,6 +32,7 @@ type RealTimegRPCAPI interface {
 	GetNasdaqFuture() (*pb.YahooFinancePrice, error)
 	GetStockVolumeRank(date string) ([]*pb.StockVolumeRankMessage, error)
 	GetFutureSnapshotByCode(code string) (*pb.SnapshotMessage, error)
+	GetOptionSnapshotByCodeArr(codeArr []string) ([]*pb.SnapshotMessage, error)
 	GetStockVolumeRankPB(date string) (*pb.StockVolumeRankResponse, error)
 }
 
@@ -63,6 +64,9 @@ type TradegRPCAPI interface {
 	SellFuture(order *entity.FutureOrder) (*pb.TradeResult, error)
 	SellFirstFuture(order *entity.FutureOrder) (*pb.TradeResult, error)
 
+	BuyOption(order *entity.OptionOrder) (*pb.TradeResult, error)
+	SellOption(order *entity.OptionOrder) (*pb.TradeResult, error)
+
 	GetLocalOrderStatusArr() error
 	GetSimulateOrderStatusArr() error
 
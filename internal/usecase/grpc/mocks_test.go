@@ -297,6 +297,21 @@ func (mr *MockRealTimegRPCAPIMockRecorder) GetNasdaqFuture() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNasdaqFuture", reflect.TypeOf((*MockRealTimegRPCAPI)(nil).GetNasdaqFuture))
 }
 
+// GetOptionSnapshotByCodeArr mocks base method.
+func (m *MockRealTimegRPCAPI) GetOptionSnapshotByCodeArr(codeArr []string) ([]*pb.SnapshotMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOptionSnapshotByCodeArr", codeArr)
+	ret0, _ := ret[0].([]*pb.SnapshotMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOptionSnapshotByCodeArr indicates an expected call of GetOptionSnapshotByCodeArr.
+func (mr *MockRealTimegRPCAPIMockRecorder) GetOptionSnapshotByCodeArr(codeArr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOptionSnapshotByCodeArr", reflect.TypeOf((*MockRealTimegRPCAPI)(nil).GetOptionSnapshotByCodeArr), codeArr)
+}
+
 // GetStockSnapshotByNumArr mocks base method.
 func (m *MockRealTimegRPCAPI) GetStockSnapshotByNumArr(stockNumArr []string) ([]*pb.SnapshotMessage, error) {
 	m.ctrl.T.Helper()
@@ -600,6 +615,21 @@ func (mr *MockTradegRPCAPIMockRecorder) BuyOddStock(order any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuyOddStock", reflect.TypeOf((*MockTradegRPCAPI)(nil).BuyOddStock), order)
 }
 
+// BuyOption mocks base method.
+func (m *MockTradegRPCAPI) BuyOption(order *entity.OptionOrder) (*pb.TradeResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BuyOption", order)
+	ret0, _ := ret[0].(*pb.TradeResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BuyOption indicates an expected call of BuyOption.
+func (mr *MockTradegRPCAPIMockRecorder) BuyOption(order any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuyOption", reflect.TypeOf((*MockTradegRPCAPI)(nil).BuyOption), order)
+}
+
 // BuyStock mocks base method.
 func (m *MockTradegRPCAPI) BuyStock(order *entity.StockOrder) (*pb.TradeResult, error) {
 	m.ctrl.T.Helper()
@@ -793,6 +823,21 @@ func (mr *MockTradegRPCAPIMockRecorder) SellOddStock(order any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SellOddStock", reflect.TypeOf((*MockTradegRPCAPI)(nil).SellOddStock), order)
 }
 
+// SellOption mocks base method.
+func (m *MockTradegRPCAPI) SellOption(order *entity.OptionOrder) (*pb.TradeResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SellOption", order)
+	ret0, _ := ret[0].(*pb.TradeResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SellOption indicates an expected call of SellOption.
+func (mr *MockTradegRPCAPIMockRecorder) SellOption(order any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SellOption", reflect.TypeOf((*MockTradegRPCAPI)(nil).SellOption), order)
+}
+
 // SellStock mocks base method.
 func (m *MockTradegRPCAPI) SellStock(order *entity.StockOrder) (*pb.TradeResult, error) {
 	m.ctrl.T.Helper()
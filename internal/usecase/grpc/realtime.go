@@ -78,6 +78,17 @@ func (t *realtime) GetFutureSnapshotByCode(code string) (*pb.SnapshotMessage, er
 	return nil, errors.New("no data")
 }
 
+// GetOptionSnapshotByCodeArr uses the future snapshot rpc, the forwarder resolves derivative contracts by code.
+func (t *realtime) GetOptionSnapshotByCodeArr(codeArr []string) ([]*pb.SnapshotMessage, error) {
+	r, err := pb.NewRealTimeDataInterfaceClient(t.conn).GetFutureSnapshotByCodeArr(context.Background(), &pb.FutureCodeArr{
+		FutureCodeArr: codeArr,
+	})
+	if err != nil {
+		return []*pb.SnapshotMessage{}, err
+	}
+	return r.GetData(), nil
+}
+
 func (t *realtime) GetNasdaq() (*pb.YahooFinancePrice, error) {
 	r, err := pb.NewRealTimeDataInterfaceClient(t.conn).GetNasdaq(context.Background(), &emptypb.Empty{})
 	if err != nil {
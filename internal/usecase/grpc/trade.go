@@ -204,3 +204,31 @@ func (t *trade) SellFirstFuture(order *entity.FutureOrder) (*pb.TradeResult, err
 	}
 	return r, nil
 }
+
+// BuyOption -.
+func (t *trade) BuyOption(order *entity.OptionOrder) (*pb.TradeResult, error) {
+	r, err := pb.NewTradeInterfaceClient(t.conn).BuyOption(context.Background(), &pb.OptionOrderDetail{
+		Code:     order.Code,
+		Price:    order.Price,
+		Quantity: order.Quantity,
+		Simulate: t.sim,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// SellOption -.
+func (t *trade) SellOption(order *entity.OptionOrder) (*pb.TradeResult, error) {
+	r, err := pb.NewTradeInterfaceClient(t.conn).SellOption(context.Background(), &pb.OptionOrderDetail{
+		Code:     order.Code,
+		Price:    order.Price,
+		Quantity: order.Quantity,
+		Simulate: t.sim,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
@@ -11,7 +11,7 @@ import (
 //go:generate mockgen -source=interfaces.go -destination=./mocks_test.go -package=usecase
 
 type Analyze interface {
-	GetRebornMap(ctx context.Context) map[time.Time][]entity.Stock
+	GetPatternHits(ctx context.Context, date, pattern string, limit, offset uint64) (*entity.PatternHitList, error)
 }
 
 type Basic interface {
@@ -20,11 +20,22 @@ type Basic interface {
 	GetShioajiUsage() (*entity.ShioajiUsage, error)
 	CreateStockSearchRoom(com chan string, dataChan chan []*entity.Stock)
 	CreateFutureSearchRoom(com chan string, dataChan chan []*entity.Future)
+	CreateOptionSearchRoom(com chan string, dataChan chan []*entity.Option)
+}
+
+type Option interface {
+	GetOptionChains(ctx context.Context) []*entity.OptionChainInfo
+	GetOptionChain(ctx context.Context, category, deliveryMonth, underlying string) (*entity.OptionChain, error)
+	GetOptionSnapshot(ctx context.Context, code string) (*entity.OptionQuote, error)
 }
 
 type History interface {
 	GetDayKbarByStockNumMultiDate(stockNum string, date time.Time, interval int64) ([]*entity.StockHistoryKbar, error)
 	GetFutureHistoryPBKbarByDate(code string, date time.Time) (*pb.HistoryKbarResponse, error)
+	GetIndicators(ctx context.Context, code string, interval entity.IndicatorInterval, date string) (*entity.Indicators, error)
+	GetKbarRange(ctx context.Context, code, from, to string, resolution entity.KbarResolution) (*entity.KbarRange, error)
+	GetContinuousContract(ctx context.Context, code, from, to string) (*entity.ContinuousContract, error)
+	GetStockCloseSeries(ctx context.Context, stockNum, from, to string) (*entity.StockCloseSeries, error)
 }
 
 type RealTime interface {
@@ -38,6 +49,9 @@ type RealTime interface {
 
 type Target interface {
 	GetTargets(ctx context.Context) []*entity.StockTarget
+	GetTargetRule(ctx context.Context) *entity.TargetRule
+	UpdateTargetRule(ctx context.Context, rule *entity.TargetRule) error
+	SubscribeNewTargets(ctx context.Context) <-chan []*entity.StockTarget
 	GetCurrentVolumeRank() (*pb.StockVolumeRankResponse, error)
 }
 
@@ -47,10 +61,15 @@ type Trade interface {
 	GetAllStockTradeBalance(ctx context.Context) ([]*entity.StockTradeBalance, error)
 	GetAllFutureTradeBalance(ctx context.Context) ([]*entity.FutureTradeBalance, error)
 	GetFutureOrderByTradeDay(ctx context.Context, tradeDay string) ([]*entity.FutureOrder, error)
+	GetAllOptionOrder(ctx context.Context) ([]*entity.OptionOrder, error)
+	GetAllOptionTradeBalance(ctx context.Context) ([]*entity.OptionTradeBalance, error)
 	BuyOddStock(num string, price float64, share int64) (string, entity.OrderStatus, error)
 	SelloddStock(num string, price float64, share int64) (string, entity.OrderStatus, error)
 	BuyFuture(order *entity.FutureOrder) (string, entity.OrderStatus, error)
+	GetFutureExpiryWarnings(ctx context.Context) ([]*entity.FutureExpiryWarning, error)
 	SellFuture(order *entity.FutureOrder) (string, entity.OrderStatus, error)
+	BuyOption(order *entity.OptionOrder) (string, entity.OrderStatus, error)
+	SellOption(order *entity.OptionOrder) (string, entity.OrderStatus, error)
 	CancelOrderByID(orderID string) (string, entity.OrderStatus, error)
 	GetFuturePosition() ([]*entity.FuturePosition, error)
 	IsFutureTradeTime() bool
@@ -60,18 +79,72 @@ type Trade interface {
 
 type System interface {
 	AddUser(ctx context.Context, t *entity.NewUser) error
-	InsertPushToken(ctx context.Context, token, username string, enabled bool) error
-	Login(ctx context.Context, username, password string) error
+	InsertPushToken(ctx context.Context, token, username string, enabled, orderPush bool) error
+	Login(ctx context.Context, username, password, totpCode string) (*entity.User, error)
 	VerifyEmail(ctx context.Context, username, code string) error
+	ResendVerification(ctx context.Context, email string) error
 	UpdateAuthTradeUser()
 	DeleteAllPushTokens(ctx context.Context) error
 	IsPushTokenEnabled(ctx context.Context, token string) (bool, error)
 	GetUserInfo(ctx context.Context, username string) (*entity.User, error)
-	GetLastJWT(ctx context.Context) (string, error)
-	InsertJWT(ctx context.Context, jwt string) error
+	GetJWTKeys(ctx context.Context) ([]*entity.JWTKey, error)
+	RotateJWTKey(ctx context.Context, grace time.Duration) error
+	GetAllUser(ctx context.Context) ([]*entity.User, error)
+	GetAllRole() []*entity.Role
+	GetRole(name string) *entity.Role
+	UpdateUserRole(ctx context.Context, username, role string) error
+	ChangePassword(ctx context.Context, username, oldPassword, newPassword string) error
+	SendPasswordResetCode(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, email, code, newPassword string) error
+	IsTokenRevoked(ctx context.Context, username, sessionID string, loginAt time.Time) bool
+	CreateSession(ctx context.Context, user *entity.User, userAgent, ip string) (*entity.Session, string, error)
+	RefreshSession(ctx context.Context, refreshToken, userAgent, ip string) (*entity.User, *entity.Session, string, error)
+	GetActiveSessions(ctx context.Context, username, currentSessionID string) ([]*entity.Session, error)
+	RevokeSession(ctx context.Context, username, sessionID string) error
+	EnrollTOTP(ctx context.Context, username string) (*entity.TOTPEnrollment, error)
+	ConfirmTOTP(ctx context.Context, username, code string) error
+	DisableTOTP(ctx context.Context, username, code string) error
+	GetTOTPStatus(ctx context.Context, username string) (*entity.TOTPStatus, error)
+	GetSetting(ctx context.Context) (*entity.SystemSetting, error)
+	UpdateSetting(ctx context.Context, setting *entity.SystemSetting) error
+	UpdateUserAuthTrade(ctx context.Context, username string, authTrade bool) error
+	CreateAPIKey(ctx context.Context, username string, t *entity.NewAPIKey) (*entity.CreatedAPIKey, error)
+	GetAPIKeys(ctx context.Context, username string) ([]*entity.APIKey, error)
+	RevokeAPIKey(ctx context.Context, username, keyID string) error
+	AuthenticateAPIKey(ctx context.Context, key, ip string) (*entity.User, *entity.APIKey, error)
 }
 
 type FCM interface {
 	AnnounceMessage(msg string) error
 	PushNotification(title, msg string) error
 }
+
+type Notify interface {
+	GetNotifyPreference(ctx context.Context, username string) (*entity.NotifyPreference, error)
+	UpdateNotifyPreference(ctx context.Context, username string, t *entity.NotifyPreference) error
+}
+
+type Alert interface {
+	CreateAlertRule(ctx context.Context, username string, t *entity.NewAlertRule) (*entity.AlertRule, error)
+	GetAlertRules(ctx context.Context, username string) ([]*entity.AlertRule, error)
+	UpdateAlertRule(ctx context.Context, username string, id int, t *entity.NewAlertRule) (*entity.AlertRule, error)
+	DeleteAlertRule(ctx context.Context, username string, id int) error
+	GetAlertHistory(ctx context.Context, username string, limit, offset uint64) ([]*entity.AlertHistory, error)
+}
+
+type Report interface {
+	GetDailyReport(ctx context.Context, date string) (*entity.DailyReport, error)
+}
+
+type Watchlist interface {
+	GetWatchlists(ctx context.Context, username string) ([]*entity.Watchlist, error)
+	CreateWatchlist(ctx context.Context, username string, t *entity.NewWatchlist) (*entity.Watchlist, error)
+	UpdateWatchlist(ctx context.Context, username string, id int, t *entity.NewWatchlist) (*entity.Watchlist, error)
+	DeleteWatchlist(ctx context.Context, username string, id int) error
+	PutWatchlistItem(ctx context.Context, username string, id int, t *entity.NewWatchlistItem) (*entity.Watchlist, error)
+	DeleteWatchlistItem(ctx context.Context, username string, id int, code string) (*entity.Watchlist, error)
+	SortWatchlistItem(ctx context.Context, username string, id int, codes []string) (*entity.Watchlist, error)
+
+	GetDefaultWatchlistCodes(ctx context.Context, username string) ([]string, error)
+	SyncDefaultWatchlist(ctx context.Context, username string, add, remove []string) error
+}
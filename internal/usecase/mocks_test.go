@@ -43,18 +43,19 @@ func (m *MockAnalyze) EXPECT() *MockAnalyzeMockRecorder {
 	return m.recorder
 }
 
-// GetRebornMap mocks base method.
-func (m *MockAnalyze) GetRebornMap(ctx context.Context) map[time.Time][]entity.Stock {
+// GetPatternHits mocks base method.
+func (m *MockAnalyze) GetPatternHits(ctx context.Context, date, pattern string, limit, offset uint64) (*entity.PatternHitList, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRebornMap", ctx)
-	ret0, _ := ret[0].(map[time.Time][]entity.Stock)
-	return ret0
+	ret := m.ctrl.Call(m, "GetPatternHits", ctx, date, pattern, limit, offset)
+	ret0, _ := ret[0].(*entity.PatternHitList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// GetRebornMap indicates an expected call of GetRebornMap.
-func (mr *MockAnalyzeMockRecorder) GetRebornMap(ctx any) *gomock.Call {
+// GetPatternHits indicates an expected call of GetPatternHits.
+func (mr *MockAnalyzeMockRecorder) GetPatternHits(ctx, date, pattern, limit, offset any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRebornMap", reflect.TypeOf((*MockAnalyze)(nil).GetRebornMap), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPatternHits", reflect.TypeOf((*MockAnalyze)(nil).GetPatternHits), ctx, date, pattern, limit, offset)
 }
 
 // MockBasic is a mock of Basic interface.
@@ -93,6 +94,18 @@ func (mr *MockBasicMockRecorder) CreateFutureSearchRoom(com, dataChan any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFutureSearchRoom", reflect.TypeOf((*MockBasic)(nil).CreateFutureSearchRoom), com, dataChan)
 }
 
+// CreateOptionSearchRoom mocks base method.
+func (m *MockBasic) CreateOptionSearchRoom(com chan string, dataChan chan []*entity.Option) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "CreateOptionSearchRoom", com, dataChan)
+}
+
+// CreateOptionSearchRoom indicates an expected call of CreateOptionSearchRoom.
+func (mr *MockBasicMockRecorder) CreateOptionSearchRoom(com, dataChan any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOptionSearchRoom", reflect.TypeOf((*MockBasic)(nil).CreateOptionSearchRoom), com, dataChan)
+}
+
 // CreateStockSearchRoom mocks base method.
 func (m *MockBasic) CreateStockSearchRoom(com chan string, dataChan chan []*entity.Stock) {
 	m.ctrl.T.Helper()
@@ -148,6 +161,74 @@ func (mr *MockBasicMockRecorder) GetStockDetail(stockNum any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStockDetail", reflect.TypeOf((*MockBasic)(nil).GetStockDetail), stockNum)
 }
 
+// MockOption is a mock of Option interface.
+type MockOption struct {
+	ctrl     *gomock.Controller
+	recorder *MockOptionMockRecorder
+	isgomock struct{}
+}
+
+// MockOptionMockRecorder is the mock recorder for MockOption.
+type MockOptionMockRecorder struct {
+	mock *MockOption
+}
+
+// NewMockOption creates a new mock instance.
+func NewMockOption(ctrl *gomock.Controller) *MockOption {
+	mock := &MockOption{ctrl: ctrl}
+	mock.recorder = &MockOptionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOption) EXPECT() *MockOptionMockRecorder {
+	return m.recorder
+}
+
+// GetOptionChain mocks base method.
+func (m *MockOption) GetOptionChain(ctx context.Context, category, deliveryMonth, underlying string) (*entity.OptionChain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOptionChain", ctx, category, deliveryMonth, underlying)
+	ret0, _ := ret[0].(*entity.OptionChain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOptionChain indicates an expected call of GetOptionChain.
+func (mr *MockOptionMockRecorder) GetOptionChain(ctx, category, deliveryMonth, underlying any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOptionChain", reflect.TypeOf((*MockOption)(nil).GetOptionChain), ctx, category, deliveryMonth, underlying)
+}
+
+// GetOptionChains mocks base method.
+func (m *MockOption) GetOptionChains(ctx context.Context) []*entity.OptionChainInfo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOptionChains", ctx)
+	ret0, _ := ret[0].([]*entity.OptionChainInfo)
+	return ret0
+}
+
+// GetOptionChains indicates an expected call of GetOptionChains.
+func (mr *MockOptionMockRecorder) GetOptionChains(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOptionChains", reflect.TypeOf((*MockOption)(nil).GetOptionChains), ctx)
+}
+
+// GetOptionSnapshot mocks base method.
+func (m *MockOption) GetOptionSnapshot(ctx context.Context, code string) (*entity.OptionQuote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOptionSnapshot", ctx, code)
+	ret0, _ := ret[0].(*entity.OptionQuote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOptionSnapshot indicates an expected call of GetOptionSnapshot.
+func (mr *MockOptionMockRecorder) GetOptionSnapshot(ctx, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOptionSnapshot", reflect.TypeOf((*MockOption)(nil).GetOptionSnapshot), ctx, code)
+}
+
 // MockHistory is a mock of History interface.
 type MockHistory struct {
 	ctrl     *gomock.Controller
@@ -172,6 +253,21 @@ func (m *MockHistory) EXPECT() *MockHistoryMockRecorder {
 	return m.recorder
 }
 
+// GetContinuousContract mocks base method.
+func (m *MockHistory) GetContinuousContract(ctx context.Context, code, from, to string) (*entity.ContinuousContract, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContinuousContract", ctx, code, from, to)
+	ret0, _ := ret[0].(*entity.ContinuousContract)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContinuousContract indicates an expected call of GetContinuousContract.
+func (mr *MockHistoryMockRecorder) GetContinuousContract(ctx, code, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContinuousContract", reflect.TypeOf((*MockHistory)(nil).GetContinuousContract), ctx, code, from, to)
+}
+
 // GetDayKbarByStockNumMultiDate mocks base method.
 func (m *MockHistory) GetDayKbarByStockNumMultiDate(stockNum string, date time.Time, interval int64) ([]*entity.StockHistoryKbar, error) {
 	m.ctrl.T.Helper()
@@ -202,6 +298,51 @@ func (mr *MockHistoryMockRecorder) GetFutureHistoryPBKbarByDate(code, date any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFutureHistoryPBKbarByDate", reflect.TypeOf((*MockHistory)(nil).GetFutureHistoryPBKbarByDate), code, date)
 }
 
+// GetIndicators mocks base method.
+func (m *MockHistory) GetIndicators(ctx context.Context, code string, interval entity.IndicatorInterval, date string) (*entity.Indicators, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIndicators", ctx, code, interval, date)
+	ret0, _ := ret[0].(*entity.Indicators)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIndicators indicates an expected call of GetIndicators.
+func (mr *MockHistoryMockRecorder) GetIndicators(ctx, code, interval, date any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIndicators", reflect.TypeOf((*MockHistory)(nil).GetIndicators), ctx, code, interval, date)
+}
+
+// GetKbarRange mocks base method.
+func (m *MockHistory) GetKbarRange(ctx context.Context, code, from, to string, resolution entity.KbarResolution) (*entity.KbarRange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKbarRange", ctx, code, from, to, resolution)
+	ret0, _ := ret[0].(*entity.KbarRange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKbarRange indicates an expected call of GetKbarRange.
+func (mr *MockHistoryMockRecorder) GetKbarRange(ctx, code, from, to, resolution any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKbarRange", reflect.TypeOf((*MockHistory)(nil).GetKbarRange), ctx, code, from, to, resolution)
+}
+
+// GetStockCloseSeries mocks base method.
+func (m *MockHistory) GetStockCloseSeries(ctx context.Context, stockNum, from, to string) (*entity.StockCloseSeries, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStockCloseSeries", ctx, stockNum, from, to)
+	ret0, _ := ret[0].(*entity.StockCloseSeries)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStockCloseSeries indicates an expected call of GetStockCloseSeries.
+func (mr *MockHistoryMockRecorder) GetStockCloseSeries(ctx, stockNum, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStockCloseSeries", reflect.TypeOf((*MockHistory)(nil).GetStockCloseSeries), ctx, stockNum, from, to)
+}
+
 // MockRealTime is a mock of RealTime interface.
 type MockRealTime struct {
 	ctrl     *gomock.Controller
@@ -345,6 +486,20 @@ func (mr *MockTargetMockRecorder) GetCurrentVolumeRank() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentVolumeRank", reflect.TypeOf((*MockTarget)(nil).GetCurrentVolumeRank))
 }
 
+// GetTargetRule mocks base method.
+func (m *MockTarget) GetTargetRule(ctx context.Context) *entity.TargetRule {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTargetRule", ctx)
+	ret0, _ := ret[0].(*entity.TargetRule)
+	return ret0
+}
+
+// GetTargetRule indicates an expected call of GetTargetRule.
+func (mr *MockTargetMockRecorder) GetTargetRule(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTargetRule", reflect.TypeOf((*MockTarget)(nil).GetTargetRule), ctx)
+}
+
 // GetTargets mocks base method.
 func (m *MockTarget) GetTargets(ctx context.Context) []*entity.StockTarget {
 	m.ctrl.T.Helper()
@@ -359,6 +514,34 @@ func (mr *MockTargetMockRecorder) GetTargets(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTargets", reflect.TypeOf((*MockTarget)(nil).GetTargets), ctx)
 }
 
+// SubscribeNewTargets mocks base method.
+func (m *MockTarget) SubscribeNewTargets(ctx context.Context) <-chan []*entity.StockTarget {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeNewTargets", ctx)
+	ret0, _ := ret[0].(<-chan []*entity.StockTarget)
+	return ret0
+}
+
+// SubscribeNewTargets indicates an expected call of SubscribeNewTargets.
+func (mr *MockTargetMockRecorder) SubscribeNewTargets(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeNewTargets", reflect.TypeOf((*MockTarget)(nil).SubscribeNewTargets), ctx)
+}
+
+// UpdateTargetRule mocks base method.
+func (m *MockTarget) UpdateTargetRule(ctx context.Context, rule *entity.TargetRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTargetRule", ctx, rule)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTargetRule indicates an expected call of UpdateTargetRule.
+func (mr *MockTargetMockRecorder) UpdateTargetRule(ctx, rule any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTargetRule", reflect.TypeOf((*MockTarget)(nil).UpdateTargetRule), ctx, rule)
+}
+
 // MockTrade is a mock of Trade interface.
 type MockTrade struct {
 	ctrl     *gomock.Controller
@@ -415,6 +598,22 @@ func (mr *MockTradeMockRecorder) BuyOddStock(num, price, share any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuyOddStock", reflect.TypeOf((*MockTrade)(nil).BuyOddStock), num, price, share)
 }
 
+// BuyOption mocks base method.
+func (m *MockTrade) BuyOption(order *entity.OptionOrder) (string, entity.OrderStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BuyOption", order)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(entity.OrderStatus)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// BuyOption indicates an expected call of BuyOption.
+func (mr *MockTradeMockRecorder) BuyOption(order any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuyOption", reflect.TypeOf((*MockTrade)(nil).BuyOption), order)
+}
+
 // CancelOrderByID mocks base method.
 func (m *MockTrade) CancelOrderByID(orderID string) (string, entity.OrderStatus, error) {
 	m.ctrl.T.Helper()
@@ -461,6 +660,36 @@ func (mr *MockTradeMockRecorder) GetAllFutureTradeBalance(ctx any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllFutureTradeBalance", reflect.TypeOf((*MockTrade)(nil).GetAllFutureTradeBalance), ctx)
 }
 
+// GetAllOptionOrder mocks base method.
+func (m *MockTrade) GetAllOptionOrder(ctx context.Context) ([]*entity.OptionOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllOptionOrder", ctx)
+	ret0, _ := ret[0].([]*entity.OptionOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllOptionOrder indicates an expected call of GetAllOptionOrder.
+func (mr *MockTradeMockRecorder) GetAllOptionOrder(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllOptionOrder", reflect.TypeOf((*MockTrade)(nil).GetAllOptionOrder), ctx)
+}
+
+// GetAllOptionTradeBalance mocks base method.
+func (m *MockTrade) GetAllOptionTradeBalance(ctx context.Context) ([]*entity.OptionTradeBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllOptionTradeBalance", ctx)
+	ret0, _ := ret[0].([]*entity.OptionTradeBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllOptionTradeBalance indicates an expected call of GetAllOptionTradeBalance.
+func (mr *MockTradeMockRecorder) GetAllOptionTradeBalance(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllOptionTradeBalance", reflect.TypeOf((*MockTrade)(nil).GetAllOptionTradeBalance), ctx)
+}
+
 // GetAllStockOrder mocks base method.
 func (m *MockTrade) GetAllStockOrder(ctx context.Context) ([]*entity.StockOrder, error) {
 	m.ctrl.T.Helper()
@@ -491,6 +720,21 @@ func (mr *MockTradeMockRecorder) GetAllStockTradeBalance(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllStockTradeBalance", reflect.TypeOf((*MockTrade)(nil).GetAllStockTradeBalance), ctx)
 }
 
+// GetFutureExpiryWarnings mocks base method.
+func (m *MockTrade) GetFutureExpiryWarnings(ctx context.Context) ([]*entity.FutureExpiryWarning, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFutureExpiryWarnings", ctx)
+	ret0, _ := ret[0].([]*entity.FutureExpiryWarning)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFutureExpiryWarnings indicates an expected call of GetFutureExpiryWarnings.
+func (mr *MockTradeMockRecorder) GetFutureExpiryWarnings(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFutureExpiryWarnings", reflect.TypeOf((*MockTrade)(nil).GetFutureExpiryWarnings), ctx)
+}
+
 // GetFutureOrderByTradeDay mocks base method.
 func (m *MockTrade) GetFutureOrderByTradeDay(ctx context.Context, tradeDay string) ([]*entity.FutureOrder, error) {
 	m.ctrl.T.Helper()
@@ -580,6 +824,22 @@ func (mr *MockTradeMockRecorder) SellFuture(order any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SellFuture", reflect.TypeOf((*MockTrade)(nil).SellFuture), order)
 }
 
+// SellOption mocks base method.
+func (m *MockTrade) SellOption(order *entity.OptionOrder) (string, entity.OrderStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SellOption", order)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(entity.OrderStatus)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SellOption indicates an expected call of SellOption.
+func (mr *MockTradeMockRecorder) SellOption(order any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SellOption", reflect.TypeOf((*MockTrade)(nil).SellOption), order)
+}
+
 // SelloddStock mocks base method.
 func (m *MockTrade) SelloddStock(num string, price float64, share int64) (string, entity.OrderStatus, error) {
 	m.ctrl.T.Helper()
@@ -634,181 +894,879 @@ func (mr *MockSystemMockRecorder) AddUser(ctx, t any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUser", reflect.TypeOf((*MockSystem)(nil).AddUser), ctx, t)
 }
 
-// DeleteAllPushTokens mocks base method.
-func (m *MockSystem) DeleteAllPushTokens(ctx context.Context) error {
+// AuthenticateAPIKey mocks base method.
+func (m *MockSystem) AuthenticateAPIKey(ctx context.Context, key, ip string) (*entity.User, *entity.APIKey, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteAllPushTokens", ctx)
+	ret := m.ctrl.Call(m, "AuthenticateAPIKey", ctx, key, ip)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(*entity.APIKey)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// AuthenticateAPIKey indicates an expected call of AuthenticateAPIKey.
+func (mr *MockSystemMockRecorder) AuthenticateAPIKey(ctx, key, ip any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthenticateAPIKey", reflect.TypeOf((*MockSystem)(nil).AuthenticateAPIKey), ctx, key, ip)
+}
+
+// ChangePassword mocks base method.
+func (m *MockSystem) ChangePassword(ctx context.Context, username, oldPassword, newPassword string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", ctx, username, oldPassword, newPassword)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteAllPushTokens indicates an expected call of DeleteAllPushTokens.
-func (mr *MockSystemMockRecorder) DeleteAllPushTokens(ctx any) *gomock.Call {
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockSystemMockRecorder) ChangePassword(ctx, username, oldPassword, newPassword any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAllPushTokens", reflect.TypeOf((*MockSystem)(nil).DeleteAllPushTokens), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockSystem)(nil).ChangePassword), ctx, username, oldPassword, newPassword)
 }
 
-// GetLastJWT mocks base method.
-func (m *MockSystem) GetLastJWT(ctx context.Context) (string, error) {
+// ConfirmTOTP mocks base method.
+func (m *MockSystem) ConfirmTOTP(ctx context.Context, username, code string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLastJWT", ctx)
-	ret0, _ := ret[0].(string)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "ConfirmTOTP", ctx, username, code)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetLastJWT indicates an expected call of GetLastJWT.
-func (mr *MockSystemMockRecorder) GetLastJWT(ctx any) *gomock.Call {
+// ConfirmTOTP indicates an expected call of ConfirmTOTP.
+func (mr *MockSystemMockRecorder) ConfirmTOTP(ctx, username, code any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastJWT", reflect.TypeOf((*MockSystem)(nil).GetLastJWT), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmTOTP", reflect.TypeOf((*MockSystem)(nil).ConfirmTOTP), ctx, username, code)
 }
 
-// GetUserInfo mocks base method.
-func (m *MockSystem) GetUserInfo(ctx context.Context, username string) (*entity.User, error) {
+// CreateAPIKey mocks base method.
+func (m *MockSystem) CreateAPIKey(ctx context.Context, username string, t *entity.NewAPIKey) (*entity.CreatedAPIKey, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserInfo", ctx, username)
-	ret0, _ := ret[0].(*entity.User)
+	ret := m.ctrl.Call(m, "CreateAPIKey", ctx, username, t)
+	ret0, _ := ret[0].(*entity.CreatedAPIKey)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserInfo indicates an expected call of GetUserInfo.
-func (mr *MockSystemMockRecorder) GetUserInfo(ctx, username any) *gomock.Call {
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockSystemMockRecorder) CreateAPIKey(ctx, username, t any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserInfo", reflect.TypeOf((*MockSystem)(nil).GetUserInfo), ctx, username)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockSystem)(nil).CreateAPIKey), ctx, username, t)
+}
+
+// CreateSession mocks base method.
+func (m *MockSystem) CreateSession(ctx context.Context, user *entity.User, userAgent, ip string) (*entity.Session, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSession", ctx, user, userAgent, ip)
+	ret0, _ := ret[0].(*entity.Session)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateSession indicates an expected call of CreateSession.
+func (mr *MockSystemMockRecorder) CreateSession(ctx, user, userAgent, ip any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockSystem)(nil).CreateSession), ctx, user, userAgent, ip)
 }
 
-// InsertJWT mocks base method.
-func (m *MockSystem) InsertJWT(ctx context.Context, jwt string) error {
+// DeleteAllPushTokens mocks base method.
+func (m *MockSystem) DeleteAllPushTokens(ctx context.Context) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InsertJWT", ctx, jwt)
+	ret := m.ctrl.Call(m, "DeleteAllPushTokens", ctx)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// InsertJWT indicates an expected call of InsertJWT.
-func (mr *MockSystemMockRecorder) InsertJWT(ctx, jwt any) *gomock.Call {
+// DeleteAllPushTokens indicates an expected call of DeleteAllPushTokens.
+func (mr *MockSystemMockRecorder) DeleteAllPushTokens(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertJWT", reflect.TypeOf((*MockSystem)(nil).InsertJWT), ctx, jwt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAllPushTokens", reflect.TypeOf((*MockSystem)(nil).DeleteAllPushTokens), ctx)
 }
 
-// InsertPushToken mocks base method.
-func (m *MockSystem) InsertPushToken(ctx context.Context, token, username string, enabled bool) error {
+// DisableTOTP mocks base method.
+func (m *MockSystem) DisableTOTP(ctx context.Context, username, code string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InsertPushToken", ctx, token, username, enabled)
+	ret := m.ctrl.Call(m, "DisableTOTP", ctx, username, code)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// InsertPushToken indicates an expected call of InsertPushToken.
-func (mr *MockSystemMockRecorder) InsertPushToken(ctx, token, username, enabled any) *gomock.Call {
+// DisableTOTP indicates an expected call of DisableTOTP.
+func (mr *MockSystemMockRecorder) DisableTOTP(ctx, username, code any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertPushToken", reflect.TypeOf((*MockSystem)(nil).InsertPushToken), ctx, token, username, enabled)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableTOTP", reflect.TypeOf((*MockSystem)(nil).DisableTOTP), ctx, username, code)
 }
 
-// IsPushTokenEnabled mocks base method.
-func (m *MockSystem) IsPushTokenEnabled(ctx context.Context, token string) (bool, error) {
+// EnrollTOTP mocks base method.
+func (m *MockSystem) EnrollTOTP(ctx context.Context, username string) (*entity.TOTPEnrollment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IsPushTokenEnabled", ctx, token)
-	ret0, _ := ret[0].(bool)
+	ret := m.ctrl.Call(m, "EnrollTOTP", ctx, username)
+	ret0, _ := ret[0].(*entity.TOTPEnrollment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// IsPushTokenEnabled indicates an expected call of IsPushTokenEnabled.
-func (mr *MockSystemMockRecorder) IsPushTokenEnabled(ctx, token any) *gomock.Call {
+// EnrollTOTP indicates an expected call of EnrollTOTP.
+func (mr *MockSystemMockRecorder) EnrollTOTP(ctx, username any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsPushTokenEnabled", reflect.TypeOf((*MockSystem)(nil).IsPushTokenEnabled), ctx, token)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollTOTP", reflect.TypeOf((*MockSystem)(nil).EnrollTOTP), ctx, username)
 }
 
-// Login mocks base method.
-func (m *MockSystem) Login(ctx context.Context, username, password string) error {
+// GetAPIKeys mocks base method.
+func (m *MockSystem) GetAPIKeys(ctx context.Context, username string) ([]*entity.APIKey, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Login", ctx, username, password)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "GetAPIKeys", ctx, username)
+	ret0, _ := ret[0].([]*entity.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// Login indicates an expected call of Login.
-func (mr *MockSystemMockRecorder) Login(ctx, username, password any) *gomock.Call {
+// GetAPIKeys indicates an expected call of GetAPIKeys.
+func (mr *MockSystemMockRecorder) GetAPIKeys(ctx, username any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockSystem)(nil).Login), ctx, username, password)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKeys", reflect.TypeOf((*MockSystem)(nil).GetAPIKeys), ctx, username)
 }
 
-// UpdateAuthTradeUser mocks base method.
-func (m *MockSystem) UpdateAuthTradeUser() {
+// GetActiveSessions mocks base method.
+func (m *MockSystem) GetActiveSessions(ctx context.Context, username, currentSessionID string) ([]*entity.Session, error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "UpdateAuthTradeUser")
+	ret := m.ctrl.Call(m, "GetActiveSessions", ctx, username, currentSessionID)
+	ret0, _ := ret[0].([]*entity.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// UpdateAuthTradeUser indicates an expected call of UpdateAuthTradeUser.
-func (mr *MockSystemMockRecorder) UpdateAuthTradeUser() *gomock.Call {
+// GetActiveSessions indicates an expected call of GetActiveSessions.
+func (mr *MockSystemMockRecorder) GetActiveSessions(ctx, username, currentSessionID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAuthTradeUser", reflect.TypeOf((*MockSystem)(nil).UpdateAuthTradeUser))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveSessions", reflect.TypeOf((*MockSystem)(nil).GetActiveSessions), ctx, username, currentSessionID)
 }
 
-// VerifyEmail mocks base method.
-func (m *MockSystem) VerifyEmail(ctx context.Context, username, code string) error {
+// GetAllRole mocks base method.
+func (m *MockSystem) GetAllRole() []*entity.Role {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "VerifyEmail", ctx, username, code)
-	ret0, _ := ret[0].(error)
+	ret := m.ctrl.Call(m, "GetAllRole")
+	ret0, _ := ret[0].([]*entity.Role)
 	return ret0
 }
 
-// VerifyEmail indicates an expected call of VerifyEmail.
-func (mr *MockSystemMockRecorder) VerifyEmail(ctx, username, code any) *gomock.Call {
+// GetAllRole indicates an expected call of GetAllRole.
+func (mr *MockSystemMockRecorder) GetAllRole() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockSystem)(nil).VerifyEmail), ctx, username, code)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllRole", reflect.TypeOf((*MockSystem)(nil).GetAllRole))
 }
 
-// MockFCM is a mock of FCM interface.
-type MockFCM struct {
-	ctrl     *gomock.Controller
-	recorder *MockFCMMockRecorder
-	isgomock struct{}
+// GetAllUser mocks base method.
+func (m *MockSystem) GetAllUser(ctx context.Context) ([]*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllUser", ctx)
+	ret0, _ := ret[0].([]*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// MockFCMMockRecorder is the mock recorder for MockFCM.
-type MockFCMMockRecorder struct {
-	mock *MockFCM
+// GetAllUser indicates an expected call of GetAllUser.
+func (mr *MockSystemMockRecorder) GetAllUser(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllUser", reflect.TypeOf((*MockSystem)(nil).GetAllUser), ctx)
 }
 
-// NewMockFCM creates a new mock instance.
-func NewMockFCM(ctrl *gomock.Controller) *MockFCM {
-	mock := &MockFCM{ctrl: ctrl}
-	mock.recorder = &MockFCMMockRecorder{mock}
-	return mock
+// GetJWTKeys mocks base method.
+func (m *MockSystem) GetJWTKeys(ctx context.Context) ([]*entity.JWTKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJWTKeys", ctx)
+	ret0, _ := ret[0].([]*entity.JWTKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockFCM) EXPECT() *MockFCMMockRecorder {
-	return m.recorder
+// GetJWTKeys indicates an expected call of GetJWTKeys.
+func (mr *MockSystemMockRecorder) GetJWTKeys(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJWTKeys", reflect.TypeOf((*MockSystem)(nil).GetJWTKeys), ctx)
 }
 
-// AnnounceMessage mocks base method.
-func (m *MockFCM) AnnounceMessage(msg string) error {
+// GetRole mocks base method.
+func (m *MockSystem) GetRole(name string) *entity.Role {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AnnounceMessage", msg)
-	ret0, _ := ret[0].(error)
+	ret := m.ctrl.Call(m, "GetRole", name)
+	ret0, _ := ret[0].(*entity.Role)
 	return ret0
 }
 
-// AnnounceMessage indicates an expected call of AnnounceMessage.
-func (mr *MockFCMMockRecorder) AnnounceMessage(msg any) *gomock.Call {
+// GetRole indicates an expected call of GetRole.
+func (mr *MockSystemMockRecorder) GetRole(name any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnnounceMessage", reflect.TypeOf((*MockFCM)(nil).AnnounceMessage), msg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRole", reflect.TypeOf((*MockSystem)(nil).GetRole), name)
 }
 
-// PushNotification mocks base method.
-func (m *MockFCM) PushNotification(title, msg string) error {
+// GetSetting mocks base method.
+func (m *MockSystem) GetSetting(ctx context.Context) (*entity.SystemSetting, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PushNotification", title, msg)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "GetSetting", ctx)
+	ret0, _ := ret[0].(*entity.SystemSetting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// PushNotification indicates an expected call of PushNotification.
-func (mr *MockFCMMockRecorder) PushNotification(title, msg any) *gomock.Call {
+// GetSetting indicates an expected call of GetSetting.
+func (mr *MockSystemMockRecorder) GetSetting(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PushNotification", reflect.TypeOf((*MockFCM)(nil).PushNotification), title, msg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSetting", reflect.TypeOf((*MockSystem)(nil).GetSetting), ctx)
+}
+
+// GetTOTPStatus mocks base method.
+func (m *MockSystem) GetTOTPStatus(ctx context.Context, username string) (*entity.TOTPStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTOTPStatus", ctx, username)
+	ret0, _ := ret[0].(*entity.TOTPStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTOTPStatus indicates an expected call of GetTOTPStatus.
+func (mr *MockSystemMockRecorder) GetTOTPStatus(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTOTPStatus", reflect.TypeOf((*MockSystem)(nil).GetTOTPStatus), ctx, username)
+}
+
+// GetUserInfo mocks base method.
+func (m *MockSystem) GetUserInfo(ctx context.Context, username string) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserInfo", ctx, username)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserInfo indicates an expected call of GetUserInfo.
+func (mr *MockSystemMockRecorder) GetUserInfo(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserInfo", reflect.TypeOf((*MockSystem)(nil).GetUserInfo), ctx, username)
+}
+
+// InsertPushToken mocks base method.
+func (m *MockSystem) InsertPushToken(ctx context.Context, token, username string, enabled, orderPush bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertPushToken", ctx, token, username, enabled, orderPush)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertPushToken indicates an expected call of InsertPushToken.
+func (mr *MockSystemMockRecorder) InsertPushToken(ctx, token, username, enabled, orderPush any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertPushToken", reflect.TypeOf((*MockSystem)(nil).InsertPushToken), ctx, token, username, enabled, orderPush)
+}
+
+// IsPushTokenEnabled mocks base method.
+func (m *MockSystem) IsPushTokenEnabled(ctx context.Context, token string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsPushTokenEnabled", ctx, token)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsPushTokenEnabled indicates an expected call of IsPushTokenEnabled.
+func (mr *MockSystemMockRecorder) IsPushTokenEnabled(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsPushTokenEnabled", reflect.TypeOf((*MockSystem)(nil).IsPushTokenEnabled), ctx, token)
+}
+
+// IsTokenRevoked mocks base method.
+func (m *MockSystem) IsTokenRevoked(ctx context.Context, username, sessionID string, loginAt time.Time) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsTokenRevoked", ctx, username, sessionID, loginAt)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsTokenRevoked indicates an expected call of IsTokenRevoked.
+func (mr *MockSystemMockRecorder) IsTokenRevoked(ctx, username, sessionID, loginAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsTokenRevoked", reflect.TypeOf((*MockSystem)(nil).IsTokenRevoked), ctx, username, sessionID, loginAt)
+}
+
+// Login mocks base method.
+func (m *MockSystem) Login(ctx context.Context, username, password, totpCode string) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Login", ctx, username, password, totpCode)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Login indicates an expected call of Login.
+func (mr *MockSystemMockRecorder) Login(ctx, username, password, totpCode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockSystem)(nil).Login), ctx, username, password, totpCode)
+}
+
+// RefreshSession mocks base method.
+func (m *MockSystem) RefreshSession(ctx context.Context, refreshToken, userAgent, ip string) (*entity.User, *entity.Session, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshSession", ctx, refreshToken, userAgent, ip)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(*entity.Session)
+	ret2, _ := ret[2].(string)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// RefreshSession indicates an expected call of RefreshSession.
+func (mr *MockSystemMockRecorder) RefreshSession(ctx, refreshToken, userAgent, ip any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshSession", reflect.TypeOf((*MockSystem)(nil).RefreshSession), ctx, refreshToken, userAgent, ip)
+}
+
+// ResendVerification mocks base method.
+func (m *MockSystem) ResendVerification(ctx context.Context, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResendVerification", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResendVerification indicates an expected call of ResendVerification.
+func (mr *MockSystemMockRecorder) ResendVerification(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResendVerification", reflect.TypeOf((*MockSystem)(nil).ResendVerification), ctx, email)
+}
+
+// ResetPassword mocks base method.
+func (m *MockSystem) ResetPassword(ctx context.Context, email, code, newPassword string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", ctx, email, code, newPassword)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockSystemMockRecorder) ResetPassword(ctx, email, code, newPassword any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockSystem)(nil).ResetPassword), ctx, email, code, newPassword)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockSystem) RevokeAPIKey(ctx context.Context, username, keyID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", ctx, username, keyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockSystemMockRecorder) RevokeAPIKey(ctx, username, keyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockSystem)(nil).RevokeAPIKey), ctx, username, keyID)
+}
+
+// RevokeSession mocks base method.
+func (m *MockSystem) RevokeSession(ctx context.Context, username, sessionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", ctx, username, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockSystemMockRecorder) RevokeSession(ctx, username, sessionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockSystem)(nil).RevokeSession), ctx, username, sessionID)
+}
+
+// RotateJWTKey mocks base method.
+func (m *MockSystem) RotateJWTKey(ctx context.Context, grace time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateJWTKey", ctx, grace)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RotateJWTKey indicates an expected call of RotateJWTKey.
+func (mr *MockSystemMockRecorder) RotateJWTKey(ctx, grace any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateJWTKey", reflect.TypeOf((*MockSystem)(nil).RotateJWTKey), ctx, grace)
+}
+
+// SendPasswordResetCode mocks base method.
+func (m *MockSystem) SendPasswordResetCode(ctx context.Context, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendPasswordResetCode", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendPasswordResetCode indicates an expected call of SendPasswordResetCode.
+func (mr *MockSystemMockRecorder) SendPasswordResetCode(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendPasswordResetCode", reflect.TypeOf((*MockSystem)(nil).SendPasswordResetCode), ctx, email)
+}
+
+// UpdateAuthTradeUser mocks base method.
+func (m *MockSystem) UpdateAuthTradeUser() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateAuthTradeUser")
+}
+
+// UpdateAuthTradeUser indicates an expected call of UpdateAuthTradeUser.
+func (mr *MockSystemMockRecorder) UpdateAuthTradeUser() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAuthTradeUser", reflect.TypeOf((*MockSystem)(nil).UpdateAuthTradeUser))
+}
+
+// UpdateSetting mocks base method.
+func (m *MockSystem) UpdateSetting(ctx context.Context, setting *entity.SystemSetting) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSetting", ctx, setting)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSetting indicates an expected call of UpdateSetting.
+func (mr *MockSystemMockRecorder) UpdateSetting(ctx, setting any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSetting", reflect.TypeOf((*MockSystem)(nil).UpdateSetting), ctx, setting)
+}
+
+// UpdateUserAuthTrade mocks base method.
+func (m *MockSystem) UpdateUserAuthTrade(ctx context.Context, username string, authTrade bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserAuthTrade", ctx, username, authTrade)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserAuthTrade indicates an expected call of UpdateUserAuthTrade.
+func (mr *MockSystemMockRecorder) UpdateUserAuthTrade(ctx, username, authTrade any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserAuthTrade", reflect.TypeOf((*MockSystem)(nil).UpdateUserAuthTrade), ctx, username, authTrade)
+}
+
+// UpdateUserRole mocks base method.
+func (m *MockSystem) UpdateUserRole(ctx context.Context, username, role string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserRole", ctx, username, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserRole indicates an expected call of UpdateUserRole.
+func (mr *MockSystemMockRecorder) UpdateUserRole(ctx, username, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserRole", reflect.TypeOf((*MockSystem)(nil).UpdateUserRole), ctx, username, role)
+}
+
+// VerifyEmail mocks base method.
+func (m *MockSystem) VerifyEmail(ctx context.Context, username, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", ctx, username, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockSystemMockRecorder) VerifyEmail(ctx, username, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockSystem)(nil).VerifyEmail), ctx, username, code)
+}
+
+// MockFCM is a mock of FCM interface.
+type MockFCM struct {
+	ctrl     *gomock.Controller
+	recorder *MockFCMMockRecorder
+	isgomock struct{}
+}
+
+// MockFCMMockRecorder is the mock recorder for MockFCM.
+type MockFCMMockRecorder struct {
+	mock *MockFCM
+}
+
+// NewMockFCM creates a new mock instance.
+func NewMockFCM(ctrl *gomock.Controller) *MockFCM {
+	mock := &MockFCM{ctrl: ctrl}
+	mock.recorder = &MockFCMMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFCM) EXPECT() *MockFCMMockRecorder {
+	return m.recorder
+}
+
+// AnnounceMessage mocks base method.
+func (m *MockFCM) AnnounceMessage(msg string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnnounceMessage", msg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AnnounceMessage indicates an expected call of AnnounceMessage.
+func (mr *MockFCMMockRecorder) AnnounceMessage(msg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnnounceMessage", reflect.TypeOf((*MockFCM)(nil).AnnounceMessage), msg)
+}
+
+// PushNotification mocks base method.
+func (m *MockFCM) PushNotification(title, msg string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PushNotification", title, msg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PushNotification indicates an expected call of PushNotification.
+func (mr *MockFCMMockRecorder) PushNotification(title, msg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PushNotification", reflect.TypeOf((*MockFCM)(nil).PushNotification), title, msg)
+}
+
+// MockNotify is a mock of Notify interface.
+type MockNotify struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotifyMockRecorder
+	isgomock struct{}
+}
+
+// MockNotifyMockRecorder is the mock recorder for MockNotify.
+type MockNotifyMockRecorder struct {
+	mock *MockNotify
+}
+
+// NewMockNotify creates a new mock instance.
+func NewMockNotify(ctrl *gomock.Controller) *MockNotify {
+	mock := &MockNotify{ctrl: ctrl}
+	mock.recorder = &MockNotifyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotify) EXPECT() *MockNotifyMockRecorder {
+	return m.recorder
+}
+
+// GetNotifyPreference mocks base method.
+func (m *MockNotify) GetNotifyPreference(ctx context.Context, username string) (*entity.NotifyPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotifyPreference", ctx, username)
+	ret0, _ := ret[0].(*entity.NotifyPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotifyPreference indicates an expected call of GetNotifyPreference.
+func (mr *MockNotifyMockRecorder) GetNotifyPreference(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotifyPreference", reflect.TypeOf((*MockNotify)(nil).GetNotifyPreference), ctx, username)
+}
+
+// UpdateNotifyPreference mocks base method.
+func (m *MockNotify) UpdateNotifyPreference(ctx context.Context, username string, t *entity.NotifyPreference) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNotifyPreference", ctx, username, t)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateNotifyPreference indicates an expected call of UpdateNotifyPreference.
+func (mr *MockNotifyMockRecorder) UpdateNotifyPreference(ctx, username, t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNotifyPreference", reflect.TypeOf((*MockNotify)(nil).UpdateNotifyPreference), ctx, username, t)
+}
+
+// MockAlert is a mock of Alert interface.
+type MockAlert struct {
+	ctrl     *gomock.Controller
+	recorder *MockAlertMockRecorder
+	isgomock struct{}
+}
+
+// MockAlertMockRecorder is the mock recorder for MockAlert.
+type MockAlertMockRecorder struct {
+	mock *MockAlert
+}
+
+// NewMockAlert creates a new mock instance.
+func NewMockAlert(ctrl *gomock.Controller) *MockAlert {
+	mock := &MockAlert{ctrl: ctrl}
+	mock.recorder = &MockAlertMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAlert) EXPECT() *MockAlertMockRecorder {
+	return m.recorder
+}
+
+// CreateAlertRule mocks base method.
+func (m *MockAlert) CreateAlertRule(ctx context.Context, username string, t *entity.NewAlertRule) (*entity.AlertRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAlertRule", ctx, username, t)
+	ret0, _ := ret[0].(*entity.AlertRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAlertRule indicates an expected call of CreateAlertRule.
+func (mr *MockAlertMockRecorder) CreateAlertRule(ctx, username, t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAlertRule", reflect.TypeOf((*MockAlert)(nil).CreateAlertRule), ctx, username, t)
+}
+
+// DeleteAlertRule mocks base method.
+func (m *MockAlert) DeleteAlertRule(ctx context.Context, username string, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAlertRule", ctx, username, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAlertRule indicates an expected call of DeleteAlertRule.
+func (mr *MockAlertMockRecorder) DeleteAlertRule(ctx, username, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAlertRule", reflect.TypeOf((*MockAlert)(nil).DeleteAlertRule), ctx, username, id)
+}
+
+// GetAlertHistory mocks base method.
+func (m *MockAlert) GetAlertHistory(ctx context.Context, username string, limit, offset uint64) ([]*entity.AlertHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAlertHistory", ctx, username, limit, offset)
+	ret0, _ := ret[0].([]*entity.AlertHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAlertHistory indicates an expected call of GetAlertHistory.
+func (mr *MockAlertMockRecorder) GetAlertHistory(ctx, username, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAlertHistory", reflect.TypeOf((*MockAlert)(nil).GetAlertHistory), ctx, username, limit, offset)
+}
+
+// GetAlertRules mocks base method.
+func (m *MockAlert) GetAlertRules(ctx context.Context, username string) ([]*entity.AlertRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAlertRules", ctx, username)
+	ret0, _ := ret[0].([]*entity.AlertRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAlertRules indicates an expected call of GetAlertRules.
+func (mr *MockAlertMockRecorder) GetAlertRules(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAlertRules", reflect.TypeOf((*MockAlert)(nil).GetAlertRules), ctx, username)
+}
+
+// UpdateAlertRule mocks base method.
+func (m *MockAlert) UpdateAlertRule(ctx context.Context, username string, id int, t *entity.NewAlertRule) (*entity.AlertRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAlertRule", ctx, username, id, t)
+	ret0, _ := ret[0].(*entity.AlertRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAlertRule indicates an expected call of UpdateAlertRule.
+func (mr *MockAlertMockRecorder) UpdateAlertRule(ctx, username, id, t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAlertRule", reflect.TypeOf((*MockAlert)(nil).UpdateAlertRule), ctx, username, id, t)
+}
+
+// MockReport is a mock of Report interface.
+type MockReport struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportMockRecorder
+	isgomock struct{}
+}
+
+// MockReportMockRecorder is the mock recorder for MockReport.
+type MockReportMockRecorder struct {
+	mock *MockReport
+}
+
+// NewMockReport creates a new mock instance.
+func NewMockReport(ctrl *gomock.Controller) *MockReport {
+	mock := &MockReport{ctrl: ctrl}
+	mock.recorder = &MockReportMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReport) EXPECT() *MockReportMockRecorder {
+	return m.recorder
+}
+
+// GetDailyReport mocks base method.
+func (m *MockReport) GetDailyReport(ctx context.Context, date string) (*entity.DailyReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDailyReport", ctx, date)
+	ret0, _ := ret[0].(*entity.DailyReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDailyReport indicates an expected call of GetDailyReport.
+func (mr *MockReportMockRecorder) GetDailyReport(ctx, date any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDailyReport", reflect.TypeOf((*MockReport)(nil).GetDailyReport), ctx, date)
+}
+
+// MockWatchlist is a mock of Watchlist interface.
+type MockWatchlist struct {
+	ctrl     *gomock.Controller
+	recorder *MockWatchlistMockRecorder
+	isgomock struct{}
+}
+
+// MockWatchlistMockRecorder is the mock recorder for MockWatchlist.
+type MockWatchlistMockRecorder struct {
+	mock *MockWatchlist
+}
+
+// NewMockWatchlist creates a new mock instance.
+func NewMockWatchlist(ctrl *gomock.Controller) *MockWatchlist {
+	mock := &MockWatchlist{ctrl: ctrl}
+	mock.recorder = &MockWatchlistMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWatchlist) EXPECT() *MockWatchlistMockRecorder {
+	return m.recorder
+}
+
+// CreateWatchlist mocks base method.
+func (m *MockWatchlist) CreateWatchlist(ctx context.Context, username string, t *entity.NewWatchlist) (*entity.Watchlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWatchlist", ctx, username, t)
+	ret0, _ := ret[0].(*entity.Watchlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWatchlist indicates an expected call of CreateWatchlist.
+func (mr *MockWatchlistMockRecorder) CreateWatchlist(ctx, username, t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWatchlist", reflect.TypeOf((*MockWatchlist)(nil).CreateWatchlist), ctx, username, t)
+}
+
+// DeleteWatchlist mocks base method.
+func (m *MockWatchlist) DeleteWatchlist(ctx context.Context, username string, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWatchlist", ctx, username, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWatchlist indicates an expected call of DeleteWatchlist.
+func (mr *MockWatchlistMockRecorder) DeleteWatchlist(ctx, username, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWatchlist", reflect.TypeOf((*MockWatchlist)(nil).DeleteWatchlist), ctx, username, id)
+}
+
+// DeleteWatchlistItem mocks base method.
+func (m *MockWatchlist) DeleteWatchlistItem(ctx context.Context, username string, id int, code string) (*entity.Watchlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWatchlistItem", ctx, username, id, code)
+	ret0, _ := ret[0].(*entity.Watchlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteWatchlistItem indicates an expected call of DeleteWatchlistItem.
+func (mr *MockWatchlistMockRecorder) DeleteWatchlistItem(ctx, username, id, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWatchlistItem", reflect.TypeOf((*MockWatchlist)(nil).DeleteWatchlistItem), ctx, username, id, code)
+}
+
+// GetDefaultWatchlistCodes mocks base method.
+func (m *MockWatchlist) GetDefaultWatchlistCodes(ctx context.Context, username string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDefaultWatchlistCodes", ctx, username)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDefaultWatchlistCodes indicates an expected call of GetDefaultWatchlistCodes.
+func (mr *MockWatchlistMockRecorder) GetDefaultWatchlistCodes(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDefaultWatchlistCodes", reflect.TypeOf((*MockWatchlist)(nil).GetDefaultWatchlistCodes), ctx, username)
+}
+
+// GetWatchlists mocks base method.
+func (m *MockWatchlist) GetWatchlists(ctx context.Context, username string) ([]*entity.Watchlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWatchlists", ctx, username)
+	ret0, _ := ret[0].([]*entity.Watchlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWatchlists indicates an expected call of GetWatchlists.
+func (mr *MockWatchlistMockRecorder) GetWatchlists(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWatchlists", reflect.TypeOf((*MockWatchlist)(nil).GetWatchlists), ctx, username)
+}
+
+// PutWatchlistItem mocks base method.
+func (m *MockWatchlist) PutWatchlistItem(ctx context.Context, username string, id int, t *entity.NewWatchlistItem) (*entity.Watchlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutWatchlistItem", ctx, username, id, t)
+	ret0, _ := ret[0].(*entity.Watchlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutWatchlistItem indicates an expected call of PutWatchlistItem.
+func (mr *MockWatchlistMockRecorder) PutWatchlistItem(ctx, username, id, t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutWatchlistItem", reflect.TypeOf((*MockWatchlist)(nil).PutWatchlistItem), ctx, username, id, t)
+}
+
+// SortWatchlistItem mocks base method.
+func (m *MockWatchlist) SortWatchlistItem(ctx context.Context, username string, id int, codes []string) (*entity.Watchlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SortWatchlistItem", ctx, username, id, codes)
+	ret0, _ := ret[0].(*entity.Watchlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SortWatchlistItem indicates an expected call of SortWatchlistItem.
+func (mr *MockWatchlistMockRecorder) SortWatchlistItem(ctx, username, id, codes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SortWatchlistItem", reflect.TypeOf((*MockWatchlist)(nil).SortWatchlistItem), ctx, username, id, codes)
+}
+
+// SyncDefaultWatchlist mocks base method.
+func (m *MockWatchlist) SyncDefaultWatchlist(ctx context.Context, username string, add, remove []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncDefaultWatchlist", ctx, username, add, remove)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SyncDefaultWatchlist indicates an expected call of SyncDefaultWatchlist.
+func (mr *MockWatchlistMockRecorder) SyncDefaultWatchlist(ctx, username, add, remove any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncDefaultWatchlist", reflect.TypeOf((*MockWatchlist)(nil).SyncDefaultWatchlist), ctx, username, add, remove)
+}
+
+// UpdateWatchlist mocks base method.
+func (m *MockWatchlist) UpdateWatchlist(ctx context.Context, username string, id int, t *entity.NewWatchlist) (*entity.Watchlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWatchlist", ctx, username, id, t)
+	ret0, _ := ret[0].(*entity.Watchlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWatchlist indicates an expected call of UpdateWatchlist.
+func (mr *MockWatchlistMockRecorder) UpdateWatchlist(ctx, username, id, t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWatchlist", reflect.TypeOf((*MockWatchlist)(nil).UpdateWatchlist), ctx, username, id, t)
 }
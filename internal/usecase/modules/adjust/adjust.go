@@ -0,0 +1,51 @@
+// Package adjust package adjust
+package adjust
+
+import (
+	"sort"
+
+	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
+)
+
+// Factors returns the accumulated factor of each ascending close, the product of factors of actions after its date.
+// The close before an ex date is the one used by the factor of the action, actions before the first close have no effect.
+func Factors(arr []*entity.HistoryCloseBase, actions []*entity.CorporateAction) []float64 {
+	sorted := make([]*entity.CorporateAction, len(actions))
+	copy(sorted, actions)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].ExDate.After(sorted[j].ExDate)
+	})
+
+	result := make([]float64, len(arr))
+	if len(arr) == 0 {
+		return result
+	}
+
+	// ex dates after the last close have no effect yet
+	next := 0
+	for next < len(sorted) && sorted[next].ExDate.After(arr[len(arr)-1].Date) {
+		next++
+	}
+
+	factor := 1.0
+	result[len(arr)-1] = factor
+	for i := len(arr) - 2; i >= 0; i-- {
+		for ; next < len(sorted) && sorted[next].ExDate.After(arr[i].Date); next++ {
+			factor *= sorted[next].Factor(arr[i].Close)
+		}
+		result[i] = factor
+	}
+	return result
+}
+
+// Closes returns adjusted copies of ascending closes, closes from cache must not be changed in place.
+func Closes(arr []*entity.HistoryCloseBase, actions []*entity.CorporateAction) []*entity.HistoryCloseBase {
+	factors := Factors(arr, actions)
+	result := make([]*entity.HistoryCloseBase, len(arr))
+	for i, v := range arr {
+		c := *v
+		c.Close *= factors[i]
+		result[i] = &c
+	}
+	return result
+}
@@ -0,0 +1,71 @@
+package adjust
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
+)
+
+var csvHeader = []string{"stock_num", "ex_date", "type", "cash", "ratio"}
+
+// ParseCSV reads actions with the header stock_num,ex_date,type,cash,ratio, an empty cash is 0 and an empty ratio is 1.
+func ParseCSV(r io.Reader) ([]*entity.CorporateAction, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = len(csvHeader)
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 || strings.Join(records[0], ",") != strings.Join(csvHeader, ",") {
+		return nil, fmt.Errorf("header must be %s", strings.Join(csvHeader, ","))
+	}
+
+	var result []*entity.CorporateAction
+	for i, record := range records[1:] {
+		action, err := parseRecord(record)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+2, err)
+		}
+		result = append(result, action)
+	}
+	return result, nil
+}
+
+func parseRecord(record []string) (*entity.CorporateAction, error) {
+	exDate, err := time.ParseInLocation(entity.ShortTimeLayout, record[1], time.Local)
+	if err != nil {
+		return nil, err
+	}
+
+	action := &entity.CorporateAction{
+		StockNum: record[0],
+		ExDate:   exDate,
+		Type:     entity.CorporateActionType(record[2]),
+		Ratio:    1,
+	}
+	if action.StockNum == "" || !action.Type.Valid() {
+		return nil, errors.New("stock num empty or type invalid")
+	}
+	if record[3] != "" {
+		if action.Cash, err = strconv.ParseFloat(record[3], 64); err != nil {
+			return nil, err
+		}
+	}
+	if record[4] != "" {
+		if action.Ratio, err = strconv.ParseFloat(record[4], 64); err != nil {
+			return nil, err
+		}
+	}
+	if action.Cash < 0 || action.Ratio <= 0 {
+		return nil, errors.New("cash must not be negative and ratio must be positive")
+	}
+	return action, nil
+}
@@ -0,0 +1,118 @@
+// Package blackscholes package blackscholes
+//
+// Prices are from the Black model, which is Black-Scholes with the future price as underlying,
+// so no dividend or carry is needed for index options priced against the index future.
+package blackscholes
+
+import (
+	"math"
+)
+
+const (
+	minVolatility = 0.0001
+	maxVolatility = 5.0
+	ivTolerance   = 0.00001
+	ivMaxLoop     = 100
+
+	daysInYear = 365
+)
+
+// Greeks -. Theta is per calendar day, vega and rho are per 1% change.
+type Greeks struct {
+	Delta float64 `json:"delta"`
+	Gamma float64 `json:"gamma"`
+	Theta float64 `json:"theta"`
+	Vega  float64 `json:"vega"`
+	Rho   float64 `json:"rho"`
+}
+
+// Input -. Years is the time to expiry, rate is the annual risk-free rate.
+type Input struct {
+	Call   bool
+	Future float64
+	Strike float64
+	Years  float64
+	Rate   float64
+}
+
+func (in Input) valid() bool {
+	return in.Future > 0 && in.Strike > 0 && in.Years > 0
+}
+
+func normCDF(x float64) float64 {
+	return 0.5 * math.Erfc(-x/math.Sqrt2)
+}
+
+func normPDF(x float64) float64 {
+	return math.Exp(-x*x/2) / math.Sqrt(2*math.Pi)
+}
+
+func (in Input) d1d2(sigma float64) (float64, float64) {
+	sqrtT := math.Sqrt(in.Years)
+	d1 := (math.Log(in.Future/in.Strike) + sigma*sigma*in.Years/2) / (sigma * sqrtT)
+	return d1, d1 - sigma*sqrtT
+}
+
+// Price returns the theoretical option price, or 0 if input is invalid.
+func Price(in Input, sigma float64) float64 {
+	if !in.valid() || sigma <= 0 {
+		return 0
+	}
+	d1, d2 := in.d1d2(sigma)
+	discount := math.Exp(-in.Rate * in.Years)
+	if in.Call {
+		return discount * (in.Future*normCDF(d1) - in.Strike*normCDF(d2))
+	}
+	return discount * (in.Strike*normCDF(-d2) - in.Future*normCDF(-d1))
+}
+
+// ImpliedVolatility solves the volatility by bisection, false if price is outside the no-arbitrage bounds.
+func ImpliedVolatility(in Input, price float64) (float64, bool) {
+	if !in.valid() || price <= 0 {
+		return 0, false
+	}
+
+	low, high := minVolatility, maxVolatility
+	if price < Price(in, low) || price > Price(in, high) {
+		return 0, false
+	}
+	for i := 0; i < ivMaxLoop; i++ {
+		mid := (low + high) / 2
+		diff := Price(in, mid) - price
+		if math.Abs(diff) < ivTolerance {
+			return mid, true
+		}
+		if diff > 0 {
+			high = mid
+		} else {
+			low = mid
+		}
+	}
+	return (low + high) / 2, true
+}
+
+// CalculateGreeks returns greeks at the volatility, or nil if input is invalid.
+func CalculateGreeks(in Input, sigma float64) *Greeks {
+	if !in.valid() || sigma <= 0 {
+		return nil
+	}
+
+	d1, _ := in.d1d2(sigma)
+	sqrtT := math.Sqrt(in.Years)
+	discount := math.Exp(-in.Rate * in.Years)
+	price := Price(in, sigma)
+
+	// theta has the same form for call and put in the black model
+	result := &Greeks{
+		Gamma: discount * normPDF(d1) / (in.Future * sigma * sqrtT),
+		Theta: (-discount*in.Future*normPDF(d1)*sigma/(2*sqrtT) + in.Rate*price) / daysInYear,
+		Vega:  discount * in.Future * normPDF(d1) * sqrtT / 100,
+		Rho:   -in.Years * price / 100,
+	}
+	if in.Call {
+		result.Delta = discount * normCDF(d1)
+	} else {
+		result.Delta = -discount * normCDF(-d1)
+	}
+	return result
+}
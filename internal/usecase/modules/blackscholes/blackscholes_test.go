@@ -0,0 +1,88 @@
+package blackscholes
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPrice(t *testing.T) {
+	tests := []struct {
+		name  string
+		in    Input
+		sigma float64
+		want  float64
+	}{
+		// Hull, Options Futures and Other Derivatives, black model put example
+		{"hull put", Input{Call: false, Future: 20, Strike: 20, Years: 4.0 / 12, Rate: 0.09}, 0.25, 1.1166},
+		// Haug, The Complete Guide to Option Pricing Formulas, black-76 example
+		{"haug call", Input{Call: true, Future: 19, Strike: 19, Years: 0.75, Rate: 0.10}, 0.28, 1.7011},
+		{"haug put", Input{Call: false, Future: 19, Strike: 19, Years: 0.75, Rate: 0.10}, 0.28, 1.7011},
+		{"itm call", Input{Call: true, Future: 17000, Strike: 16500, Years: 30.0 / 365, Rate: 0.015}, 0.2, 683.0184},
+		{"otm put", Input{Call: false, Future: 17000, Strike: 16500, Years: 30.0 / 365, Rate: 0.015}, 0.2, 183.6344},
+		{"otm call", Input{Call: true, Future: 17000, Strike: 17500, Years: 10.0 / 365, Rate: 0.015}, 0.15, 25.6582},
+		{"itm put", Input{Call: false, Future: 17000, Strike: 17500, Years: 10.0 / 365, Rate: 0.015}, 0.15, 525.4527},
+		{"expired", Input{Call: true, Future: 17000, Strike: 16500, Years: 0, Rate: 0.015}, 0.2, 0},
+		{"zero sigma", Input{Call: true, Future: 17000, Strike: 16500, Years: 0.1, Rate: 0.015}, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Price(tt.in, tt.sigma); math.Abs(got-tt.want) > 0.0001 {
+				t.Errorf("Price() = %.4f, want %.4f", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestImpliedVolatilityRoundTrip(t *testing.T) {
+	for _, call := range []bool{true, false} {
+		for _, strike := range []float64{15000, 16500, 17000, 17500, 19000} {
+			for _, sigma := range []float64{0.08, 0.2, 0.45, 1.2} {
+				in := Input{Call: call, Future: 17000, Strike: strike, Years: 45.0 / 365, Rate: 0.015}
+				price := Price(in, sigma)
+				if price < 0.01 {
+					// too far out of the money to solve back
+					continue
+				}
+				iv, ok := ImpliedVolatility(in, price)
+				if !ok {
+					t.Fatalf("ImpliedVolatility(%+v, %f) not solved", in, price)
+				}
+				if got := Price(in, iv); math.Abs(got-price) > ivTolerance {
+					t.Errorf("call %v strike %.0f sigma %.2f: price of iv %.6f = %.6f, want %.6f", call, strike, sigma, iv, got, price)
+				}
+			}
+		}
+	}
+}
+
+func TestImpliedVolatilityOutOfBounds(t *testing.T) {
+	in := Input{Call: true, Future: 17000, Strike: 16500, Years: 30.0 / 365, Rate: 0.015}
+	// below the discounted intrinsic value
+	if _, ok := ImpliedVolatility(in, 100); ok {
+		t.Error("price below intrinsic value should not be solved")
+	}
+	if _, ok := ImpliedVolatility(in, 0); ok {
+		t.Error("zero price should not be solved")
+	}
+}
+
+func TestPutCallParity(t *testing.T) {
+	for _, strike := range []float64{15000, 17000, 19000} {
+		for _, years := range []float64{7.0 / 365, 0.25, 1} {
+			call := Input{Call: true, Future: 17000, Strike: strike, Years: years, Rate: 0.015}
+			put := call
+			put.Call = false
+
+			// black model: c - p = e^(-rT) * (F - K)
+			want := math.Exp(-call.Rate*call.Years) * (call.Future - call.Strike)
+			if got := Price(call, 0.2) - Price(put, 0.2); math.Abs(got-want) > 1e-6 {
+				t.Errorf("strike %.0f years %.3f: c - p = %.6f, want %.6f", strike, years, got, want)
+			}
+
+			callGreeks, putGreeks := CalculateGreeks(call, 0.2), CalculateGreeks(put, 0.2)
+			if got, want := callGreeks.Delta-putGreeks.Delta, math.Exp(-call.Rate*call.Years); math.Abs(got-want) > 1e-9 {
+				t.Errorf("strike %.0f years %.3f: call delta - put delta = %.9f, want %.9f", strike, years, got, want)
+			}
+		}
+	}
+}
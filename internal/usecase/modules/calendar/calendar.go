@@ -288,6 +288,39 @@ func (t *Calendar) GetLastNStockTradeDay(n int64) []time.Time {
 	return arr
 }
 
+// ShiftTradeDay returns the nth trade day after date, or before it if n is negative, date itself if n is 0.
+// Zero time is returned when it is out of the calendar.
+func (t *Calendar) ShiftTradeDay(date time.Time, n int) time.Time {
+	d := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.Local)
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+	for n > 0 {
+		d = d.AddDate(0, 0, step)
+		if d.Year() < startTradeYear || d.Year() > endTradeYear {
+			return time.Time{}
+		}
+		if t.isTradeDay(d) {
+			n--
+		}
+	}
+	return d
+}
+
+// CountTradeDay returns the number of trade days after start until end, end included.
+func (t *Calendar) CountTradeDay(start, end time.Time) int {
+	var count int
+	d := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.Local).AddDate(0, 0, 1)
+	for !d.After(end) {
+		if t.isTradeDay(d) {
+			count++
+		}
+		d = d.AddDate(0, 0, 1)
+	}
+	return count
+}
+
 // TradePeriod -.
 type TradePeriod struct {
 	StartTime time.Time
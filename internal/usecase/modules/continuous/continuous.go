@@ -0,0 +1,146 @@
+// Package continuous package continuous
+package continuous
+
+import (
+	"sort"
+	"time"
+
+	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
+)
+
+// Schedule is the contracts of one category in delivery order with the last trade day each is the front month.
+type Schedule struct {
+	contracts  []*entity.Future
+	lastActive []time.Time
+}
+
+// NewSchedule -. lastActiveDay returns the last trade day a contract of the delivery date is the front month.
+func NewSchedule(contracts []*entity.Future, lastActiveDay func(delivery time.Time) time.Time) *Schedule {
+	arr := make([]*entity.Future, len(contracts))
+	copy(arr, contracts)
+	sort.SliceStable(arr, func(i, j int) bool {
+		return arr[i].DeliveryDate.Before(arr[j].DeliveryDate)
+	})
+
+	s := &Schedule{contracts: arr}
+	for _, v := range arr {
+		s.lastActive = append(s.lastActive, lastActiveDay(v.DeliveryDate))
+	}
+	return s
+}
+
+// Len -.
+func (s *Schedule) Len() int {
+	return len(s.contracts)
+}
+
+func (s *Schedule) index(nth int, tradeDay time.Time) int {
+	for i, last := range s.lastActive {
+		if !tradeDay.After(last) {
+			if i+nth-1 < len(s.contracts) {
+				return i + nth - 1
+			}
+			return -1
+		}
+	}
+	return -1
+}
+
+// Contract returns the nth contract on the trade day, nil if it is not listed.
+func (s *Schedule) Contract(nth int, tradeDay time.Time) *entity.Future {
+	if i := s.index(nth, tradeDay); i >= 0 {
+		return s.contracts[i]
+	}
+	return nil
+}
+
+// Next returns the contract delivered after the code, nil if it is the last one.
+func (s *Schedule) Next(code string) *entity.Future {
+	for i, v := range s.contracts {
+		if v.Code == code && i+1 < len(s.contracts) {
+			return s.contracts[i+1]
+		}
+	}
+	return nil
+}
+
+// LastActiveDay returns the last trade day the code is the front month, zero time if not in the schedule.
+func (s *Schedule) LastActiveDay(code string) time.Time {
+	for i, v := range s.contracts {
+		if v.Code == code {
+			return s.lastActive[i]
+		}
+	}
+	return time.Time{}
+}
+
+// Segments groups ascending trade days by the nth contract, days without a contract are skipped.
+func (s *Schedule) Segments(nth int, tradeDays []time.Time) []*entity.ContinuousSegment {
+	var result []*entity.ContinuousSegment
+	var current *entity.ContinuousSegment
+	for _, d := range tradeDays {
+		contract := s.Contract(nth, d)
+		if contract == nil {
+			continue
+		}
+		if current == nil || current.Code != contract.Code {
+			current = &entity.ContinuousSegment{
+				Code:         contract.Code,
+				DeliveryDate: contract.DeliveryDate,
+				From:         d,
+			}
+			result = append(result, current)
+		}
+		current.To = d
+	}
+	return result
+}
+
+// Roll is the close of the old and new contract on the last day of a segment.
+type Roll struct {
+	From float64
+	To   float64
+}
+
+// SetAdjustments back-adjusts segments from the last one, rolls[i] is between segments[i] and segments[i+1].
+// Difference adds the accumulated gaps, ratio multiplies the accumulated ratios, a roll without both closes is no gap.
+func SetAdjustments(segments []*entity.ContinuousSegment, rolls []Roll, method entity.ContinuousAdjustment) {
+	if len(segments) == 0 {
+		return
+	}
+
+	adjustment := 0.0
+	if method == entity.ContinuousAdjustmentRatio {
+		adjustment = 1
+	}
+	segments[len(segments)-1].Adjustment = adjustment
+	for i := len(segments) - 2; i >= 0; i-- {
+		if i < len(rolls) && rolls[i].From != 0 && rolls[i].To != 0 {
+			switch method {
+			case entity.ContinuousAdjustmentDifference:
+				adjustment += rolls[i].To - rolls[i].From
+			case entity.ContinuousAdjustmentRatio:
+				adjustment *= rolls[i].To / rolls[i].From
+			}
+		}
+		segments[i].Adjustment = adjustment
+	}
+}
+
+// AdjustKbar returns an adjusted copy, kbars from cache must not be changed in place.
+func AdjustKbar(v *entity.HistoryKbarBase, adjustment float64, method entity.ContinuousAdjustment) *entity.HistoryKbarBase {
+	result := *v
+	switch method {
+	case entity.ContinuousAdjustmentDifference:
+		result.Open += adjustment
+		result.High += adjustment
+		result.Low += adjustment
+		result.Close += adjustment
+	case entity.ContinuousAdjustmentRatio:
+		result.Open *= adjustment
+		result.High *= adjustment
+		result.Low *= adjustment
+		result.Close *= adjustment
+	}
+	return &result
+}
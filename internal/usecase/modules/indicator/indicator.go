@@ -0,0 +1,76 @@
+// Package indicator package indicator
+package indicator
+
+import (
+	"time"
+
+	"github.com/markcheno/go-talib"
+	"github.com/toc-taiwan/toc-machine-trading/internal/config"
+	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
+)
+
+// Calculator computes the enabled indicators from ascending kbars.
+type Calculator struct {
+	cfg         config.Indicator
+	enabled     map[entity.IndicatorName]struct{}
+	rsiMinCount int
+}
+
+// NewCalculator -. rsiMinCount is the minimum minute bars before intraday rsi is calculated.
+func NewCalculator(cfg config.Indicator, rsiMinCount int) *Calculator {
+	enabled := make(map[entity.IndicatorName]struct{})
+	for _, v := range cfg.Enabled {
+		enabled[entity.IndicatorName(v)] = struct{}{}
+	}
+	return &Calculator{
+		cfg:         cfg,
+		enabled:     enabled,
+		rsiMinCount: rsiMinCount,
+	}
+}
+
+func (c *Calculator) isEnabled(name entity.IndicatorName) bool {
+	_, ok := c.enabled[name]
+	return ok
+}
+
+// Calculate fills indicators into result, kbars must be sorted by time.
+func (c *Calculator) Calculate(result *entity.Indicators, kbars []*entity.StockHistoryKbar) {
+	count := len(kbars)
+	result.Time = make([]time.Time, count)
+	high := make([]float64, count)
+	low := make([]float64, count)
+	closeArr := make([]float64, count)
+	for i, v := range kbars {
+		result.Time[i] = v.KbarTime
+		high[i] = v.High
+		low[i] = v.Low
+		closeArr[i] = v.Close
+	}
+
+	if c.isEnabled(entity.IndicatorRSI) && count > c.cfg.RSIPeriod && c.cfg.RSIPeriod > 1 &&
+		(result.Interval != entity.IndicatorIntervalMinute || count >= c.rsiMinCount) {
+		result.RSI = talib.Rsi(closeArr, c.cfg.RSIPeriod)
+	}
+
+	if c.isEnabled(entity.IndicatorMACD) && count > c.cfg.MACDSlow+c.cfg.MACDSignal &&
+		c.cfg.MACDFast > 1 && c.cfg.MACDSlow > c.cfg.MACDFast && c.cfg.MACDSignal > 0 {
+		macd, signal, hist := talib.Macd(closeArr, c.cfg.MACDFast, c.cfg.MACDSlow, c.cfg.MACDSignal)
+		result.MACD = &entity.MACDSeries{MACD: macd, Signal: signal, Hist: hist}
+	}
+
+	if c.isEnabled(entity.IndicatorBollinger) && count >= c.cfg.BollingerPeriod && c.cfg.BollingerPeriod > 1 {
+		upper, middle, lower := talib.BBands(closeArr, c.cfg.BollingerPeriod, c.cfg.BollingerDev, c.cfg.BollingerDev, talib.SMA)
+		result.Bollinger = &entity.BollingerSeries{Upper: upper, Middle: middle, Lower: lower}
+	}
+
+	if c.isEnabled(entity.IndicatorATR) && count > c.cfg.ATRPeriod && c.cfg.ATRPeriod > 0 {
+		result.ATR = talib.Atr(high, low, closeArr, c.cfg.ATRPeriod)
+	}
+
+	if c.isEnabled(entity.IndicatorKD) && count > c.cfg.KDPeriod+c.cfg.KDSlowK+c.cfg.KDSlowD &&
+		c.cfg.KDPeriod > 0 && c.cfg.KDSlowK > 0 && c.cfg.KDSlowD > 0 {
+		k, d := talib.Stoch(high, low, closeArr, c.cfg.KDPeriod, c.cfg.KDSlowK, talib.SMA, c.cfg.KDSlowD, talib.SMA)
+		result.KD = &entity.KDSeries{K: k, D: d}
+	}
+}
@@ -0,0 +1,104 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+const slackWebhookHost = "hooks.slack.com"
+
+var errAddressNotAllowed = errors.New("notify address is not allowed")
+
+// blockedNets are not covered by net.IP helpers, e.g. 100.100.100.200 is the metadata service of some clouds.
+var blockedNets = func() []*net.IPNet {
+	var result []*net.IPNet
+	for _, v := range []string{"0.0.0.0/8", "100.64.0.0/10", "192.0.0.0/24", "198.18.0.0/15", "240.0.0.0/4"} {
+		_, n, err := net.ParseCIDR(v)
+		if err != nil {
+			panic(err)
+		}
+		result = append(result, n)
+	}
+	return result
+}()
+
+// CheckWebhookURL returns error if the url is not http(s) or its host resolves to a non public address.
+func CheckWebhookURL(ctx context.Context, raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return errAddressNotAllowed
+	}
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, u.Hostname())
+	if err != nil {
+		return err
+	}
+	for _, v := range addrs {
+		if !isPublicIP(v.IP) {
+			return errAddressNotAllowed
+		}
+	}
+	return nil
+}
+
+// CheckSlackWebhook only accepts slack incoming webhooks.
+func CheckSlackWebhook(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "https" || u.Host != slackWebhookHost {
+		return errAddressNotAllowed
+	}
+	return nil
+}
+
+func isPublicIP(ip net.IP) bool {
+	if ip.IsUnspecified() || ip.IsLoopback() || ip.IsPrivate() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return false
+	}
+	for _, n := range blockedNets {
+		if n.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// newPublicClient checks the resolved address right before connecting,
+// so a host resolving to a different address after the url was saved is still rejected.
+func newPublicClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !isPublicIP(ip) {
+				return errAddressNotAllowed
+			}
+			return nil
+		},
+	}
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			// no proxy, the proxy would dial the address instead of the checked dialer
+			Proxy:               nil,
+			DialContext:         dialer.DialContext,
+			ForceAttemptHTTP2:   true,
+			TLSHandshakeTimeout: timeout,
+		},
+	}
+}
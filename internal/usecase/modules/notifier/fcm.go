@@ -0,0 +1,153 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+
+	firebase "firebase.google.com/go/v4"
+	"firebase.google.com/go/v4/messaging"
+	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
+	"google.golang.org/api/option"
+)
+
+// fcmMulticastLimit is the max tokens of one SendEachForMulticast call.
+const fcmMulticastLimit = 500
+
+// FCM sends to push tokens through firebase cloud messaging.
+type FCM struct {
+	app *firebase.App
+
+	// onInvalidTokens is called with tokens reported as unregistered or invalid
+	onInvalidTokens func(ctx context.Context, tokens []string)
+}
+
+type srvAccount struct {
+	ProjectID string `json:"project_id"`
+}
+
+// NewFCM returns error if the service account file is missing or invalid.
+func NewFCM(serviceAccountFilePath string) (*FCM, error) {
+	data, err := os.ReadFile(serviceAccountFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	content := srvAccount{}
+	if err = json.Unmarshal(data, &content); err != nil {
+		return nil, err
+	}
+
+	config := &firebase.Config{ProjectID: content.ProjectID}
+	fb, err := firebase.NewApp(context.Background(), config, option.WithCredentialsFile(serviceAccountFilePath))
+	if err != nil {
+		return nil, err
+	}
+	return &FCM{app: fb}, nil
+}
+
+// OnInvalidTokens -.
+func (f *FCM) OnInvalidTokens(fn func(ctx context.Context, tokens []string)) {
+	f.onInvalidTokens = fn
+}
+
+func (f *FCM) Channel() entity.NotifyChannel {
+	return entity.NotifyChannelFCM
+}
+
+func (f *FCM) Notify(ctx context.Context, to *Recipient, msg *Message) error {
+	return f.Multicast(ctx, to.PushTokens, msg)
+}
+
+// Multicast sends to the tokens in batches, message without title is sent as data only.
+func (f *FCM) Multicast(ctx context.Context, tokens []string, msg *Message) error {
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	client, err := f.app.Messaging(ctx)
+	if err != nil {
+		return err
+	}
+
+	for start := 0; start < len(tokens); start += fcmMulticastLimit {
+		end := start + fcmMulticastLimit
+		if end > len(tokens) {
+			end = len(tokens)
+		}
+		if err = f.multicast(ctx, client, tokens[start:end], msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *FCM) multicast(ctx context.Context, client *messaging.Client, tokens []string, msg *Message) error {
+	message := &messaging.MulticastMessage{
+		APNS:   newAPNS(),
+		Tokens: tokens,
+		Data:   msg.Data,
+	}
+	if msg.Title != "" {
+		message.Notification = &messaging.Notification{
+			Title: msg.Title,
+			Body:  msg.Body,
+		}
+	}
+
+	result, err := client.SendEachForMulticast(ctx, message)
+	if err != nil {
+		return err
+	}
+	if result.FailureCount == 0 || f.onInvalidTokens == nil {
+		return nil
+	}
+
+	var invalid []string
+	for i, v := range result.Responses {
+		if v.Success || v.Error == nil {
+			continue
+		}
+		switch {
+		case messaging.IsUnregistered(v.Error), messaging.IsSenderIDMismatch(v.Error):
+			invalid = append(invalid, tokens[i])
+		case messaging.IsInvalidArgument(v.Error) && result.SuccessCount > 0:
+			// invalid argument may also come from the payload, only trust it if others succeeded
+			invalid = append(invalid, tokens[i])
+		}
+	}
+	if len(invalid) != 0 {
+		f.onInvalidTokens(ctx, invalid)
+	}
+	return nil
+}
+
+// Announce sends to all devices subscribed to the topic.
+func (f *FCM) Announce(ctx context.Context, topic string, msg *Message) error {
+	client, err := f.app.Messaging(ctx)
+	if err != nil {
+		return err
+	}
+
+	message := &messaging.Message{
+		Notification: &messaging.Notification{
+			Title: msg.Title,
+			Body:  msg.Body,
+		},
+		APNS:  newAPNS(),
+		Topic: topic,
+	}
+	_, err = client.Send(ctx, message)
+	return err
+}
+
+func newAPNS() *messaging.APNSConfig {
+	return &messaging.APNSConfig{
+		Payload: &messaging.APNSPayload{
+			Aps: &messaging.Aps{
+				Sound:            "default",
+				ContentAvailable: true,
+			},
+		},
+	}
+}
@@ -0,0 +1,67 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
+)
+
+// File stands in for a channel which is not configured, messages are appended to the file as json lines.
+type File struct {
+	channel entity.NotifyChannel
+	path    string
+	lock    sync.Mutex
+}
+
+type fileRecord struct {
+	Time    time.Time            `json:"time"`
+	Channel entity.NotifyChannel `json:"channel"`
+	Email   string               `json:"email,omitempty"`
+	Tokens  int                  `json:"tokens,omitempty"`
+	*Message
+}
+
+// NewFile -.
+func NewFile(channel entity.NotifyChannel, path string) *File {
+	return &File{
+		channel: channel,
+		path:    path,
+	}
+}
+
+func (f *File) Channel() entity.NotifyChannel {
+	return f.channel
+}
+
+func (f *File) Notify(ctx context.Context, to *Recipient, msg *Message) error {
+	data, err := json.Marshal(fileRecord{
+		Time:    time.Now(),
+		Channel: f.channel,
+		Email:   to.Email,
+		Tokens:  len(to.PushTokens),
+		Message: msg,
+	})
+	if err != nil {
+		return err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if err = os.MkdirAll(filepath.Dir(f.path), 0o750); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(f.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(data, '\n'))
+	return err
+}
@@ -0,0 +1,25 @@
+package notifier
+
+import (
+	"context"
+
+	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
+)
+
+// Nop stands in for a channel which is not configured, messages are dropped.
+type Nop struct {
+	channel entity.NotifyChannel
+}
+
+// NewNop -.
+func NewNop(channel entity.NotifyChannel) *Nop {
+	return &Nop{channel: channel}
+}
+
+func (n *Nop) Channel() entity.NotifyChannel {
+	return n.channel
+}
+
+func (n *Nop) Notify(ctx context.Context, to *Recipient, msg *Message) error {
+	return nil
+}
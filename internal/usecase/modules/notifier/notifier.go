@@ -0,0 +1,29 @@
+// Package notifier package notifier
+package notifier
+
+import (
+	"context"
+
+	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
+)
+
+// Message -.
+type Message struct {
+	Title string            `json:"title"`
+	Body  string            `json:"body"`
+	Data  map[string]string `json:"data,omitempty"`
+}
+
+// Recipient holds the address of each channel, a notifier skips the recipient if its address is empty.
+type Recipient struct {
+	Email        string
+	PushTokens   []string
+	SlackWebhook string
+	WebhookURL   string
+}
+
+// Notifier delivers a message through one channel.
+type Notifier interface {
+	Channel() entity.NotifyChannel
+	Notify(ctx context.Context, to *Recipient, msg *Message) error
+}
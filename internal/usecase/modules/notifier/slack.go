@@ -0,0 +1,42 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
+)
+
+const slackTimeout = 10 * time.Second
+
+// Slack posts to the incoming webhook of the recipient.
+type Slack struct {
+	client *http.Client
+}
+
+// NewSlack -.
+func NewSlack() *Slack {
+	return &Slack{
+		client: newPublicClient(slackTimeout),
+	}
+}
+
+func (s *Slack) Channel() entity.NotifyChannel {
+	return entity.NotifyChannelSlack
+}
+
+func (s *Slack) Notify(ctx context.Context, to *Recipient, msg *Message) error {
+	if to.SlackWebhook == "" {
+		return nil
+	}
+	// defense in depth, the url is checked on save and again here, the client also rejects non public addresses resolved at send time
+	if err := CheckSlackWebhook(to.SlackWebhook); err != nil {
+		return err
+	}
+	return slack.PostWebhookCustomHTTPContext(ctx, to.SlackWebhook, s.client, &slack.WebhookMessage{
+		Text: fmt.Sprintf("*%s*\n%s", msg.Title, msg.Body),
+	})
+}
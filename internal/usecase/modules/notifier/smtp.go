@@ -0,0 +1,59 @@
+package notifier
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/toc-taiwan/toc-machine-trading/internal/config"
+	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
+	"gopkg.in/gomail.v2"
+)
+
+var errSMTPNotConfigured = errors.New("smtp config not set")
+
+// SMTP -.
+type SMTP struct {
+	cfg config.SMTP
+}
+
+// NewSMTP -.
+func NewSMTP(cfg config.SMTP) *SMTP {
+	return &SMTP{cfg: cfg}
+}
+
+// Configured -.
+func (s *SMTP) Configured() bool {
+	return s.cfg.Host != "" && s.cfg.Port != 0 && s.cfg.Username != "" && s.cfg.Password != ""
+}
+
+func (s *SMTP) Channel() entity.NotifyChannel {
+	return entity.NotifyChannelEmail
+}
+
+func (s *SMTP) Notify(ctx context.Context, to *Recipient, msg *Message) error {
+	if to.Email == "" {
+		return nil
+	}
+	return s.Send(to.Email, msg.Title, strings.ReplaceAll(html.EscapeString(msg.Body), "\n", "<br>"))
+}
+
+// Send sends a html mail.
+func (s *SMTP) Send(to, subject, body string) error {
+	if !s.Configured() {
+		return errSMTPNotConfigured
+	}
+
+	m := gomail.NewMessage()
+	m.SetHeader("From", fmt.Sprintf("TMT <%s>", s.cfg.Username))
+	m.SetHeader("To", to)
+	m.SetHeader("Subject", subject)
+	m.SetBody("text/html", body)
+
+	d := gomail.NewDialer(s.cfg.Host, 587, s.cfg.Username, s.cfg.Password)
+	d.TLSConfig = &tls.Config{InsecureSkipVerify: true}
+	return d.DialAndSend(m)
+}
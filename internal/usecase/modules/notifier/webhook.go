@@ -0,0 +1,63 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
+)
+
+const webhookTimeout = 10 * time.Second
+
+// Webhook posts the message as json to the url of the recipient.
+type Webhook struct {
+	client *http.Client
+}
+
+type webhookBody struct {
+	Time time.Time `json:"time"`
+	*Message
+}
+
+// NewWebhook -.
+func NewWebhook() *Webhook {
+	return &Webhook{
+		client: newPublicClient(webhookTimeout),
+	}
+}
+
+func (w *Webhook) Channel() entity.NotifyChannel {
+	return entity.NotifyChannelWebhook
+}
+
+func (w *Webhook) Notify(ctx context.Context, to *Recipient, msg *Message) error {
+	if to.WebhookURL == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(webhookBody{Time: time.Now(), Message: msg})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, to.WebhookURL, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("webhook responded %d", res.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+// Package partition package partition
+package partition
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
+)
+
+const nameMonthLayout = "200601"
+
+// location is the time zone of month bounds, the same as Asia/Taipei set by the partition migration.
+// Taiwan has no daylight saving, a fixed zone does not depend on tzdata of the host.
+var location = time.FixedZone("Asia/Taipei", 8*60*60)
+
+// MonthStart returns the first day of the month of t in Asia/Taipei.
+func MonthStart(t time.Time) time.Time {
+	t = t.In(location)
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, location)
+}
+
+// Of returns the partition of table which contains t.
+func Of(table entity.HistoryTable, t time.Time) *entity.HistoryPartition {
+	from := MonthStart(t)
+	return &entity.HistoryPartition{
+		Table: table,
+		Name:  fmt.Sprintf("%s_p%s", table, from.Format(nameMonthLayout)),
+		From:  from,
+		To:    from.AddDate(0, 1, 0),
+	}
+}
+
+// Parse returns the partition of the name, ok is false if it is not a monthly partition of table.
+func Parse(table entity.HistoryTable, name string) (*entity.HistoryPartition, bool) {
+	suffix, found := strings.CutPrefix(name, string(table)+"_p")
+	if !found {
+		return nil, false
+	}
+	month, err := time.ParseInLocation(nameMonthLayout, suffix, location)
+	if err != nil {
+		return nil, false
+	}
+	return Of(table, month), true
+}
+
+// Plan returns missing partitions of months from from to until, both included,
+// and existing ones which end before the month of keepFrom, a zero keepFrom keeps all.
+// Both are sorted by month, names which are not monthly partitions of table are ignored.
+func Plan(table entity.HistoryTable, existing []string, from, until, keepFrom time.Time) ([]*entity.HistoryPartition, []*entity.HistoryPartition) {
+	existMap := make(map[string]struct{})
+	var expired []*entity.HistoryPartition
+	for _, name := range existing {
+		p, ok := Parse(table, name)
+		if !ok {
+			continue
+		}
+		existMap[p.Name] = struct{}{}
+		if !keepFrom.IsZero() && !p.To.After(MonthStart(keepFrom)) {
+			expired = append(expired, p)
+		}
+	}
+	sort.SliceStable(expired, func(i, j int) bool {
+		return expired[i].From.Before(expired[j].From)
+	})
+
+	var create []*entity.HistoryPartition
+	for month := MonthStart(from); !month.After(until); month = month.AddDate(0, 1, 0) {
+		p := Of(table, month)
+		if _, ok := existMap[p.Name]; ok {
+			continue
+		}
+		if !keepFrom.IsZero() && p.From.Before(MonthStart(keepFrom)) {
+			continue
+		}
+		create = append(create, p)
+	}
+	return create, expired
+}
@@ -0,0 +1,120 @@
+package partition
+
+import (
+	"testing"
+	"time"
+
+	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
+)
+
+func TestOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       time.Time
+		wantName string
+		wantFrom string
+		wantTo   string
+	}{
+		{"mid month", time.Date(2026, 10, 19, 9, 0, 0, 0, location), "history_stock_tick_p202610", "2026-10-01T00:00:00+08:00", "2026-11-01T00:00:00+08:00"},
+		{"month start", time.Date(2026, 10, 1, 0, 0, 0, 0, location), "history_stock_tick_p202610", "2026-10-01T00:00:00+08:00", "2026-11-01T00:00:00+08:00"},
+		{"last nanosecond", time.Date(2026, 10, 31, 23, 59, 59, 999999999, location), "history_stock_tick_p202610", "2026-10-01T00:00:00+08:00", "2026-11-01T00:00:00+08:00"},
+		{"utc still previous month", time.Date(2026, 9, 30, 16, 0, 0, 0, time.UTC), "history_stock_tick_p202610", "2026-10-01T00:00:00+08:00", "2026-11-01T00:00:00+08:00"},
+		{"utc before taipei month start", time.Date(2026, 9, 30, 15, 59, 59, 0, time.UTC), "history_stock_tick_p202609", "2026-09-01T00:00:00+08:00", "2026-10-01T00:00:00+08:00"},
+		{"december", time.Date(2026, 12, 25, 0, 0, 0, 0, location), "history_stock_tick_p202612", "2026-12-01T00:00:00+08:00", "2027-01-01T00:00:00+08:00"},
+		{"february", time.Date(2028, 2, 29, 12, 0, 0, 0, location), "history_stock_tick_p202802", "2028-02-01T00:00:00+08:00", "2028-03-01T00:00:00+08:00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Of(entity.HistoryTableStockTick, tt.in)
+			if got.Name != tt.wantName {
+				t.Errorf("Of() name = %s, want %s", got.Name, tt.wantName)
+			}
+			if from := got.From.Format(time.RFC3339); from != tt.wantFrom {
+				t.Errorf("Of() from = %s, want %s", from, tt.wantFrom)
+			}
+			if to := got.To.Format(time.RFC3339); to != tt.wantTo {
+				t.Errorf("Of() to = %s, want %s", to, tt.wantTo)
+			}
+			if tt.in.Before(got.From) || !tt.in.Before(got.To) {
+				t.Errorf("Of() [%s, %s) does not contain %s", got.From, got.To, tt.in)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       string
+		wantOK   bool
+		wantFrom string
+	}{
+		{"monthly partition", "history_stock_kbar_p202610", true, "2026-10-01T00:00:00+08:00"},
+		{"january", "history_stock_kbar_p202701", true, "2027-01-01T00:00:00+08:00"},
+		{"other table", "history_stock_tick_p202610", false, ""},
+		{"unpartitioned table", "history_stock_kbar_unpartitioned", false, ""},
+		{"default partition", "history_stock_kbar_default", false, ""},
+		{"invalid month", "history_stock_kbar_p202613", false, ""},
+		{"daily suffix", "history_stock_kbar_p20261019", false, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Parse(entity.HistoryTableStockKbar, tt.in)
+			if ok != tt.wantOK {
+				t.Fatalf("Parse() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if got.Name != tt.in {
+				t.Errorf("Parse() name = %s, want %s", got.Name, tt.in)
+			}
+			if from := got.From.Format(time.RFC3339); from != tt.wantFrom {
+				t.Errorf("Parse() from = %s, want %s", from, tt.wantFrom)
+			}
+		})
+	}
+}
+
+func TestPlan(t *testing.T) {
+	existing := []string{
+		"history_stock_close_p202607",
+		"history_stock_close_p202609",
+		"history_stock_close_p202610",
+		"history_stock_close_default",
+	}
+	from := time.Date(2026, 8, 15, 0, 0, 0, 0, location)
+	until := time.Date(2026, 12, 1, 0, 0, 0, 0, location)
+	keepFrom := time.Date(2026, 8, 20, 0, 0, 0, 0, location)
+
+	create, expired := Plan(entity.HistoryTableStockClose, existing, from, until, keepFrom)
+
+	var createNames, expiredNames []string
+	for _, v := range create {
+		createNames = append(createNames, v.Name)
+	}
+	for _, v := range expired {
+		expiredNames = append(expiredNames, v.Name)
+	}
+
+	wantCreate := []string{"history_stock_close_p202608", "history_stock_close_p202611", "history_stock_close_p202612"}
+	wantExpired := []string{"history_stock_close_p202607"}
+	if !equalStrings(createNames, wantCreate) {
+		t.Errorf("Plan() create = %v, want %v", createNames, wantCreate)
+	}
+	if !equalStrings(expiredNames, wantExpired) {
+		t.Errorf("Plan() expired = %v, want %v", expiredNames, wantExpired)
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -3,6 +3,7 @@ package quota
 
 import (
 	"math"
+	"strings"
 
 	"github.com/toc-taiwan/toc-machine-trading/internal/config"
 )
@@ -12,6 +13,13 @@ const (
 	stockTradeFeeRatio float64 = 0.001425
 
 	futureTradeTaxRatio float64 = 0.00002
+
+	// optionMultiplier is the premium value of one point of TAIEX options, tax is on the premium.
+	optionMultiplier    float64 = 50
+	optionTradeTaxRatio float64 = 0.001
+
+	// optionCategoryPrefix covers TXO and weekly TX1 to TX5, all with the same multiplier.
+	optionCategoryPrefix = "TX"
 )
 
 // Quota -.
@@ -19,6 +27,7 @@ type Quota struct {
 	stockQuota       int64
 	stockFeeDiscount float64
 	futureTradeFee   int64
+	optionTradeFee   int64
 }
 
 // NewQuota -.
@@ -27,6 +36,7 @@ func NewQuota(cfg config.Quota) *Quota {
 		stockQuota:       cfg.StockTradeQuota,
 		stockFeeDiscount: cfg.StockFeeDiscount,
 		futureTradeFee:   cfg.FutureTradeFee,
+		optionTradeFee:   cfg.OptionTradeFee,
 	}
 }
 
@@ -87,3 +97,54 @@ func (q *Quota) GetFutureSellCost(price float64, position int64) int64 {
 	base := price * float64(position) * 50
 	return int64(math.Ceil(base)-math.Floor(base*futureTradeTaxRatio)) - q.futureTradeFee*position
 }
+
+// GetStockTradeFee is the fee before discount.
+func (q *Quota) GetStockTradeFee(price float64, lot, share int64) int64 {
+	base := price*float64(lot)*1000 + price*float64(share)
+	return int64(math.Floor(base * stockTradeFeeRatio))
+}
+
+// GetStockTradeTax is only charged on sell.
+func (q *Quota) GetStockTradeTax(price float64, lot, share int64) int64 {
+	base := price*float64(lot)*1000 + price*float64(share)
+	return int64(math.Floor(base * stockTradeTaxRatio))
+}
+
+// GetFutureTradeFee -.
+func (q *Quota) GetFutureTradeFee(position int64) int64 {
+	return q.futureTradeFee * position
+}
+
+// GetFutureTradeTax -.
+func (q *Quota) GetFutureTradeTax(price float64, position int64) int64 {
+	base := price * float64(position) * 50
+	return int64(math.Floor(base * futureTradeTaxRatio))
+}
+
+// IsOptionSupported returns false for categories the option cost does not apply to, e.g. stock options.
+func IsOptionSupported(category string) bool {
+	return strings.HasPrefix(category, optionCategoryPrefix)
+}
+
+// GetOptionBuyCost is the premium paid with fee and tax.
+func (q *Quota) GetOptionBuyCost(price float64, quantity int64) int64 {
+	base := price * float64(quantity) * optionMultiplier
+	return int64(math.Ceil(base)+math.Floor(base*optionTradeTaxRatio)) + q.optionTradeFee*quantity
+}
+
+// GetOptionSellCost is the premium received after fee and tax.
+func (q *Quota) GetOptionSellCost(price float64, quantity int64) int64 {
+	base := price * float64(quantity) * optionMultiplier
+	return int64(math.Ceil(base)-math.Floor(base*optionTradeTaxRatio)) - q.optionTradeFee*quantity
+}
+
+// GetOptionTradeFee -.
+func (q *Quota) GetOptionTradeFee(quantity int64) int64 {
+	return q.optionTradeFee * quantity
+}
+
+// GetOptionTradeTax -.
+func (q *Quota) GetOptionTradeTax(price float64, quantity int64) int64 {
+	base := price * float64(quantity) * optionMultiplier
+	return int64(math.Floor(base * optionTradeTaxRatio))
+}
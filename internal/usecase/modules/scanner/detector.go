@@ -0,0 +1,204 @@
+package scanner
+
+import (
+	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
+)
+
+const (
+	detailUp   = "up"
+	detailDown = "down"
+)
+
+func average(arr []float64) float64 {
+	if len(arr) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range arr {
+		sum += v
+	}
+	return sum / float64(len(arr))
+}
+
+func closeArr(bars []*entity.StockHistoryKbar) []float64 {
+	result := make([]float64, len(bars))
+	for i, v := range bars {
+		result[i] = v.Close
+	}
+	return result
+}
+
+// maCross hits when the short ma crosses the long ma on the last bar, value is the long ma.
+type maCross struct {
+	short int
+	long  int
+}
+
+func (d *maCross) Name() entity.PatternName {
+	return entity.PatternMACross
+}
+
+func (d *maCross) Detect(bars []*entity.StockHistoryKbar) (string, float64, bool) {
+	count := len(bars)
+	if d.short <= 0 || d.long <= d.short || count <= d.long {
+		return "", 0, false
+	}
+
+	closes := closeArr(bars)
+	lastShort, lastLong := average(closes[count-1-d.short:count-1]), average(closes[count-1-d.long:count-1])
+	short, long := average(closes[count-d.short:]), average(closes[count-d.long:])
+	switch {
+	case lastShort <= lastLong && short > long:
+		return detailUp, long, true
+	case lastShort >= lastLong && short < long:
+		return detailDown, long, true
+	default:
+		return "", 0, false
+	}
+}
+
+// gap hits when the open is ratio percent away from the last close, value is the gap percent.
+type gap struct {
+	ratio float64
+}
+
+func (d *gap) Name() entity.PatternName {
+	return entity.PatternGap
+}
+
+func (d *gap) Detect(bars []*entity.StockHistoryKbar) (string, float64, bool) {
+	count := len(bars)
+	if d.ratio <= 0 || count < 2 || bars[count-2].Close == 0 {
+		return "", 0, false
+	}
+
+	last, current := bars[count-2], bars[count-1]
+	change := 100 * (current.Open - last.Close) / last.Close
+	switch {
+	case change >= d.ratio:
+		return detailUp, change, true
+	case change <= -d.ratio:
+		return detailDown, change, true
+	default:
+		return "", 0, false
+	}
+}
+
+// breakout hits when the close is beyond the high or low of the previous days, value is the broken price.
+type breakout struct {
+	days int
+}
+
+func (d *breakout) Name() entity.PatternName {
+	return entity.PatternBreakout
+}
+
+func (d *breakout) Detect(bars []*entity.StockHistoryKbar) (string, float64, bool) {
+	count := len(bars)
+	if d.days <= 0 || count <= d.days {
+		return "", 0, false
+	}
+
+	var high, low float64
+	for _, v := range bars[count-1-d.days : count-1] {
+		if high == 0 || v.High > high {
+			high = v.High
+		}
+		if low == 0 || v.Low < low {
+			low = v.Low
+		}
+	}
+
+	current := bars[count-1]
+	switch {
+	case current.Close > high:
+		return detailUp, high, true
+	case current.Close < low:
+		return detailDown, low, true
+	default:
+		return "", 0, false
+	}
+}
+
+// volumeSpike hits when the volume is ratio times the average of previous days, value is the times.
+type volumeSpike struct {
+	days  int
+	ratio float64
+}
+
+func (d *volumeSpike) Name() entity.PatternName {
+	return entity.PatternVolumeSpike
+}
+
+func (d *volumeSpike) Detect(bars []*entity.StockHistoryKbar) (string, float64, bool) {
+	count := len(bars)
+	if d.days <= 0 || d.ratio <= 0 || count <= d.days {
+		return "", 0, false
+	}
+
+	volumes := make([]float64, 0, d.days)
+	for _, v := range bars[count-1-d.days : count-1] {
+		volumes = append(volumes, float64(v.Volume))
+	}
+	avg := average(volumes)
+	if avg == 0 {
+		return "", 0, false
+	}
+
+	current := bars[count-1]
+	times := float64(current.Volume) / avg
+	if times < d.ratio {
+		return "", 0, false
+	}
+	if current.Close >= current.Open {
+		return detailUp, times, true
+	}
+	return detailDown, times, true
+}
+
+// insideBar hits when the range is inside the range of the last bar, value is the range ratio.
+type insideBar struct{}
+
+func (d *insideBar) Name() entity.PatternName {
+	return entity.PatternInsideBar
+}
+
+func (d *insideBar) Detect(bars []*entity.StockHistoryKbar) (string, float64, bool) {
+	count := len(bars)
+	if count < 2 {
+		return "", 0, false
+	}
+
+	last, current := bars[count-2], bars[count-1]
+	if last.High <= last.Low || current.High >= last.High || current.Low <= last.Low {
+		return "", 0, false
+	}
+	ratio := (current.High - current.Low) / (last.High - last.Low)
+	if current.Close >= current.Open {
+		return detailUp, ratio, true
+	}
+	return detailDown, ratio, true
+}
+
+// quaterMAReborn hits when the last close is not above the ma and the open is above it, value is the ma.
+type quaterMAReborn struct {
+	period int
+}
+
+func (d *quaterMAReborn) Name() entity.PatternName {
+	return entity.PatternQuaterMA
+}
+
+func (d *quaterMAReborn) Detect(bars []*entity.StockHistoryKbar) (string, float64, bool) {
+	count := len(bars)
+	if d.period <= 0 || count <= d.period {
+		return "", 0, false
+	}
+
+	ma := average(closeArr(bars[count-1-d.period : count-1]))
+	last, current := bars[count-2], bars[count-1]
+	if last.Close > ma || current.Open <= ma {
+		return "", 0, false
+	}
+	return detailUp, ma, true
+}
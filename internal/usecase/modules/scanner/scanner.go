@@ -0,0 +1,85 @@
+// Package scanner package scanner
+package scanner
+
+import (
+	"time"
+
+	"github.com/toc-taiwan/toc-machine-trading/internal/config"
+	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
+)
+
+// Detector finds one pattern on the last bar of the day bars, bars are sorted by time.
+type Detector interface {
+	Name() entity.PatternName
+	Detect(bars []*entity.StockHistoryKbar) (detail string, value float64, ok bool)
+}
+
+// Scanner runs registered detectors over day bars of many stocks.
+type Scanner struct {
+	detectors []Detector
+}
+
+// NewScanner registers the built-in detectors enabled in config.
+func NewScanner(cfg config.Scanner) *Scanner {
+	s := &Scanner{}
+	for _, v := range cfg.Enabled {
+		switch entity.PatternName(v) {
+		case entity.PatternMACross:
+			s.Register(&maCross{short: cfg.MAShort, long: cfg.MALong})
+		case entity.PatternGap:
+			s.Register(&gap{ratio: cfg.GapRatio})
+		case entity.PatternBreakout:
+			s.Register(&breakout{days: cfg.BreakoutDays})
+		case entity.PatternVolumeSpike:
+			s.Register(&volumeSpike{days: cfg.VolumeSpikeDays, ratio: cfg.VolumeSpikeRatio})
+		case entity.PatternInsideBar:
+			s.Register(&insideBar{})
+		case entity.PatternQuaterMA:
+			s.Register(&quaterMAReborn{period: cfg.QuaterMADays})
+		}
+	}
+	return s
+}
+
+// Register adds a detector, detectors with the same name are replaced.
+func (s *Scanner) Register(d Detector) {
+	for i, v := range s.detectors {
+		if v.Name() == d.Name() {
+			s.detectors[i] = d
+			return
+		}
+	}
+	s.detectors = append(s.detectors, d)
+}
+
+// Scan returns hits on date, bars of each stock after date are ignored and stocks without a bar on date are skipped.
+func (s *Scanner) Scan(date time.Time, barsMap map[string][]*entity.StockHistoryKbar) []*entity.PatternHit {
+	var result []*entity.PatternHit
+	now := time.Now()
+	end := date.AddDate(0, 0, 1)
+	for stockNum, bars := range barsMap {
+		count := len(bars)
+		for count > 0 && !bars[count-1].KbarTime.Before(end) {
+			count--
+		}
+		if count == 0 || bars[count-1].KbarTime.Before(date) {
+			continue
+		}
+
+		for _, d := range s.detectors {
+			detail, value, ok := d.Detect(bars[:count])
+			if !ok {
+				continue
+			}
+			result = append(result, &entity.PatternHit{
+				Date:     date,
+				StockNum: stockNum,
+				Pattern:  d.Name(),
+				Detail:   detail,
+				Value:    value,
+				Created:  now,
+			})
+		}
+	}
+	return result
+}
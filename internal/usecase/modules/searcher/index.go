@@ -0,0 +1,192 @@
+package searcher
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// match tier of a document, lower is better.
+const (
+	tierExactCode int = iota
+	tierExactName
+	tierPrefixCode
+	tierPrefixName
+	tierContains
+)
+
+type document[T any] struct {
+	code    string
+	name    string
+	item    T
+	deleted bool
+}
+
+// index is an n-gram index of code and name, every unigram and bigram points to its documents,
+// a query is looked up by its rarest gram then verified by substring.
+type index[T any] struct {
+	docs    []*document[T]
+	codeMap map[string]int
+	grams   map[string][]int
+
+	// less is the order of documents in the same tier and popularity.
+	less func(a, b T) bool
+}
+
+func newIndex[T any](less func(a, b T) bool) *index[T] {
+	return &index[T]{
+		codeMap: make(map[string]int),
+		grams:   make(map[string][]int),
+		less:    less,
+	}
+}
+
+// add replaces the document of the same code, it is re-indexed only if the name changed.
+func (idx *index[T]) add(code, name string, item T) {
+	code, name = strings.ToLower(code), strings.ToLower(name)
+	if id, ok := idx.codeMap[code]; ok {
+		doc := idx.docs[id]
+		if doc.name == name {
+			doc.item = item
+			return
+		}
+		doc.deleted = true
+	}
+
+	id := len(idx.docs)
+	idx.docs = append(idx.docs, &document[T]{code: code, name: name, item: item})
+	idx.codeMap[code] = id
+
+	gramSet := make(map[string]struct{})
+	for _, g := range splitGrams(code) {
+		gramSet[g] = struct{}{}
+	}
+	for _, g := range splitGrams(name) {
+		gramSet[g] = struct{}{}
+	}
+	for g := range gramSet {
+		idx.grams[g] = append(idx.grams[g], id)
+	}
+}
+
+func splitGrams(s string) []string {
+	runes := []rune(s)
+	result := make([]string, 0, 2*len(runes))
+	for i := range runes {
+		result = append(result, string(runes[i]))
+		if i+1 < len(runes) {
+			result = append(result, string(runes[i:i+2]))
+		}
+	}
+	return result
+}
+
+// candidates returns ids of documents which may contain the query, all live documents if query is empty.
+func (idx *index[T]) candidates(query string) []int {
+	if query == "" {
+		result := make([]int, 0, len(idx.codeMap))
+		for _, id := range idx.codeMap {
+			result = append(result, id)
+		}
+		sort.Ints(result)
+		return result
+	}
+
+	runes := []rune(query)
+	if len(runes) == 1 {
+		return idx.grams[query]
+	}
+
+	var rarest []int
+	for i := 0; i+1 < len(runes); i++ {
+		posting, ok := idx.grams[string(runes[i:i+2])]
+		if !ok {
+			return nil
+		}
+		if rarest == nil || len(posting) < len(rarest) {
+			rarest = posting
+		}
+	}
+	return rarest
+}
+
+type ranked[T any] struct {
+	item       T
+	tier       int
+	popularity int
+}
+
+// search returns documents containing the query in code or name, exact matches first,
+// then prefix matches, then popular ones by the popularity rank of code.
+func (idx *index[T]) search(query string, popularity map[string]int) []T {
+	query = strings.ToLower(query)
+	if query == "" {
+		return nil
+	}
+
+	var matched []ranked[T]
+	for _, id := range idx.candidates(query) {
+		doc := idx.docs[id]
+		if doc.deleted {
+			continue
+		}
+
+		var tier int
+		switch {
+		case doc.code == query:
+			tier = tierExactCode
+		case doc.name == query:
+			tier = tierExactName
+		case strings.HasPrefix(doc.code, query):
+			tier = tierPrefixCode
+		case strings.HasPrefix(doc.name, query):
+			tier = tierPrefixName
+		case strings.Contains(doc.code, query), strings.Contains(doc.name, query):
+			tier = tierContains
+		default:
+			continue
+		}
+
+		rank, ok := popularity[doc.code]
+		if !ok {
+			rank = math.MaxInt
+		}
+		matched = append(matched, ranked[T]{item: doc.item, tier: tier, popularity: rank})
+	}
+
+	sort.SliceStable(matched, func(i, j int) bool {
+		if matched[i].tier != matched[j].tier {
+			return matched[i].tier < matched[j].tier
+		}
+		if matched[i].popularity != matched[j].popularity {
+			return matched[i].popularity < matched[j].popularity
+		}
+		return idx.less(matched[i].item, matched[j].item)
+	})
+
+	if len(matched) == 0 {
+		return nil
+	}
+	result := make([]T, len(matched))
+	for i, v := range matched {
+		result[i] = v.item
+	}
+	return result
+}
+
+// items returns live documents of the query, all of them if query is empty, in no particular order.
+func (idx *index[T]) items(query string) []T {
+	query = strings.ToLower(query)
+	var result []T
+	for _, id := range idx.candidates(query) {
+		doc := idx.docs[id]
+		if doc.deleted {
+			continue
+		}
+		if query != "" && !strings.Contains(doc.code, query) && !strings.Contains(doc.name, query) {
+			continue
+		}
+		result = append(result, doc.item)
+	}
+	return result
+}
@@ -18,35 +18,56 @@ type Searcher interface {
 	AddStock(stock *entity.Stock)
 	AddFuture(future *entity.Future)
 	AddOption(option *entity.Option)
+	SetPopularity(codes []string)
 
 	SearchStock(code string) []*entity.Stock
 	SearchFuture(code string) []*entity.Future
 	SearchOption(code string) []*entity.Option
+	FilterOption(filter *entity.OptionSearchFilter) []*entity.Option
 }
 
 type searcher struct {
 	lock sync.RWMutex
 
-	stockArr  []*entity.Stock
-	futureArr []*entity.Future
-	optionArr []*entity.Option
+	stockIndex  *index[*entity.Stock]
+	futureIndex *index[*entity.Future]
+	optionIndex *index[*entity.Option]
+
+	// popularity is the volume rank of code, lower is more popular.
+	popularity map[string]int
 }
 
 func Get() Searcher {
 	if singleton == nil {
 		once.Do(func() {
-			singleton = &searcher{}
+			singleton = newSearcher()
 		})
 		return Get()
 	}
 	return singleton
 }
 
+func newSearcher() *searcher {
+	return &searcher{
+		stockIndex: newIndex(func(a, b *entity.Stock) bool {
+			return a.Number < b.Number
+		}),
+		futureIndex: newIndex(func(a, b *entity.Future) bool {
+			return a.DeliveryDate.Before(b.DeliveryDate)
+		}),
+		optionIndex: newIndex(func(a, b *entity.Option) bool {
+			return a.DeliveryDate.Before(b.DeliveryDate)
+		}),
+		popularity: make(map[string]int),
+	}
+}
+
+// AddStock replaces the stock of the same number.
 func (s *searcher) AddStock(stock *entity.Stock) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
-	s.stockArr = append(s.stockArr, stock)
+	s.stockIndex.add(stock.Number, stock.Name, stock)
 }
 
 func (s *searcher) AddFuture(future *entity.Future) {
@@ -57,86 +78,88 @@ func (s *searcher) AddFuture(future *entity.Future) {
 		return
 	}
 
-	s.futureArr = append(s.futureArr, future)
+	s.futureIndex.add(future.Code, future.Name, future)
 }
 
 func (s *searcher) AddOption(option *entity.Option) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
-	s.optionArr = append(s.optionArr, option)
+	s.optionIndex.add(option.Code, option.Name, option)
 }
 
-func (s *searcher) SearchStock(param string) []*entity.Stock {
-	if param == "" {
-		return nil
+// SetPopularity boosts codes in order, usually the volume rank, other codes keep their order after them.
+func (s *searcher) SetPopularity(codes []string) {
+	popularity := make(map[string]int, len(codes))
+	for i, v := range codes {
+		code := strings.ToLower(v)
+		if _, ok := popularity[code]; !ok {
+			popularity[code] = i
+		}
 	}
 
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.popularity = popularity
+}
+
+func (s *searcher) SearchStock(param string) []*entity.Stock {
 	s.lock.RLock()
 	defer s.lock.RUnlock()
 
-	param = strings.ToLower(param)
-	var result []*entity.Stock
-	for _, v := range s.stockArr {
-		if strings.Contains(strings.ToLower(v.Number), param) {
-			result = append(result, v)
-		} else if strings.Contains(strings.ToLower(v.Name), param) {
-			result = append(result, v)
-		}
-	}
-	if len(result) != 0 {
-		sort.SliceStable(result, func(i, j int) bool {
-			return result[i].Number < result[j].Number
-		})
-	}
-	return result
+	return s.stockIndex.search(param, s.popularity)
 }
 
 func (s *searcher) SearchFuture(param string) []*entity.Future {
-	if param == "" {
-		return nil
-	}
-
 	s.lock.RLock()
 	defer s.lock.RUnlock()
 
-	param = strings.ToLower(param)
-	var result []*entity.Future
-	for _, v := range s.futureArr {
-		if strings.Contains(strings.ToLower(v.Code), param) {
-			result = append(result, v)
-		} else if strings.Contains(strings.ToLower(v.Name), param) {
-			result = append(result, v)
-		}
-	}
-	if len(result) != 0 {
-		sort.SliceStable(result, func(i, j int) bool {
-			return result[i].DeliveryDate.Before(result[j].DeliveryDate)
-		})
-	}
-	return result
+	return s.futureIndex.search(param, s.popularity)
 }
 
 func (s *searcher) SearchOption(param string) []*entity.Option {
-	if param == "" {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.optionIndex.search(param, s.popularity)
+}
+
+// FilterOption matches keyword like SearchOption, underlying is the category, right is call or put.
+func (s *searcher) FilterOption(filter *entity.OptionSearchFilter) []*entity.Option {
+	if filter == nil || filter.IsEmpty() {
 		return nil
 	}
 
 	s.lock.RLock()
 	defer s.lock.RUnlock()
 
-	param = strings.ToLower(param)
+	underlying := strings.ToUpper(filter.Underlying)
+	right := strings.ToUpper(filter.Right)
 	var result []*entity.Option
-	for _, v := range s.optionArr {
-		if strings.Contains(strings.ToLower(v.Code), param) {
-			result = append(result, v)
-		} else if strings.Contains(strings.ToLower(v.Name), param) {
-			result = append(result, v)
+	for _, v := range s.optionIndex.items(filter.Keyword) {
+		if underlying != "" && strings.ToUpper(v.Category) != underlying {
+			continue
+		}
+		if right != "" && !strings.HasPrefix(strings.ToUpper(v.OptionRight), right[:1]) {
+			continue
+		}
+		if filter.DeliveryMonth != "" && v.DeliveryMonth != filter.DeliveryMonth {
+			continue
+		}
+		if (filter.StrikeMin != 0 && v.StrikePrice < filter.StrikeMin) || (filter.StrikeMax != 0 && v.StrikePrice > filter.StrikeMax) {
+			continue
 		}
+		result = append(result, v)
 	}
 	if len(result) != 0 {
 		sort.SliceStable(result, func(i, j int) bool {
-			return result[i].DeliveryDate.Before(result[j].DeliveryDate)
+			if !result[i].DeliveryDate.Equal(result[j].DeliveryDate) {
+				return result[i].DeliveryDate.Before(result[j].DeliveryDate)
+			}
+			if result[i].StrikePrice != result[j].StrikePrice {
+				return result[i].StrikePrice < result[j].StrikePrice
+			}
+			return result[i].IsCall() && !result[j].IsCall()
 		})
 	}
 	return result
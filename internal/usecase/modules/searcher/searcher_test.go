@@ -0,0 +1,238 @@
+package searcher
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
+)
+
+var (
+	nameRunes = []rune("台積聯發鴻海富邦國泰中華電信統一大立光華碩廣達緯創仁寶和碩友達群創南亞塑化長榮陽明萬海玉山元大兆豐")
+	// calls and futures use A to L for months, puts use M to X.
+	monthCodes = []rune("ABCDEFGHIJKLMNOPQRSTUVWX")
+)
+
+// newBenchSearcher builds a universe close to the real one, about 1,800 stocks, 3,000 futures and 16,000 options.
+func newBenchSearcher() *searcher {
+	s := newSearcher()
+	base := time.Date(2026, 1, 21, 13, 30, 0, 0, time.Local)
+
+	for i := 0; i < 1800; i++ {
+		name := string([]rune{nameRunes[i%len(nameRunes)], nameRunes[(i*7+3)%len(nameRunes)], nameRunes[(i*13+5)%len(nameRunes)]})
+		s.AddStock(&entity.Stock{Number: fmt.Sprintf("%d", 1101+i*5), Name: name})
+	}
+	// refreshed details should not be duplicated
+	s.AddStock(&entity.Stock{Number: "2330", Name: "台積電"})
+	s.AddStock(&entity.Stock{Number: "2330", Name: "台積電"})
+
+	categories := []string{"TXF", "MXF"}
+	for c := 0; c < 248; c++ {
+		categories = append(categories, string([]rune{rune('A' + c%26), rune('A' + (c/26)%26), 'F'}))
+	}
+	for _, category := range categories {
+		for m := 0; m < 12; m++ {
+			s.AddFuture(&entity.Future{
+				Code:         fmt.Sprintf("%s%c6", category, monthCodes[m]),
+				Name:         fmt.Sprintf("%s期貨%02d", category, m+1),
+				Category:     category,
+				DeliveryDate: base.AddDate(0, m, 0),
+			})
+		}
+	}
+
+	for m := 0; m < 10; m++ {
+		for strike := 12000; strike <= 28000; strike += 50 {
+			for r, right := range []string{"C", "P"} {
+				s.AddOption(&entity.Option{
+					Code:          fmt.Sprintf("TXO%d%c6", strike, monthCodes[m+12*r]),
+					Name:          fmt.Sprintf("臺指選擇權%02d%s%d", m+1, right, strike),
+					Category:      "TXO",
+					DeliveryMonth: fmt.Sprintf("2026%02d", m+1),
+					DeliveryDate:  base.AddDate(0, m, 0),
+					StrikePrice:   float64(strike),
+					OptionRight:   right,
+				})
+			}
+		}
+	}
+	for c := 0; c < 30; c++ {
+		category := string([]rune{rune('A' + c%26), rune('A' + (c/26)%26), 'O'})
+		for m := 0; m < 4; m++ {
+			for strike := 0; strike < 40; strike++ {
+				for r, right := range []string{"C", "P"} {
+					s.AddOption(&entity.Option{
+						Code:          fmt.Sprintf("%s%d%c6", category, 100+strike*5, monthCodes[m+12*r]),
+						Name:          fmt.Sprintf("%s選擇權%02d%s", category, m+1, right),
+						Category:      category,
+						DeliveryMonth: fmt.Sprintf("2026%02d", m+1),
+						DeliveryDate:  base.AddDate(0, m, 0),
+						StrikePrice:   float64(100 + strike*5),
+						OptionRight:   right,
+					})
+				}
+			}
+		}
+	}
+
+	s.SetPopularity([]string{"2330", "2317", "2454"})
+	return s
+}
+
+// keystrokes returns every prefix of word, the query of each key press.
+func keystrokes(word string) []string {
+	runes := []rune(word)
+	result := make([]string, len(runes))
+	for i := range runes {
+		result[i] = string(runes[:i+1])
+	}
+	return result
+}
+
+func BenchmarkSearchStock(b *testing.B) {
+	s := newBenchSearcher()
+	for _, word := range []string{"2330", "台積電"} {
+		for _, q := range keystrokes(word) {
+			b.Run(q, func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					s.SearchStock(q)
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkSearchFuture(b *testing.B) {
+	s := newBenchSearcher()
+	for _, q := range keystrokes("MXFA6") {
+		b.Run(q, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				s.SearchFuture(q)
+			}
+		})
+	}
+}
+
+func BenchmarkSearchOption(b *testing.B) {
+	s := newBenchSearcher()
+	for _, q := range keystrokes("TXO20000C6") {
+		b.Run(q, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				s.SearchOption(q)
+			}
+		})
+	}
+}
+
+func stockNumbers(arr []*entity.Stock) []string {
+	result := make([]string, len(arr))
+	for i, v := range arr {
+		result[i] = v.Number
+	}
+	return result
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSearchStockTier(t *testing.T) {
+	s := newSearcher()
+	// added in reverse, number order alone would be 0099, 0100, 1500, 50, 5000
+	s.AddStock(&entity.Stock{Number: "1500", Name: "包含"})
+	s.AddStock(&entity.Stock{Number: "0100", Name: "50指數"})
+	s.AddStock(&entity.Stock{Number: "5000", Name: "前綴"})
+	s.AddStock(&entity.Stock{Number: "0099", Name: "50"})
+	s.AddStock(&entity.Stock{Number: "50", Name: "代號"})
+	// popularity only orders inside a tier
+	s.SetPopularity([]string{"1500", "0100"})
+
+	want := []string{"50", "0099", "5000", "0100", "1500"}
+	if got := stockNumbers(s.SearchStock("50")); !equalStrings(got, want) {
+		t.Errorf("SearchStock() = %v, want %v", got, want)
+	}
+}
+
+func TestSearchStockPopularity(t *testing.T) {
+	s := newSearcher()
+	s.AddStock(&entity.Stock{Number: "2301", Name: "光寶科"})
+	s.AddStock(&entity.Stock{Number: "2302", Name: "麗正"})
+	s.AddStock(&entity.Stock{Number: "2303", Name: "聯電"})
+	s.AddStock(&entity.Stock{Number: "2304", Name: "台光"})
+
+	if got, want := stockNumbers(s.SearchStock("230")), []string{"2301", "2302", "2303", "2304"}; !equalStrings(got, want) {
+		t.Errorf("SearchStock() without popularity = %v, want %v", got, want)
+	}
+
+	s.SetPopularity([]string{"2303", "2302", "2303"})
+	if got, want := stockNumbers(s.SearchStock("230")), []string{"2303", "2302", "2301", "2304"}; !equalStrings(got, want) {
+		t.Errorf("SearchStock() with popularity = %v, want %v", got, want)
+	}
+}
+
+func TestAddStockReplace(t *testing.T) {
+	s := newSearcher()
+	s.AddStock(&entity.Stock{Number: "2330", Name: "台積電", LastClose: 1000})
+	s.AddStock(&entity.Stock{Number: "2330", Name: "台積電", LastClose: 1010})
+
+	result := s.SearchStock("2330")
+	if len(result) != 1 || result[0].LastClose != 1010 {
+		t.Fatalf("SearchStock() after same name = %+v, want one stock of the last add", result)
+	}
+
+	s.AddStock(&entity.Stock{Number: "2330", Name: "新台積"})
+	result = s.SearchStock("2330")
+	if len(result) != 1 || result[0].Name != "新台積" {
+		t.Fatalf("SearchStock() after rename = %+v, want one stock of the new name", result)
+	}
+	if result = s.SearchStock("台積電"); result != nil {
+		t.Errorf("SearchStock() of the old name = %+v, want nil", result)
+	}
+	if result = s.SearchStock("新台"); len(result) != 1 {
+		t.Errorf("SearchStock() of the new name = %+v, want one stock", result)
+	}
+}
+
+func TestSearchStockSingleRune(t *testing.T) {
+	s := newSearcher()
+	s.AddStock(&entity.Stock{Number: "2330", Name: "台積電"})
+	s.AddStock(&entity.Stock{Number: "3045", Name: "台灣大"})
+	s.AddStock(&entity.Stock{Number: "2317", Name: "鴻海"})
+
+	if got, want := stockNumbers(s.SearchStock("台")), []string{"2330", "3045"}; !equalStrings(got, want) {
+		t.Errorf("SearchStock() = %v, want %v", got, want)
+	}
+	// 3045 is a prefix match, the others contain it
+	if got, want := stockNumbers(s.SearchStock("3")), []string{"3045", "2317", "2330"}; !equalStrings(got, want) {
+		t.Errorf("SearchStock() = %v, want %v", got, want)
+	}
+	if got := s.SearchStock("9"); got != nil {
+		t.Errorf("SearchStock() of missing rune = %v, want nil", stockNumbers(got))
+	}
+}
+
+func TestSearchStockMissingBigram(t *testing.T) {
+	s := newSearcher()
+	s.AddStock(&entity.Stock{Number: "2330", Name: "台積"})
+	s.AddStock(&entity.Stock{Number: "2303", Name: "積電"})
+
+	if got := s.SearchStock("台電"); got != nil {
+		t.Errorf("SearchStock() of missing bigram = %v, want nil", stockNumbers(got))
+	}
+	// every bigram exists but in different stocks
+	if got := s.SearchStock("台積電"); got != nil {
+		t.Errorf("SearchStock() of split bigrams = %v, want nil", stockNumbers(got))
+	}
+	if got := s.SearchStock(""); got != nil {
+		t.Errorf("SearchStock() of empty query = %v, want nil", stockNumbers(got))
+	}
+}
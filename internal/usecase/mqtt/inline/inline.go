@@ -10,6 +10,7 @@ import (
 	mqttSrv "github.com/mochi-mqtt/server/v2"
 	"github.com/mochi-mqtt/server/v2/packets"
 	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
+	"github.com/toc-taiwan/toc-machine-trading/internal/usecase/cache"
 	"github.com/toc-taiwan/toc-machine-trading/internal/usecase/mqtt"
 	"github.com/toc-taiwan/toc-machine-trading/pkg/embedbkr"
 	"github.com/toc-taiwan/toc-trade-protobuf/golang/pb"
@@ -18,6 +19,7 @@ import (
 
 type Inliner struct {
 	srv *embedbkr.MQSrv
+	cc  *cache.Cache
 
 	subIDMap     map[int]struct{}
 	subIDMapLock sync.Mutex
@@ -26,6 +28,7 @@ type Inliner struct {
 func NewInliner() mqtt.MQTT {
 	return &Inliner{
 		srv:      embedbkr.Get(),
+		cc:       cache.Get(),
 		subIDMap: make(map[int]struct{}),
 	}
 }
@@ -213,6 +216,14 @@ func (i *Inliner) protoToOrder(proto *pb.OrderStatus) interface{} {
 			OrderDetail: detail,
 		}
 	case pb.OrderType_TYPE_FUTURE:
+		// options are reported as future orders, tell them apart by the option detail
+		if i.cc.GetOptionDetail(proto.GetCode()) != nil {
+			return &entity.OptionOrder{
+				Code:        proto.GetCode(),
+				Quantity:    proto.GetQuantity(),
+				OrderDetail: detail,
+			}
+		}
 		return &entity.FutureOrder{
 			Code:        proto.GetCode(),
 			Position:    proto.GetQuantity(),
@@ -0,0 +1,186 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/toc-taiwan/postgres"
+	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
+)
+
+type alert struct {
+	*postgres.Postgres
+}
+
+func NewAlert(pg *postgres.Postgres) AlertRepo {
+	return &alert{pg}
+}
+
+// InsertAlertRule inserts the rule and fills its id.
+func (r *alert) InsertAlertRule(ctx context.Context, t *entity.AlertRule) error {
+	builder := r.Builder.Insert(tableNameAlertRule).
+		Columns("user_id, type, code, threshold, cooldown, enabled, created").
+		Values(t.UserID, string(t.Type), t.Code, t.Threshold, t.Cooldown, t.Enabled, t.Created).
+		Suffix("RETURNING id")
+
+	tx, err := r.BeginTransaction()
+	if err != nil {
+		return err
+	}
+	defer r.EndTransaction(tx, err)
+	var sql string
+	var args []interface{}
+
+	if sql, args, err = builder.ToSql(); err != nil {
+		return err
+	} else if err = tx.QueryRow(ctx, sql, args...).Scan(&t.ID); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *alert) UpdateAlertRule(ctx context.Context, t *entity.AlertRule) error {
+	builder := r.Builder.Update(tableNameAlertRule).
+		Set("type", string(t.Type)).
+		Set("code", t.Code).
+		Set("threshold", t.Threshold).
+		Set("cooldown", t.Cooldown).
+		Set("enabled", t.Enabled).
+		Where(squirrel.Eq{"id": t.ID})
+
+	tx, err := r.BeginTransaction()
+	if err != nil {
+		return err
+	}
+	defer r.EndTransaction(tx, err)
+	var sql string
+	var args []interface{}
+
+	if sql, args, err = builder.ToSql(); err != nil {
+		return err
+	} else if _, err = tx.Exec(ctx, sql, args...); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *alert) DeleteAlertRule(ctx context.Context, id int) error {
+	builder := r.Builder.Delete(tableNameAlertRule).Where(squirrel.Eq{"id": id})
+
+	tx, err := r.BeginTransaction()
+	if err != nil {
+		return err
+	}
+	defer r.EndTransaction(tx, err)
+	var sql string
+	var args []interface{}
+
+	if sql, args, err = builder.ToSql(); err != nil {
+		return err
+	} else if _, err = tx.Exec(ctx, sql, args...); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *alert) QueryAlertRuleByUserID(ctx context.Context, userID int) ([]*entity.AlertRule, error) {
+	return r.queryAlertRule(ctx, squirrel.Eq{"user_id": userID})
+}
+
+func (r *alert) QueryEnabledAlertRule(ctx context.Context) ([]*entity.AlertRule, error) {
+	return r.queryAlertRule(ctx, squirrel.Eq{"enabled": true})
+}
+
+func (r *alert) queryAlertRule(ctx context.Context, where squirrel.Eq) ([]*entity.AlertRule, error) {
+	sql, arg, err := r.Builder.
+		Select("id, user_id, type, code, threshold, cooldown, enabled, last_triggered, created").
+		From(tableNameAlertRule).
+		Where(where).
+		OrderBy("id ASC").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.Pool().Query(ctx, sql, arg...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*entity.AlertRule
+	for rows.Next() {
+		e := entity.AlertRule{}
+		var alertType string
+		if err := rows.Scan(&e.ID, &e.UserID, &alertType, &e.Code, &e.Threshold, &e.Cooldown, &e.Enabled, &e.LastTriggered, &e.Created); err != nil {
+			return nil, err
+		}
+		e.Type = entity.AlertType(alertType)
+		result = append(result, &e)
+	}
+	return result, nil
+}
+
+// InsertAlertHistory inserts the history and marks the rule triggered in the same transaction.
+func (r *alert) InsertAlertHistory(ctx context.Context, t *entity.AlertHistory) error {
+	insert := r.Builder.Insert(tableNameAlertHistory).
+		Columns("rule_id, user_id, type, code, threshold, value, message, triggered").
+		Values(t.RuleID, t.UserID, string(t.Type), t.Code, t.Threshold, t.Value, t.Message, t.Triggered).
+		Suffix("RETURNING id")
+	update := r.Builder.Update(tableNameAlertRule).
+		Set("last_triggered", t.Triggered).
+		Where(squirrel.Eq{"id": t.RuleID})
+
+	tx, err := r.BeginTransaction()
+	if err != nil {
+		return err
+	}
+	defer r.EndTransaction(tx, err)
+	var sql string
+	var args []interface{}
+
+	if sql, args, err = insert.ToSql(); err != nil {
+		return err
+	} else if err = tx.QueryRow(ctx, sql, args...).Scan(&t.ID); err != nil {
+		return err
+	}
+
+	if sql, args, err = update.ToSql(); err != nil {
+		return err
+	} else if _, err = tx.Exec(ctx, sql, args...); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *alert) QueryAlertHistoryByUserID(ctx context.Context, userID int, limit, offset uint64) ([]*entity.AlertHistory, error) {
+	sql, arg, err := r.Builder.
+		Select("id, rule_id, user_id, type, code, threshold, value, message, triggered").
+		From(tableNameAlertHistory).
+		Where(squirrel.Eq{"user_id": userID}).
+		OrderBy("triggered DESC").
+		Limit(limit).
+		Offset(offset).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.Pool().Query(ctx, sql, arg...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*entity.AlertHistory
+	for rows.Next() {
+		e := entity.AlertHistory{}
+		var alertType string
+		if err := rows.Scan(&e.ID, &e.RuleID, &e.UserID, &alertType, &e.Code, &e.Threshold, &e.Value, &e.Message, &e.Triggered); err != nil {
+			return nil, err
+		}
+		e.Type = entity.AlertType(alertType)
+		result = append(result, &e)
+	}
+	return result, nil
+}
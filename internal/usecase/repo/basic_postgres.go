@@ -5,6 +5,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/Masterminds/squirrel"
 	"github.com/google/go-cmp/cmp"
 	"github.com/toc-taiwan/postgres"
 	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
@@ -280,6 +281,34 @@ func (r *basic) queryAllFuture(ctx context.Context) (map[string]*entity.Future,
 	return entities, nil
 }
 
+// QueryFutureArrByCategory returns listed and expired futures of the category in delivery order.
+func (r *basic) QueryFutureArrByCategory(ctx context.Context, category string) ([]*entity.Future, error) {
+	sql, arg, err := r.Builder.
+		Select("code, symbol, name, category, delivery_month, delivery_date, underlying_kind, unit, limit_up, limit_down, reference, update_date").
+		From(tableNameFuture).
+		Where(squirrel.Eq{"category": category}).
+		OrderBy("delivery_date ASC").ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.Pool().Query(ctx, sql, arg...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*entity.Future
+	for rows.Next() {
+		e := entity.Future{}
+		if err = rows.Scan(&e.Code, &e.Symbol, &e.Name, &e.Category, &e.DeliveryMonth, &e.DeliveryDate, &e.UnderlyingKind, &e.Unit, &e.LimitUp, &e.LimitDown, &e.Reference, &e.UpdateDate); err != nil {
+			return nil, err
+		}
+		result = append(result, &e)
+	}
+	return result, nil
+}
+
 func (r *basic) InsertOrUpdatetOptionArr(ctx context.Context, t []*entity.Option) error {
 	inDBOption, err := r.queryAllOption(ctx)
 	if err != nil {
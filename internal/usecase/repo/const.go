@@ -3,21 +3,29 @@ package repo
 var batchSize int = 2000
 
 const (
-	tableNameCalendar string = "basic_calendar"
-	tableNameStock    string = "basic_stock"
-	tableNameFuture   string = "basic_future"
-	tableNameOption   string = "basic_option"
-	tableNameTarget   string = "basic_targets"
-
-	tableNameHistoryStockAnalyze string = "history_stock_analyze"
-	tableNameHistoryStockClose   string = "history_stock_close"
-	tableNameHistoryStockKbar    string = "history_stock_kbar"
-	tableNameHistoryStockTick    string = "history_stock_tick"
+	tableNameCalendar        string = "basic_calendar"
+	tableNameStock           string = "basic_stock"
+	tableNameFuture          string = "basic_future"
+	tableNameOption          string = "basic_option"
+	tableNameTarget          string = "basic_targets"
+	tableNameCorporateAction string = "basic_corporate_action"
+
+	tableNameHistoryStockAnalyze  string = "history_stock_analyze"
+	tableNameHistoryStockClose    string = "history_stock_close"
+	tableNameHistoryStockKbar     string = "history_stock_kbar"
+	tableNameHistoryStockTick     string = "history_stock_tick"
+	tableNameHistoryFutureKbar    string = "history_future_kbar"
+	tableNameHistoryFutureKbarDay string = "history_future_kbar_day"
+
+	tableNameAnalyzePatternHit string = "analyze_pattern_hit"
 
 	tableNameTradeStockOrder    string = "trade_stock_order"
 	tableNameTradeStockBalance  string = "trade_stock_balance"
 	tableNameTradeFutureOrder   string = "trade_future_order"
 	tableNameFutureTradeBalance string = "trade_future_balance"
+	tableNameTradeOptionOrder   string = "trade_option_order"
+	tableNameOptionTradeBalance string = "trade_option_balance"
+	tableNameTradeFutureRoll    string = "trade_future_roll"
 
 	tableNameAccountBalance    string = "account_balance"
 	tableNameAccountSettlement string = "account_settlement"
@@ -31,4 +39,25 @@ const (
 	tableNameSystemAccount   string = "system_account"
 	tableNameSystemPushToken string = "system_push_token"
 	tableNameSystemJWT       string = "system_jwt"
+
+	tableNameSystemRole           string = "system_role"
+	tableNameSystemRolePermission string = "system_role_permission"
+	tableNameSystemPasswordReset  string = "system_password_reset"
+	tableNameSystemEmailVerify    string = "system_email_verification"
+	tableNameSystemSession        string = "system_session"
+	tableNameSystemTOTP           string = "system_totp"
+	tableNameSystemTOTPBackupCode string = "system_totp_backup_code"
+	tableNameSystemSetting        string = "system_setting"
+	tableNameSystemAPIKey         string = "system_api_key"
+
+	tableNameSystemNotifyAddress    string = "system_notify_address"
+	tableNameSystemNotifyPreference string = "system_notify_preference"
+
+	tableNameAlertRule    string = "alert_rule"
+	tableNameAlertHistory string = "alert_history"
+
+	tableNameReportDaily string = "report_daily"
+
+	tableNameWatchlist     string = "watchlist"
+	tableNameWatchlistItem string = "watchlist_item"
 )
@@ -364,3 +364,300 @@ func (r *history) QueryAllQuaterMAByStockNum(ctx context.Context, stockNum strin
 	}
 	return result, nil
 }
+
+// QueryAllStockDayKbarByDateRange aggregates minute kbars of all stocks into day kbars in [start, end), sorted by time.
+func (r *history) QueryAllStockDayKbarByDateRange(ctx context.Context, start, end time.Time) (map[string][]*entity.StockHistoryKbar, error) {
+	sql, args, err := r.Builder.
+		Select(
+			"stock_num, date_trunc('day', kbar_time) AS day",
+			"(array_agg(open ORDER BY kbar_time ASC))[1]",
+			"max(high), min(low)",
+			"(array_agg(close ORDER BY kbar_time DESC))[1]",
+			"sum(volume)",
+		).
+		From(tableNameHistoryStockKbar).
+		Where(squirrel.GtOrEq{"kbar_time": start}).
+		Where(squirrel.Lt{"kbar_time": end}).
+		GroupBy("stock_num", "day").
+		OrderBy("day ASC").ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.Pool().Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make(map[string][]*entity.StockHistoryKbar)
+	for rows.Next() {
+		e := entity.StockHistoryKbar{}
+		if err := rows.Scan(&e.StockNum, &e.KbarTime, &e.Open, &e.High, &e.Low, &e.Close, &e.Volume); err != nil {
+			return nil, err
+		}
+		result[e.StockNum] = append(result[e.StockNum], &e)
+	}
+	return result, nil
+}
+
+// QueryAllStockCloseByDateRange returns closes of all stocks in [start, end), sorted by date.
+func (r *history) QueryAllStockCloseByDateRange(ctx context.Context, start, end time.Time) (map[string][]*entity.StockHistoryClose, error) {
+	sql, args, err := r.Builder.
+		Select("date, stock_num, close").
+		From(tableNameHistoryStockClose).
+		Where(squirrel.GtOrEq{"date": start}).
+		Where(squirrel.Lt{"date": end}).
+		OrderBy("date ASC").ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.Pool().Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make(map[string][]*entity.StockHistoryClose)
+	for rows.Next() {
+		e := entity.StockHistoryClose{}
+		if err := rows.Scan(&e.Date, &e.StockNum, &e.Close); err != nil {
+			return nil, err
+		}
+		result[e.StockNum] = append(result[e.StockNum], &e)
+	}
+	return result, nil
+}
+
+// QueryStockCloseByDateRange returns closes of the stock in [start, end), sorted by date.
+func (r *history) QueryStockCloseByDateRange(ctx context.Context, stockNum string, start, end time.Time) ([]*entity.StockHistoryClose, error) {
+	sql, args, err := r.Builder.
+		Select("date, stock_num, close").
+		From(tableNameHistoryStockClose).
+		Where(squirrel.Eq{"stock_num": stockNum}).
+		Where(squirrel.GtOrEq{"date": start}).
+		Where(squirrel.Lt{"date": end}).
+		OrderBy("date ASC").ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.Pool().Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*entity.StockHistoryClose
+	for rows.Next() {
+		e := entity.StockHistoryClose{}
+		if err := rows.Scan(&e.Date, &e.StockNum, &e.Close); err != nil {
+			return nil, err
+		}
+		result = append(result, &e)
+	}
+	return result, nil
+}
+
+// DeleteQuaterMAByStockNumArr removes MA of the stocks, they are calculated again on the next history fetch.
+func (r *history) DeleteQuaterMAByStockNumArr(ctx context.Context, stockNumArr []string) error {
+	tx, err := r.BeginTransaction()
+	if err != nil {
+		return err
+	}
+	defer r.EndTransaction(tx, err)
+	var sql string
+	var args []interface{}
+
+	builder := r.Builder.Delete(tableNameHistoryStockAnalyze).Where(squirrel.Eq{"stock_num": stockNumArr})
+	if sql, args, err = builder.ToSql(); err != nil {
+		return err
+	} else if _, err = tx.Exec(ctx, sql, args...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// InsertOrUpdateCorporateActionArr -.
+func (r *history) InsertOrUpdateCorporateActionArr(ctx context.Context, t []*entity.CorporateAction) error {
+	split := [][]*entity.CorporateAction{}
+	for start := 0; start < len(t); start += batchSize {
+		end := start + batchSize
+		if end > len(t) {
+			end = len(t)
+		}
+		split = append(split, t[start:end])
+	}
+
+	tx, err := r.BeginTransaction()
+	if err != nil {
+		return err
+	}
+	defer r.EndTransaction(tx, err)
+	var sql string
+	var args []interface{}
+
+	for _, s := range split {
+		builder := r.Builder.Insert(tableNameCorporateAction).Columns("stock_num, ex_date, action_type, cash, ratio")
+		for _, v := range s {
+			builder = builder.Values(v.StockNum, v.ExDate, v.Type, v.Cash, v.Ratio)
+		}
+		builder = builder.Suffix(`ON CONFLICT ("stock_num", "ex_date", "action_type") DO UPDATE SET "cash" = EXCLUDED."cash", "ratio" = EXCLUDED."ratio"`)
+		if sql, args, err = builder.ToSql(); err != nil {
+			return err
+		} else if _, err = tx.Exec(ctx, sql, args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// QueryAllCorporateAction returns actions of all stocks, sorted by ex date.
+func (r *history) QueryAllCorporateAction(ctx context.Context) ([]*entity.CorporateAction, error) {
+	sql, args, err := r.Builder.
+		Select("stock_num, ex_date, action_type, cash, ratio").
+		From(tableNameCorporateAction).
+		OrderBy("ex_date ASC").ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.Pool().Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*entity.CorporateAction
+	for rows.Next() {
+		e := entity.CorporateAction{}
+		if err := rows.Scan(&e.StockNum, &e.ExDate, &e.Type, &e.Cash, &e.Ratio); err != nil {
+			return nil, err
+		}
+		result = append(result, &e)
+	}
+	return result, nil
+}
+
+// InsertFutureHistoryKbarArr inserts kbars of one trade day, night session kbars belong to the next trade day.
+func (r *history) InsertFutureHistoryKbarArr(ctx context.Context, code string, tradeDay time.Time, t []*entity.FutureHistoryKbar) error {
+	var split [][]*entity.FutureHistoryKbar
+	if len(t) > batchSize {
+		count := len(t)/batchSize + 1
+		for i := 0; i < count; i++ {
+			start := i * batchSize
+			end := (i + 1) * batchSize
+			if end > len(t) {
+				end = len(t)
+			}
+			if start != end {
+				split = append(split, t[start:end])
+			}
+		}
+	} else {
+		split = append(split, t)
+	}
+
+	tx, err := r.BeginTransaction()
+	if err != nil {
+		return err
+	}
+	defer r.EndTransaction(tx, err)
+	var sql string
+	var args []interface{}
+
+	for _, s := range split {
+		if len(s) == 0 {
+			continue
+		}
+		builder := r.Builder.Insert(tableNameHistoryFutureKbar).Columns("code, trade_day, kbar_time, open, high, low, close, volume")
+		for _, v := range s {
+			builder = builder.Values(v.Code, tradeDay, v.KbarTime, v.Open, v.High, v.Low, v.Close, v.Volume)
+		}
+		builder = builder.Suffix(`ON CONFLICT ("code", "kbar_time") DO UPDATE SET "open" = EXCLUDED."open", "high" = EXCLUDED."high", "low" = EXCLUDED."low", "close" = EXCLUDED."close", "volume" = EXCLUDED."volume"`)
+
+		if sql, args, err = builder.ToSql(); err != nil {
+			return err
+		} else if _, err = tx.Exec(ctx, sql, args...); err != nil {
+			return err
+		}
+	}
+
+	// the day is recorded even without kbars, so empty days are not fetched again
+	builder := r.Builder.Insert(tableNameHistoryFutureKbarDay).Columns("code, trade_day").
+		Values(code, tradeDay).
+		Suffix(`ON CONFLICT ("code", "trade_day") DO NOTHING`)
+	if sql, args, err = builder.ToSql(); err != nil {
+		return err
+	} else if _, err = tx.Exec(ctx, sql, args...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// QueryFutureHistoryKbarByTradeDay returns false if the trade day is not stored, stored days may have no kbars.
+func (r *history) QueryFutureHistoryKbarByTradeDay(ctx context.Context, code string, tradeDay time.Time) ([]*entity.FutureHistoryKbar, bool, error) {
+	sql, args, err := r.Builder.
+		Select("count(*)").
+		From(tableNameHistoryFutureKbarDay).
+		Where(squirrel.Eq{"code": code}).
+		Where(squirrel.Eq{"trade_day": tradeDay}).ToSql()
+	if err != nil {
+		return nil, false, err
+	}
+	var count int64
+	if err = r.Pool().QueryRow(ctx, sql, args...).Scan(&count); err != nil {
+		return nil, false, err
+	}
+	if count == 0 {
+		return nil, false, nil
+	}
+
+	sql, args, err = r.Builder.
+		Select("code, kbar_time, open, high, low, close, volume").
+		From(tableNameHistoryFutureKbar).
+		Where(squirrel.Eq{"code": code}).
+		Where(squirrel.Eq{"trade_day": tradeDay}).
+		OrderBy("kbar_time ASC").ToSql()
+	if err != nil {
+		return nil, false, err
+	}
+
+	rows, err := r.Pool().Query(ctx, sql, args...)
+	if err != nil {
+		return nil, false, err
+	}
+	defer rows.Close()
+
+	result := []*entity.FutureHistoryKbar{}
+	for rows.Next() {
+		e := entity.FutureHistoryKbar{}
+		if err := rows.Scan(&e.Code, &e.KbarTime, &e.Open, &e.High, &e.Low, &e.Close, &e.Volume); err != nil {
+			return nil, false, err
+		}
+		result = append(result, &e)
+	}
+	return result, true, nil
+}
+
+// DeleteFutureHistoryKbarByTradeDay -.
+func (r *history) DeleteFutureHistoryKbarByTradeDay(ctx context.Context, code string, tradeDay time.Time) error {
+	tx, err := r.BeginTransaction()
+	if err != nil {
+		return err
+	}
+	defer r.EndTransaction(tx, err)
+	var sql string
+	var args []interface{}
+
+	builder := r.Builder.Delete(tableNameHistoryFutureKbar).
+		Where(squirrel.Eq{"code": code}).
+		Where(squirrel.Eq{"trade_day": tradeDay})
+	if sql, args, err = builder.ToSql(); err != nil {
+		return err
+	} else if _, err = tx.Exec(ctx, sql, args...); err != nil {
+		return err
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package repo
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
@@ -15,6 +16,7 @@ type BasicRepo interface {
 	InsertOrUpdatetCalendarDateArr(ctx context.Context, t []*entity.CalendarDate) error
 	InsertOrUpdatetFutureArr(ctx context.Context, t []*entity.Future) error
 	InsertOrUpdatetOptionArr(ctx context.Context, t []*entity.Option) error
+	QueryFutureArrByCategory(ctx context.Context, category string) ([]*entity.Future, error)
 }
 
 type HistoryRepo interface {
@@ -29,6 +31,27 @@ type HistoryRepo interface {
 	DeleteHistoryKbarByStockAndDate(ctx context.Context, stockNumArr []string, date time.Time) error
 	DeleteHistoryTickByStockAndDate(ctx context.Context, stockNumArr []string, date time.Time) error
 	DeleteHistoryCloseByStockAndDate(ctx context.Context, stockNumArr []string, date time.Time) error
+	QueryAllStockDayKbarByDateRange(ctx context.Context, start, end time.Time) (map[string][]*entity.StockHistoryKbar, error)
+	QueryAllStockCloseByDateRange(ctx context.Context, start, end time.Time) (map[string][]*entity.StockHistoryClose, error)
+	InsertFutureHistoryKbarArr(ctx context.Context, code string, tradeDay time.Time, t []*entity.FutureHistoryKbar) error
+	QueryFutureHistoryKbarByTradeDay(ctx context.Context, code string, tradeDay time.Time) ([]*entity.FutureHistoryKbar, bool, error)
+	DeleteFutureHistoryKbarByTradeDay(ctx context.Context, code string, tradeDay time.Time) error
+	QueryStockCloseByDateRange(ctx context.Context, stockNum string, start, end time.Time) ([]*entity.StockHistoryClose, error)
+	DeleteQuaterMAByStockNumArr(ctx context.Context, stockNumArr []string) error
+	InsertOrUpdateCorporateActionArr(ctx context.Context, t []*entity.CorporateAction) error
+	QueryAllCorporateAction(ctx context.Context) ([]*entity.CorporateAction, error)
+}
+
+type PartitionRepo interface {
+	QueryPartitionNameArr(ctx context.Context, table entity.HistoryTable) ([]string, error)
+	CreatePartition(ctx context.Context, p *entity.HistoryPartition) error
+	ArchivePartition(ctx context.Context, p *entity.HistoryPartition, w io.Writer) error
+	DropPartition(ctx context.Context, p *entity.HistoryPartition) error
+}
+
+type PatternRepo interface {
+	InsertOrUpdatePatternHitArr(ctx context.Context, t []*entity.PatternHit) error
+	QueryPatternHitByDate(ctx context.Context, date time.Time, patternName entity.PatternName, limit, offset uint64) (*entity.PatternHitList, error)
 }
 
 type RealTimeRepo interface {
@@ -40,12 +63,80 @@ type SystemRepo interface {
 	InsertUser(ctx context.Context, t *entity.NewUser) error
 	QueryAllUser(ctx context.Context) ([]*entity.User, error)
 	QueryUserByUsername(ctx context.Context, username string) (*entity.User, error)
-	InsertOrUpdatePushToken(ctx context.Context, token, username string, enabled bool) error
+	InsertOrUpdatePushToken(ctx context.Context, token, username string, enabled, orderPush bool) error
 	GetAllPushTokens(ctx context.Context) ([]string, error)
+	QueryPushTokensByUserID(ctx context.Context, userID int) ([]string, error)
+	DeletePushTokens(ctx context.Context, tokens []string) error
+	QueryOrderNotifyUserID(ctx context.Context, usernames []string) ([]int, error)
 	GetPushToken(ctx context.Context, token string) (*entity.PushToken, error)
 	DeleteAllPushTokens(ctx context.Context) error
-	GetLastJWT(ctx context.Context) (string, error)
 	InsertJWT(ctx context.Context, jwt string) error
+	QueryAllRole(ctx context.Context) ([]*entity.Role, error)
+	UpdateUserRole(ctx context.Context, username, role string) error
+	QueryUserByEmail(ctx context.Context, email string) (*entity.User, error)
+	QueryUserByID(ctx context.Context, id int) (*entity.User, error)
+	UpdateUserPassword(ctx context.Context, username, password string) error
+	UpdateUserTokenValidAfter(ctx context.Context, username string, t time.Time) error
+	InsertPasswordReset(ctx context.Context, t *entity.PasswordReset) error
+	QueryLastPasswordReset(ctx context.Context, userID int) (*entity.PasswordReset, error)
+	IncreasePasswordResetAttempts(ctx context.Context, id int) error
+	UsePasswordResetByUserID(ctx context.Context, userID int) error
+	InsertEmailVerification(ctx context.Context, t *entity.EmailVerification) error
+	QueryLastEmailVerification(ctx context.Context, userID int) (*entity.EmailVerification, error)
+	IncreaseEmailVerificationAttempts(ctx context.Context, id int) error
+	UseEmailVerificationByUserID(ctx context.Context, userID int) error
+	DeleteUnverifiedUserCreatedBefore(ctx context.Context, before time.Time) ([]string, error)
+	QueryValidJWTKey(ctx context.Context) ([]*entity.JWTKey, error)
+	RotateJWTKey(ctx context.Context, key string, retireAt time.Time) error
+	InsertSession(ctx context.Context, t *entity.Session) error
+	QuerySessionBySessionID(ctx context.Context, sessionID string) (*entity.Session, error)
+	QuerySessionByRefreshTokenHash(ctx context.Context, hash string) (*entity.Session, error)
+	QueryActiveSessionByUserID(ctx context.Context, userID int) ([]*entity.Session, error)
+	UpdateSessionRefreshToken(ctx context.Context, t *entity.Session) error
+	RevokeSession(ctx context.Context, sessionID string) error
+	RevokeSessionByUserID(ctx context.Context, userID int) ([]string, error)
+	ReplaceTOTP(ctx context.Context, t *entity.TOTP, backupCodeHashes []string) error
+	QueryTOTPByUserID(ctx context.Context, userID int) (*entity.TOTP, error)
+	QueryEnabledTOTPUserID(ctx context.Context) ([]int, error)
+	UpdateTOTP(ctx context.Context, userID int, enabled bool, lastUsedStep int64) error
+	DeleteTOTP(ctx context.Context, userID int) error
+	UseTOTPBackupCode(ctx context.Context, userID int, codeHash string) (bool, error)
+	CountUnusedTOTPBackupCode(ctx context.Context, userID int) (int, error)
+	UpdateUserAuthTrade(ctx context.Context, username string, authTrade bool) error
+	QuerySetting(ctx context.Context, key string) (string, error)
+	UpsertSetting(ctx context.Context, key, value string) error
+	InsertAPIKey(ctx context.Context, t *entity.APIKey) error
+	QueryAPIKeyByKeyID(ctx context.Context, keyID string) (*entity.APIKey, error)
+	QueryAPIKeyByUserID(ctx context.Context, userID int) ([]*entity.APIKey, error)
+	RevokeAPIKey(ctx context.Context, keyID string) error
+	UpdateAPIKeyLastUsed(ctx context.Context, keyID string, lastUsed time.Time) error
+	QueryNotifyPreference(ctx context.Context, userID int) (*entity.NotifyPreference, error)
+	ReplaceNotifyPreference(ctx context.Context, userID int, t *entity.NotifyPreference) error
+}
+
+type AlertRepo interface {
+	InsertAlertRule(ctx context.Context, t *entity.AlertRule) error
+	UpdateAlertRule(ctx context.Context, t *entity.AlertRule) error
+	DeleteAlertRule(ctx context.Context, id int) error
+	QueryAlertRuleByUserID(ctx context.Context, userID int) ([]*entity.AlertRule, error)
+	QueryEnabledAlertRule(ctx context.Context) ([]*entity.AlertRule, error)
+	InsertAlertHistory(ctx context.Context, t *entity.AlertHistory) error
+	QueryAlertHistoryByUserID(ctx context.Context, userID int, limit, offset uint64) ([]*entity.AlertHistory, error)
+}
+
+type ReportRepo interface {
+	InsertOrUpdateDailyReport(ctx context.Context, t *entity.DailyReport) error
+	QueryDailyReportByDate(ctx context.Context, date time.Time) (*entity.DailyReport, error)
+}
+
+type WatchlistRepo interface {
+	InsertWatchlist(ctx context.Context, t *entity.Watchlist) error
+	UpdateWatchlist(ctx context.Context, t *entity.Watchlist) error
+	DeleteWatchlist(ctx context.Context, id int) error
+	QueryWatchlistByUserID(ctx context.Context, userID int) ([]*entity.Watchlist, error)
+	InsertOrUpdateWatchlistItem(ctx context.Context, t []*entity.WatchlistItem) error
+	DeleteWatchlistItem(ctx context.Context, watchlistID int, codes []string) error
+	UpdateWatchlistItemSort(ctx context.Context, watchlistID int, codes []string) error
 }
 
 type TargetRepo interface {
@@ -55,20 +146,30 @@ type TargetRepo interface {
 
 type TradeRepo interface {
 	QueryAllStockTradeBalance(ctx context.Context) ([]*entity.StockTradeBalance, error)
+	QueryStockTradeBalanceByDate(ctx context.Context, date time.Time) (*entity.StockTradeBalance, error)
 	InsertOrUpdateStockTradeBalance(ctx context.Context, t *entity.StockTradeBalance) error
 	QueryAllFutureTradeBalance(ctx context.Context) ([]*entity.FutureTradeBalance, error)
+	QueryFutureTradeBalanceByDate(ctx context.Context, date time.Time) (*entity.FutureTradeBalance, error)
 	InsertOrUpdateFutureTradeBalance(ctx context.Context, t *entity.FutureTradeBalance) error
+	QueryAllOptionTradeBalance(ctx context.Context) ([]*entity.OptionTradeBalance, error)
+	QueryOptionTradeBalanceByDate(ctx context.Context, date time.Time) (*entity.OptionTradeBalance, error)
+	InsertOrUpdateOptionTradeBalance(ctx context.Context, t *entity.OptionTradeBalance) error
 	InsertOrUpdateOrderByOrderID(ctx context.Context, t *entity.StockOrder) error
 	QueryAllStockOrder(ctx context.Context) ([]*entity.StockOrder, error)
 	QueryAllStockOrderByDate(ctx context.Context, timeTange []time.Time) ([]*entity.StockOrder, error)
 	InsertOrUpdateFutureOrderByOrderID(ctx context.Context, t *entity.FutureOrder) error
 	QueryAllFutureOrder(ctx context.Context) ([]*entity.FutureOrder, error)
 	QueryAllFutureOrderByDate(ctx context.Context, timeTange []time.Time) ([]*entity.FutureOrder, error)
+	InsertOrUpdateOptionOrderByOrderID(ctx context.Context, t *entity.OptionOrder) error
+	QueryAllOptionOrder(ctx context.Context) ([]*entity.OptionOrder, error)
+	QueryAllOptionOrderByDate(ctx context.Context, timeTange []time.Time) ([]*entity.OptionOrder, error)
 	QueryLastAccountBalance(ctx context.Context) (*entity.AccountBalance, error)
+	QueryAccountBalanceByDate(ctx context.Context, date time.Time) (*entity.AccountBalance, error)
 	InsertOrUpdateAccountBalance(ctx context.Context, t *entity.AccountBalance) error
 	InsertOrUpdateAccountSettlement(ctx context.Context, t *entity.Settlement) error
 	QueryInventoryUUIDStockByDate(ctx context.Context, date time.Time) (map[string]string, error)
 	InsertOrUpdateInventoryStock(ctx context.Context, t []*entity.InventoryStock) error
 	ClearInventoryStockByUUID(ctx context.Context, uuid string) error
 	QueryInventoryStockByDate(ctx context.Context, date time.Time) ([]*entity.InventoryStock, error)
+	InsertFutureRoll(ctx context.Context, t *entity.FutureRoll) (bool, error)
 }
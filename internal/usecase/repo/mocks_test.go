@@ -11,6 +11,7 @@ package repo
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
 	time "time"
 
@@ -98,6 +99,21 @@ func (mr *MockBasicRepoMockRecorder) InsertOrUpdatetStockArr(ctx, t any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertOrUpdatetStockArr", reflect.TypeOf((*MockBasicRepo)(nil).InsertOrUpdatetStockArr), ctx, t)
 }
 
+// QueryFutureArrByCategory mocks base method.
+func (m *MockBasicRepo) QueryFutureArrByCategory(ctx context.Context, category string) ([]*entity.Future, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryFutureArrByCategory", ctx, category)
+	ret0, _ := ret[0].([]*entity.Future)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryFutureArrByCategory indicates an expected call of QueryFutureArrByCategory.
+func (mr *MockBasicRepoMockRecorder) QueryFutureArrByCategory(ctx, category any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryFutureArrByCategory", reflect.TypeOf((*MockBasicRepo)(nil).QueryFutureArrByCategory), ctx, category)
+}
+
 // UpdateAllStockDayTradeToNo mocks base method.
 func (m *MockBasicRepo) UpdateAllStockDayTradeToNo(ctx context.Context) error {
 	m.ctrl.T.Helper()
@@ -136,6 +152,20 @@ func (m *MockHistoryRepo) EXPECT() *MockHistoryRepoMockRecorder {
 	return m.recorder
 }
 
+// DeleteFutureHistoryKbarByTradeDay mocks base method.
+func (m *MockHistoryRepo) DeleteFutureHistoryKbarByTradeDay(ctx context.Context, code string, tradeDay time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFutureHistoryKbarByTradeDay", ctx, code, tradeDay)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFutureHistoryKbarByTradeDay indicates an expected call of DeleteFutureHistoryKbarByTradeDay.
+func (mr *MockHistoryRepoMockRecorder) DeleteFutureHistoryKbarByTradeDay(ctx, code, tradeDay any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFutureHistoryKbarByTradeDay", reflect.TypeOf((*MockHistoryRepo)(nil).DeleteFutureHistoryKbarByTradeDay), ctx, code, tradeDay)
+}
+
 // DeleteHistoryCloseByStockAndDate mocks base method.
 func (m *MockHistoryRepo) DeleteHistoryCloseByStockAndDate(ctx context.Context, stockNumArr []string, date time.Time) error {
 	m.ctrl.T.Helper()
@@ -178,6 +208,34 @@ func (mr *MockHistoryRepoMockRecorder) DeleteHistoryTickByStockAndDate(ctx, stoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteHistoryTickByStockAndDate", reflect.TypeOf((*MockHistoryRepo)(nil).DeleteHistoryTickByStockAndDate), ctx, stockNumArr, date)
 }
 
+// DeleteQuaterMAByStockNumArr mocks base method.
+func (m *MockHistoryRepo) DeleteQuaterMAByStockNumArr(ctx context.Context, stockNumArr []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteQuaterMAByStockNumArr", ctx, stockNumArr)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteQuaterMAByStockNumArr indicates an expected call of DeleteQuaterMAByStockNumArr.
+func (mr *MockHistoryRepoMockRecorder) DeleteQuaterMAByStockNumArr(ctx, stockNumArr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteQuaterMAByStockNumArr", reflect.TypeOf((*MockHistoryRepo)(nil).DeleteQuaterMAByStockNumArr), ctx, stockNumArr)
+}
+
+// InsertFutureHistoryKbarArr mocks base method.
+func (m *MockHistoryRepo) InsertFutureHistoryKbarArr(ctx context.Context, code string, tradeDay time.Time, t []*entity.FutureHistoryKbar) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertFutureHistoryKbarArr", ctx, code, tradeDay, t)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertFutureHistoryKbarArr indicates an expected call of InsertFutureHistoryKbarArr.
+func (mr *MockHistoryRepoMockRecorder) InsertFutureHistoryKbarArr(ctx, code, tradeDay, t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertFutureHistoryKbarArr", reflect.TypeOf((*MockHistoryRepo)(nil).InsertFutureHistoryKbarArr), ctx, code, tradeDay, t)
+}
+
 // InsertHistoryCloseArr mocks base method.
 func (m *MockHistoryRepo) InsertHistoryCloseArr(ctx context.Context, t []*entity.StockHistoryClose) error {
 	m.ctrl.T.Helper()
@@ -192,313 +250,1460 @@ func (mr *MockHistoryRepoMockRecorder) InsertHistoryCloseArr(ctx, t any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertHistoryCloseArr", reflect.TypeOf((*MockHistoryRepo)(nil).InsertHistoryCloseArr), ctx, t)
 }
 
-// InsertHistoryKbarArr mocks base method.
-func (m *MockHistoryRepo) InsertHistoryKbarArr(ctx context.Context, t []*entity.StockHistoryKbar) error {
+// InsertHistoryKbarArr mocks base method.
+func (m *MockHistoryRepo) InsertHistoryKbarArr(ctx context.Context, t []*entity.StockHistoryKbar) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertHistoryKbarArr", ctx, t)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertHistoryKbarArr indicates an expected call of InsertHistoryKbarArr.
+func (mr *MockHistoryRepoMockRecorder) InsertHistoryKbarArr(ctx, t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertHistoryKbarArr", reflect.TypeOf((*MockHistoryRepo)(nil).InsertHistoryKbarArr), ctx, t)
+}
+
+// InsertHistoryTickArr mocks base method.
+func (m *MockHistoryRepo) InsertHistoryTickArr(ctx context.Context, t []*entity.StockHistoryTick) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertHistoryTickArr", ctx, t)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertHistoryTickArr indicates an expected call of InsertHistoryTickArr.
+func (mr *MockHistoryRepoMockRecorder) InsertHistoryTickArr(ctx, t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertHistoryTickArr", reflect.TypeOf((*MockHistoryRepo)(nil).InsertHistoryTickArr), ctx, t)
+}
+
+// InsertOrUpdateCorporateActionArr mocks base method.
+func (m *MockHistoryRepo) InsertOrUpdateCorporateActionArr(ctx context.Context, t []*entity.CorporateAction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertOrUpdateCorporateActionArr", ctx, t)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertOrUpdateCorporateActionArr indicates an expected call of InsertOrUpdateCorporateActionArr.
+func (mr *MockHistoryRepoMockRecorder) InsertOrUpdateCorporateActionArr(ctx, t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertOrUpdateCorporateActionArr", reflect.TypeOf((*MockHistoryRepo)(nil).InsertOrUpdateCorporateActionArr), ctx, t)
+}
+
+// InsertQuaterMA mocks base method.
+func (m *MockHistoryRepo) InsertQuaterMA(ctx context.Context, t *entity.StockHistoryAnalyze) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertQuaterMA", ctx, t)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertQuaterMA indicates an expected call of InsertQuaterMA.
+func (mr *MockHistoryRepoMockRecorder) InsertQuaterMA(ctx, t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertQuaterMA", reflect.TypeOf((*MockHistoryRepo)(nil).InsertQuaterMA), ctx, t)
+}
+
+// QueryAllCorporateAction mocks base method.
+func (m *MockHistoryRepo) QueryAllCorporateAction(ctx context.Context) ([]*entity.CorporateAction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryAllCorporateAction", ctx)
+	ret0, _ := ret[0].([]*entity.CorporateAction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryAllCorporateAction indicates an expected call of QueryAllCorporateAction.
+func (mr *MockHistoryRepoMockRecorder) QueryAllCorporateAction(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryAllCorporateAction", reflect.TypeOf((*MockHistoryRepo)(nil).QueryAllCorporateAction), ctx)
+}
+
+// QueryAllQuaterMAByStockNum mocks base method.
+func (m *MockHistoryRepo) QueryAllQuaterMAByStockNum(ctx context.Context, stockNum string) (map[time.Time]*entity.StockHistoryAnalyze, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryAllQuaterMAByStockNum", ctx, stockNum)
+	ret0, _ := ret[0].(map[time.Time]*entity.StockHistoryAnalyze)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryAllQuaterMAByStockNum indicates an expected call of QueryAllQuaterMAByStockNum.
+func (mr *MockHistoryRepoMockRecorder) QueryAllQuaterMAByStockNum(ctx, stockNum any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryAllQuaterMAByStockNum", reflect.TypeOf((*MockHistoryRepo)(nil).QueryAllQuaterMAByStockNum), ctx, stockNum)
+}
+
+// QueryAllStockCloseByDateRange mocks base method.
+func (m *MockHistoryRepo) QueryAllStockCloseByDateRange(ctx context.Context, start, end time.Time) (map[string][]*entity.StockHistoryClose, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryAllStockCloseByDateRange", ctx, start, end)
+	ret0, _ := ret[0].(map[string][]*entity.StockHistoryClose)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryAllStockCloseByDateRange indicates an expected call of QueryAllStockCloseByDateRange.
+func (mr *MockHistoryRepoMockRecorder) QueryAllStockCloseByDateRange(ctx, start, end any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryAllStockCloseByDateRange", reflect.TypeOf((*MockHistoryRepo)(nil).QueryAllStockCloseByDateRange), ctx, start, end)
+}
+
+// QueryAllStockDayKbarByDateRange mocks base method.
+func (m *MockHistoryRepo) QueryAllStockDayKbarByDateRange(ctx context.Context, start, end time.Time) (map[string][]*entity.StockHistoryKbar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryAllStockDayKbarByDateRange", ctx, start, end)
+	ret0, _ := ret[0].(map[string][]*entity.StockHistoryKbar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryAllStockDayKbarByDateRange indicates an expected call of QueryAllStockDayKbarByDateRange.
+func (mr *MockHistoryRepoMockRecorder) QueryAllStockDayKbarByDateRange(ctx, start, end any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryAllStockDayKbarByDateRange", reflect.TypeOf((*MockHistoryRepo)(nil).QueryAllStockDayKbarByDateRange), ctx, start, end)
+}
+
+// QueryFutureHistoryKbarByTradeDay mocks base method.
+func (m *MockHistoryRepo) QueryFutureHistoryKbarByTradeDay(ctx context.Context, code string, tradeDay time.Time) ([]*entity.FutureHistoryKbar, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryFutureHistoryKbarByTradeDay", ctx, code, tradeDay)
+	ret0, _ := ret[0].([]*entity.FutureHistoryKbar)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// QueryFutureHistoryKbarByTradeDay indicates an expected call of QueryFutureHistoryKbarByTradeDay.
+func (mr *MockHistoryRepoMockRecorder) QueryFutureHistoryKbarByTradeDay(ctx, code, tradeDay any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryFutureHistoryKbarByTradeDay", reflect.TypeOf((*MockHistoryRepo)(nil).QueryFutureHistoryKbarByTradeDay), ctx, code, tradeDay)
+}
+
+// QueryMultiStockKbarArrByDate mocks base method.
+func (m *MockHistoryRepo) QueryMultiStockKbarArrByDate(ctx context.Context, stockNumArr []string, date time.Time) (map[string][]*entity.StockHistoryKbar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryMultiStockKbarArrByDate", ctx, stockNumArr, date)
+	ret0, _ := ret[0].(map[string][]*entity.StockHistoryKbar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryMultiStockKbarArrByDate indicates an expected call of QueryMultiStockKbarArrByDate.
+func (mr *MockHistoryRepoMockRecorder) QueryMultiStockKbarArrByDate(ctx, stockNumArr, date any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryMultiStockKbarArrByDate", reflect.TypeOf((*MockHistoryRepo)(nil).QueryMultiStockKbarArrByDate), ctx, stockNumArr, date)
+}
+
+// QueryMultiStockTickArrByDate mocks base method.
+func (m *MockHistoryRepo) QueryMultiStockTickArrByDate(ctx context.Context, stockNumArr []string, date time.Time) (map[string][]*entity.StockHistoryTick, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryMultiStockTickArrByDate", ctx, stockNumArr, date)
+	ret0, _ := ret[0].(map[string][]*entity.StockHistoryTick)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryMultiStockTickArrByDate indicates an expected call of QueryMultiStockTickArrByDate.
+func (mr *MockHistoryRepoMockRecorder) QueryMultiStockTickArrByDate(ctx, stockNumArr, date any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryMultiStockTickArrByDate", reflect.TypeOf((*MockHistoryRepo)(nil).QueryMultiStockTickArrByDate), ctx, stockNumArr, date)
+}
+
+// QueryMutltiStockCloseByDate mocks base method.
+func (m *MockHistoryRepo) QueryMutltiStockCloseByDate(ctx context.Context, stockNumArr []string, date time.Time) (map[string]*entity.StockHistoryClose, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryMutltiStockCloseByDate", ctx, stockNumArr, date)
+	ret0, _ := ret[0].(map[string]*entity.StockHistoryClose)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryMutltiStockCloseByDate indicates an expected call of QueryMutltiStockCloseByDate.
+func (mr *MockHistoryRepoMockRecorder) QueryMutltiStockCloseByDate(ctx, stockNumArr, date any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryMutltiStockCloseByDate", reflect.TypeOf((*MockHistoryRepo)(nil).QueryMutltiStockCloseByDate), ctx, stockNumArr, date)
+}
+
+// QueryStockCloseByDateRange mocks base method.
+func (m *MockHistoryRepo) QueryStockCloseByDateRange(ctx context.Context, stockNum string, start, end time.Time) ([]*entity.StockHistoryClose, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryStockCloseByDateRange", ctx, stockNum, start, end)
+	ret0, _ := ret[0].([]*entity.StockHistoryClose)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryStockCloseByDateRange indicates an expected call of QueryStockCloseByDateRange.
+func (mr *MockHistoryRepoMockRecorder) QueryStockCloseByDateRange(ctx, stockNum, start, end any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryStockCloseByDateRange", reflect.TypeOf((*MockHistoryRepo)(nil).QueryStockCloseByDateRange), ctx, stockNum, start, end)
+}
+
+// MockPartitionRepo is a mock of PartitionRepo interface.
+type MockPartitionRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockPartitionRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockPartitionRepoMockRecorder is the mock recorder for MockPartitionRepo.
+type MockPartitionRepoMockRecorder struct {
+	mock *MockPartitionRepo
+}
+
+// NewMockPartitionRepo creates a new mock instance.
+func NewMockPartitionRepo(ctrl *gomock.Controller) *MockPartitionRepo {
+	mock := &MockPartitionRepo{ctrl: ctrl}
+	mock.recorder = &MockPartitionRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPartitionRepo) EXPECT() *MockPartitionRepoMockRecorder {
+	return m.recorder
+}
+
+// ArchivePartition mocks base method.
+func (m *MockPartitionRepo) ArchivePartition(ctx context.Context, p *entity.HistoryPartition, w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchivePartition", ctx, p, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ArchivePartition indicates an expected call of ArchivePartition.
+func (mr *MockPartitionRepoMockRecorder) ArchivePartition(ctx, p, w any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchivePartition", reflect.TypeOf((*MockPartitionRepo)(nil).ArchivePartition), ctx, p, w)
+}
+
+// CreatePartition mocks base method.
+func (m *MockPartitionRepo) CreatePartition(ctx context.Context, p *entity.HistoryPartition) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePartition", ctx, p)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePartition indicates an expected call of CreatePartition.
+func (mr *MockPartitionRepoMockRecorder) CreatePartition(ctx, p any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePartition", reflect.TypeOf((*MockPartitionRepo)(nil).CreatePartition), ctx, p)
+}
+
+// DropPartition mocks base method.
+func (m *MockPartitionRepo) DropPartition(ctx context.Context, p *entity.HistoryPartition) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DropPartition", ctx, p)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DropPartition indicates an expected call of DropPartition.
+func (mr *MockPartitionRepoMockRecorder) DropPartition(ctx, p any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DropPartition", reflect.TypeOf((*MockPartitionRepo)(nil).DropPartition), ctx, p)
+}
+
+// QueryPartitionNameArr mocks base method.
+func (m *MockPartitionRepo) QueryPartitionNameArr(ctx context.Context, table entity.HistoryTable) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryPartitionNameArr", ctx, table)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryPartitionNameArr indicates an expected call of QueryPartitionNameArr.
+func (mr *MockPartitionRepoMockRecorder) QueryPartitionNameArr(ctx, table any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryPartitionNameArr", reflect.TypeOf((*MockPartitionRepo)(nil).QueryPartitionNameArr), ctx, table)
+}
+
+// MockPatternRepo is a mock of PatternRepo interface.
+type MockPatternRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockPatternRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockPatternRepoMockRecorder is the mock recorder for MockPatternRepo.
+type MockPatternRepoMockRecorder struct {
+	mock *MockPatternRepo
+}
+
+// NewMockPatternRepo creates a new mock instance.
+func NewMockPatternRepo(ctrl *gomock.Controller) *MockPatternRepo {
+	mock := &MockPatternRepo{ctrl: ctrl}
+	mock.recorder = &MockPatternRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPatternRepo) EXPECT() *MockPatternRepoMockRecorder {
+	return m.recorder
+}
+
+// InsertOrUpdatePatternHitArr mocks base method.
+func (m *MockPatternRepo) InsertOrUpdatePatternHitArr(ctx context.Context, t []*entity.PatternHit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertOrUpdatePatternHitArr", ctx, t)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertOrUpdatePatternHitArr indicates an expected call of InsertOrUpdatePatternHitArr.
+func (mr *MockPatternRepoMockRecorder) InsertOrUpdatePatternHitArr(ctx, t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertOrUpdatePatternHitArr", reflect.TypeOf((*MockPatternRepo)(nil).InsertOrUpdatePatternHitArr), ctx, t)
+}
+
+// QueryPatternHitByDate mocks base method.
+func (m *MockPatternRepo) QueryPatternHitByDate(ctx context.Context, date time.Time, patternName entity.PatternName, limit, offset uint64) (*entity.PatternHitList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryPatternHitByDate", ctx, date, patternName, limit, offset)
+	ret0, _ := ret[0].(*entity.PatternHitList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryPatternHitByDate indicates an expected call of QueryPatternHitByDate.
+func (mr *MockPatternRepoMockRecorder) QueryPatternHitByDate(ctx, date, patternName, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryPatternHitByDate", reflect.TypeOf((*MockPatternRepo)(nil).QueryPatternHitByDate), ctx, date, patternName, limit, offset)
+}
+
+// MockRealTimeRepo is a mock of RealTimeRepo interface.
+type MockRealTimeRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockRealTimeRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockRealTimeRepoMockRecorder is the mock recorder for MockRealTimeRepo.
+type MockRealTimeRepoMockRecorder struct {
+	mock *MockRealTimeRepo
+}
+
+// NewMockRealTimeRepo creates a new mock instance.
+func NewMockRealTimeRepo(ctrl *gomock.Controller) *MockRealTimeRepo {
+	mock := &MockRealTimeRepo{ctrl: ctrl}
+	mock.recorder = &MockRealTimeRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRealTimeRepo) EXPECT() *MockRealTimeRepoMockRecorder {
+	return m.recorder
+}
+
+// InsertEvent mocks base method.
+func (m *MockRealTimeRepo) InsertEvent(ctx context.Context, t *entity.SinopacEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertEvent", ctx, t)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertEvent indicates an expected call of InsertEvent.
+func (mr *MockRealTimeRepoMockRecorder) InsertEvent(ctx, t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertEvent", reflect.TypeOf((*MockRealTimeRepo)(nil).InsertEvent), ctx, t)
+}
+
+// MockSystemRepo is a mock of SystemRepo interface.
+type MockSystemRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockSystemRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockSystemRepoMockRecorder is the mock recorder for MockSystemRepo.
+type MockSystemRepoMockRecorder struct {
+	mock *MockSystemRepo
+}
+
+// NewMockSystemRepo creates a new mock instance.
+func NewMockSystemRepo(ctrl *gomock.Controller) *MockSystemRepo {
+	mock := &MockSystemRepo{ctrl: ctrl}
+	mock.recorder = &MockSystemRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSystemRepo) EXPECT() *MockSystemRepoMockRecorder {
+	return m.recorder
+}
+
+// CountUnusedTOTPBackupCode mocks base method.
+func (m *MockSystemRepo) CountUnusedTOTPBackupCode(ctx context.Context, userID int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUnusedTOTPBackupCode", ctx, userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUnusedTOTPBackupCode indicates an expected call of CountUnusedTOTPBackupCode.
+func (mr *MockSystemRepoMockRecorder) CountUnusedTOTPBackupCode(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUnusedTOTPBackupCode", reflect.TypeOf((*MockSystemRepo)(nil).CountUnusedTOTPBackupCode), ctx, userID)
+}
+
+// DeleteAllPushTokens mocks base method.
+func (m *MockSystemRepo) DeleteAllPushTokens(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAllPushTokens", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAllPushTokens indicates an expected call of DeleteAllPushTokens.
+func (mr *MockSystemRepoMockRecorder) DeleteAllPushTokens(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAllPushTokens", reflect.TypeOf((*MockSystemRepo)(nil).DeleteAllPushTokens), ctx)
+}
+
+// DeletePushTokens mocks base method.
+func (m *MockSystemRepo) DeletePushTokens(ctx context.Context, tokens []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePushTokens", ctx, tokens)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePushTokens indicates an expected call of DeletePushTokens.
+func (mr *MockSystemRepoMockRecorder) DeletePushTokens(ctx, tokens any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePushTokens", reflect.TypeOf((*MockSystemRepo)(nil).DeletePushTokens), ctx, tokens)
+}
+
+// DeleteTOTP mocks base method.
+func (m *MockSystemRepo) DeleteTOTP(ctx context.Context, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTOTP", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTOTP indicates an expected call of DeleteTOTP.
+func (mr *MockSystemRepoMockRecorder) DeleteTOTP(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTOTP", reflect.TypeOf((*MockSystemRepo)(nil).DeleteTOTP), ctx, userID)
+}
+
+// DeleteUnverifiedUserCreatedBefore mocks base method.
+func (m *MockSystemRepo) DeleteUnverifiedUserCreatedBefore(ctx context.Context, before time.Time) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUnverifiedUserCreatedBefore", ctx, before)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUnverifiedUserCreatedBefore indicates an expected call of DeleteUnverifiedUserCreatedBefore.
+func (mr *MockSystemRepoMockRecorder) DeleteUnverifiedUserCreatedBefore(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUnverifiedUserCreatedBefore", reflect.TypeOf((*MockSystemRepo)(nil).DeleteUnverifiedUserCreatedBefore), ctx, before)
+}
+
+// EmailVerification mocks base method.
+func (m *MockSystemRepo) EmailVerification(ctx context.Context, username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EmailVerification", ctx, username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EmailVerification indicates an expected call of EmailVerification.
+func (mr *MockSystemRepoMockRecorder) EmailVerification(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EmailVerification", reflect.TypeOf((*MockSystemRepo)(nil).EmailVerification), ctx, username)
+}
+
+// GetAllPushTokens mocks base method.
+func (m *MockSystemRepo) GetAllPushTokens(ctx context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllPushTokens", ctx)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllPushTokens indicates an expected call of GetAllPushTokens.
+func (mr *MockSystemRepoMockRecorder) GetAllPushTokens(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllPushTokens", reflect.TypeOf((*MockSystemRepo)(nil).GetAllPushTokens), ctx)
+}
+
+// GetPushToken mocks base method.
+func (m *MockSystemRepo) GetPushToken(ctx context.Context, token string) (*entity.PushToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPushToken", ctx, token)
+	ret0, _ := ret[0].(*entity.PushToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPushToken indicates an expected call of GetPushToken.
+func (mr *MockSystemRepoMockRecorder) GetPushToken(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPushToken", reflect.TypeOf((*MockSystemRepo)(nil).GetPushToken), ctx, token)
+}
+
+// IncreaseEmailVerificationAttempts mocks base method.
+func (m *MockSystemRepo) IncreaseEmailVerificationAttempts(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncreaseEmailVerificationAttempts", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncreaseEmailVerificationAttempts indicates an expected call of IncreaseEmailVerificationAttempts.
+func (mr *MockSystemRepoMockRecorder) IncreaseEmailVerificationAttempts(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncreaseEmailVerificationAttempts", reflect.TypeOf((*MockSystemRepo)(nil).IncreaseEmailVerificationAttempts), ctx, id)
+}
+
+// IncreasePasswordResetAttempts mocks base method.
+func (m *MockSystemRepo) IncreasePasswordResetAttempts(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncreasePasswordResetAttempts", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncreasePasswordResetAttempts indicates an expected call of IncreasePasswordResetAttempts.
+func (mr *MockSystemRepoMockRecorder) IncreasePasswordResetAttempts(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncreasePasswordResetAttempts", reflect.TypeOf((*MockSystemRepo)(nil).IncreasePasswordResetAttempts), ctx, id)
+}
+
+// InsertAPIKey mocks base method.
+func (m *MockSystemRepo) InsertAPIKey(ctx context.Context, t *entity.APIKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertAPIKey", ctx, t)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertAPIKey indicates an expected call of InsertAPIKey.
+func (mr *MockSystemRepoMockRecorder) InsertAPIKey(ctx, t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertAPIKey", reflect.TypeOf((*MockSystemRepo)(nil).InsertAPIKey), ctx, t)
+}
+
+// InsertEmailVerification mocks base method.
+func (m *MockSystemRepo) InsertEmailVerification(ctx context.Context, t *entity.EmailVerification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertEmailVerification", ctx, t)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertEmailVerification indicates an expected call of InsertEmailVerification.
+func (mr *MockSystemRepoMockRecorder) InsertEmailVerification(ctx, t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertEmailVerification", reflect.TypeOf((*MockSystemRepo)(nil).InsertEmailVerification), ctx, t)
+}
+
+// InsertJWT mocks base method.
+func (m *MockSystemRepo) InsertJWT(ctx context.Context, jwt string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertJWT", ctx, jwt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertJWT indicates an expected call of InsertJWT.
+func (mr *MockSystemRepoMockRecorder) InsertJWT(ctx, jwt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertJWT", reflect.TypeOf((*MockSystemRepo)(nil).InsertJWT), ctx, jwt)
+}
+
+// InsertOrUpdatePushToken mocks base method.
+func (m *MockSystemRepo) InsertOrUpdatePushToken(ctx context.Context, token, username string, enabled, orderPush bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertOrUpdatePushToken", ctx, token, username, enabled, orderPush)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertOrUpdatePushToken indicates an expected call of InsertOrUpdatePushToken.
+func (mr *MockSystemRepoMockRecorder) InsertOrUpdatePushToken(ctx, token, username, enabled, orderPush any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertOrUpdatePushToken", reflect.TypeOf((*MockSystemRepo)(nil).InsertOrUpdatePushToken), ctx, token, username, enabled, orderPush)
+}
+
+// InsertPasswordReset mocks base method.
+func (m *MockSystemRepo) InsertPasswordReset(ctx context.Context, t *entity.PasswordReset) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertPasswordReset", ctx, t)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertPasswordReset indicates an expected call of InsertPasswordReset.
+func (mr *MockSystemRepoMockRecorder) InsertPasswordReset(ctx, t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertPasswordReset", reflect.TypeOf((*MockSystemRepo)(nil).InsertPasswordReset), ctx, t)
+}
+
+// InsertSession mocks base method.
+func (m *MockSystemRepo) InsertSession(ctx context.Context, t *entity.Session) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertSession", ctx, t)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertSession indicates an expected call of InsertSession.
+func (mr *MockSystemRepoMockRecorder) InsertSession(ctx, t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertSession", reflect.TypeOf((*MockSystemRepo)(nil).InsertSession), ctx, t)
+}
+
+// InsertUser mocks base method.
+func (m *MockSystemRepo) InsertUser(ctx context.Context, t *entity.NewUser) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertUser", ctx, t)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertUser indicates an expected call of InsertUser.
+func (mr *MockSystemRepoMockRecorder) InsertUser(ctx, t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertUser", reflect.TypeOf((*MockSystemRepo)(nil).InsertUser), ctx, t)
+}
+
+// QueryAPIKeyByKeyID mocks base method.
+func (m *MockSystemRepo) QueryAPIKeyByKeyID(ctx context.Context, keyID string) (*entity.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryAPIKeyByKeyID", ctx, keyID)
+	ret0, _ := ret[0].(*entity.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryAPIKeyByKeyID indicates an expected call of QueryAPIKeyByKeyID.
+func (mr *MockSystemRepoMockRecorder) QueryAPIKeyByKeyID(ctx, keyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryAPIKeyByKeyID", reflect.TypeOf((*MockSystemRepo)(nil).QueryAPIKeyByKeyID), ctx, keyID)
+}
+
+// QueryAPIKeyByUserID mocks base method.
+func (m *MockSystemRepo) QueryAPIKeyByUserID(ctx context.Context, userID int) ([]*entity.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryAPIKeyByUserID", ctx, userID)
+	ret0, _ := ret[0].([]*entity.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryAPIKeyByUserID indicates an expected call of QueryAPIKeyByUserID.
+func (mr *MockSystemRepoMockRecorder) QueryAPIKeyByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryAPIKeyByUserID", reflect.TypeOf((*MockSystemRepo)(nil).QueryAPIKeyByUserID), ctx, userID)
+}
+
+// QueryActiveSessionByUserID mocks base method.
+func (m *MockSystemRepo) QueryActiveSessionByUserID(ctx context.Context, userID int) ([]*entity.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryActiveSessionByUserID", ctx, userID)
+	ret0, _ := ret[0].([]*entity.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryActiveSessionByUserID indicates an expected call of QueryActiveSessionByUserID.
+func (mr *MockSystemRepoMockRecorder) QueryActiveSessionByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryActiveSessionByUserID", reflect.TypeOf((*MockSystemRepo)(nil).QueryActiveSessionByUserID), ctx, userID)
+}
+
+// QueryAllRole mocks base method.
+func (m *MockSystemRepo) QueryAllRole(ctx context.Context) ([]*entity.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryAllRole", ctx)
+	ret0, _ := ret[0].([]*entity.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryAllRole indicates an expected call of QueryAllRole.
+func (mr *MockSystemRepoMockRecorder) QueryAllRole(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryAllRole", reflect.TypeOf((*MockSystemRepo)(nil).QueryAllRole), ctx)
+}
+
+// QueryAllUser mocks base method.
+func (m *MockSystemRepo) QueryAllUser(ctx context.Context) ([]*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryAllUser", ctx)
+	ret0, _ := ret[0].([]*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryAllUser indicates an expected call of QueryAllUser.
+func (mr *MockSystemRepoMockRecorder) QueryAllUser(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryAllUser", reflect.TypeOf((*MockSystemRepo)(nil).QueryAllUser), ctx)
+}
+
+// QueryEnabledTOTPUserID mocks base method.
+func (m *MockSystemRepo) QueryEnabledTOTPUserID(ctx context.Context) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryEnabledTOTPUserID", ctx)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryEnabledTOTPUserID indicates an expected call of QueryEnabledTOTPUserID.
+func (mr *MockSystemRepoMockRecorder) QueryEnabledTOTPUserID(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryEnabledTOTPUserID", reflect.TypeOf((*MockSystemRepo)(nil).QueryEnabledTOTPUserID), ctx)
+}
+
+// QueryLastEmailVerification mocks base method.
+func (m *MockSystemRepo) QueryLastEmailVerification(ctx context.Context, userID int) (*entity.EmailVerification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryLastEmailVerification", ctx, userID)
+	ret0, _ := ret[0].(*entity.EmailVerification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryLastEmailVerification indicates an expected call of QueryLastEmailVerification.
+func (mr *MockSystemRepoMockRecorder) QueryLastEmailVerification(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryLastEmailVerification", reflect.TypeOf((*MockSystemRepo)(nil).QueryLastEmailVerification), ctx, userID)
+}
+
+// QueryLastPasswordReset mocks base method.
+func (m *MockSystemRepo) QueryLastPasswordReset(ctx context.Context, userID int) (*entity.PasswordReset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryLastPasswordReset", ctx, userID)
+	ret0, _ := ret[0].(*entity.PasswordReset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryLastPasswordReset indicates an expected call of QueryLastPasswordReset.
+func (mr *MockSystemRepoMockRecorder) QueryLastPasswordReset(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryLastPasswordReset", reflect.TypeOf((*MockSystemRepo)(nil).QueryLastPasswordReset), ctx, userID)
+}
+
+// QueryNotifyPreference mocks base method.
+func (m *MockSystemRepo) QueryNotifyPreference(ctx context.Context, userID int) (*entity.NotifyPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryNotifyPreference", ctx, userID)
+	ret0, _ := ret[0].(*entity.NotifyPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryNotifyPreference indicates an expected call of QueryNotifyPreference.
+func (mr *MockSystemRepoMockRecorder) QueryNotifyPreference(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryNotifyPreference", reflect.TypeOf((*MockSystemRepo)(nil).QueryNotifyPreference), ctx, userID)
+}
+
+// QueryOrderNotifyUserID mocks base method.
+func (m *MockSystemRepo) QueryOrderNotifyUserID(ctx context.Context, usernames []string) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryOrderNotifyUserID", ctx, usernames)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryOrderNotifyUserID indicates an expected call of QueryOrderNotifyUserID.
+func (mr *MockSystemRepoMockRecorder) QueryOrderNotifyUserID(ctx, usernames any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryOrderNotifyUserID", reflect.TypeOf((*MockSystemRepo)(nil).QueryOrderNotifyUserID), ctx, usernames)
+}
+
+// QueryPushTokensByUserID mocks base method.
+func (m *MockSystemRepo) QueryPushTokensByUserID(ctx context.Context, userID int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryPushTokensByUserID", ctx, userID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryPushTokensByUserID indicates an expected call of QueryPushTokensByUserID.
+func (mr *MockSystemRepoMockRecorder) QueryPushTokensByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryPushTokensByUserID", reflect.TypeOf((*MockSystemRepo)(nil).QueryPushTokensByUserID), ctx, userID)
+}
+
+// QuerySessionByRefreshTokenHash mocks base method.
+func (m *MockSystemRepo) QuerySessionByRefreshTokenHash(ctx context.Context, hash string) (*entity.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QuerySessionByRefreshTokenHash", ctx, hash)
+	ret0, _ := ret[0].(*entity.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QuerySessionByRefreshTokenHash indicates an expected call of QuerySessionByRefreshTokenHash.
+func (mr *MockSystemRepoMockRecorder) QuerySessionByRefreshTokenHash(ctx, hash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QuerySessionByRefreshTokenHash", reflect.TypeOf((*MockSystemRepo)(nil).QuerySessionByRefreshTokenHash), ctx, hash)
+}
+
+// QuerySessionBySessionID mocks base method.
+func (m *MockSystemRepo) QuerySessionBySessionID(ctx context.Context, sessionID string) (*entity.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QuerySessionBySessionID", ctx, sessionID)
+	ret0, _ := ret[0].(*entity.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QuerySessionBySessionID indicates an expected call of QuerySessionBySessionID.
+func (mr *MockSystemRepoMockRecorder) QuerySessionBySessionID(ctx, sessionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QuerySessionBySessionID", reflect.TypeOf((*MockSystemRepo)(nil).QuerySessionBySessionID), ctx, sessionID)
+}
+
+// QuerySetting mocks base method.
+func (m *MockSystemRepo) QuerySetting(ctx context.Context, key string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QuerySetting", ctx, key)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QuerySetting indicates an expected call of QuerySetting.
+func (mr *MockSystemRepoMockRecorder) QuerySetting(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QuerySetting", reflect.TypeOf((*MockSystemRepo)(nil).QuerySetting), ctx, key)
+}
+
+// QueryTOTPByUserID mocks base method.
+func (m *MockSystemRepo) QueryTOTPByUserID(ctx context.Context, userID int) (*entity.TOTP, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryTOTPByUserID", ctx, userID)
+	ret0, _ := ret[0].(*entity.TOTP)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryTOTPByUserID indicates an expected call of QueryTOTPByUserID.
+func (mr *MockSystemRepoMockRecorder) QueryTOTPByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryTOTPByUserID", reflect.TypeOf((*MockSystemRepo)(nil).QueryTOTPByUserID), ctx, userID)
+}
+
+// QueryUserByEmail mocks base method.
+func (m *MockSystemRepo) QueryUserByEmail(ctx context.Context, email string) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryUserByEmail", ctx, email)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryUserByEmail indicates an expected call of QueryUserByEmail.
+func (mr *MockSystemRepoMockRecorder) QueryUserByEmail(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryUserByEmail", reflect.TypeOf((*MockSystemRepo)(nil).QueryUserByEmail), ctx, email)
+}
+
+// QueryUserByID mocks base method.
+func (m *MockSystemRepo) QueryUserByID(ctx context.Context, id int) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryUserByID", ctx, id)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryUserByID indicates an expected call of QueryUserByID.
+func (mr *MockSystemRepoMockRecorder) QueryUserByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryUserByID", reflect.TypeOf((*MockSystemRepo)(nil).QueryUserByID), ctx, id)
+}
+
+// QueryUserByUsername mocks base method.
+func (m *MockSystemRepo) QueryUserByUsername(ctx context.Context, username string) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryUserByUsername", ctx, username)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryUserByUsername indicates an expected call of QueryUserByUsername.
+func (mr *MockSystemRepoMockRecorder) QueryUserByUsername(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryUserByUsername", reflect.TypeOf((*MockSystemRepo)(nil).QueryUserByUsername), ctx, username)
+}
+
+// QueryValidJWTKey mocks base method.
+func (m *MockSystemRepo) QueryValidJWTKey(ctx context.Context) ([]*entity.JWTKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryValidJWTKey", ctx)
+	ret0, _ := ret[0].([]*entity.JWTKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryValidJWTKey indicates an expected call of QueryValidJWTKey.
+func (mr *MockSystemRepoMockRecorder) QueryValidJWTKey(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryValidJWTKey", reflect.TypeOf((*MockSystemRepo)(nil).QueryValidJWTKey), ctx)
+}
+
+// ReplaceNotifyPreference mocks base method.
+func (m *MockSystemRepo) ReplaceNotifyPreference(ctx context.Context, userID int, t *entity.NotifyPreference) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceNotifyPreference", ctx, userID, t)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceNotifyPreference indicates an expected call of ReplaceNotifyPreference.
+func (mr *MockSystemRepoMockRecorder) ReplaceNotifyPreference(ctx, userID, t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceNotifyPreference", reflect.TypeOf((*MockSystemRepo)(nil).ReplaceNotifyPreference), ctx, userID, t)
+}
+
+// ReplaceTOTP mocks base method.
+func (m *MockSystemRepo) ReplaceTOTP(ctx context.Context, t *entity.TOTP, backupCodeHashes []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceTOTP", ctx, t, backupCodeHashes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceTOTP indicates an expected call of ReplaceTOTP.
+func (mr *MockSystemRepoMockRecorder) ReplaceTOTP(ctx, t, backupCodeHashes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceTOTP", reflect.TypeOf((*MockSystemRepo)(nil).ReplaceTOTP), ctx, t, backupCodeHashes)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockSystemRepo) RevokeAPIKey(ctx context.Context, keyID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", ctx, keyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockSystemRepoMockRecorder) RevokeAPIKey(ctx, keyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockSystemRepo)(nil).RevokeAPIKey), ctx, keyID)
+}
+
+// RevokeSession mocks base method.
+func (m *MockSystemRepo) RevokeSession(ctx context.Context, sessionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", ctx, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockSystemRepoMockRecorder) RevokeSession(ctx, sessionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockSystemRepo)(nil).RevokeSession), ctx, sessionID)
+}
+
+// RevokeSessionByUserID mocks base method.
+func (m *MockSystemRepo) RevokeSessionByUserID(ctx context.Context, userID int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSessionByUserID", ctx, userID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeSessionByUserID indicates an expected call of RevokeSessionByUserID.
+func (mr *MockSystemRepoMockRecorder) RevokeSessionByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSessionByUserID", reflect.TypeOf((*MockSystemRepo)(nil).RevokeSessionByUserID), ctx, userID)
+}
+
+// RotateJWTKey mocks base method.
+func (m *MockSystemRepo) RotateJWTKey(ctx context.Context, key string, retireAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateJWTKey", ctx, key, retireAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RotateJWTKey indicates an expected call of RotateJWTKey.
+func (mr *MockSystemRepoMockRecorder) RotateJWTKey(ctx, key, retireAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateJWTKey", reflect.TypeOf((*MockSystemRepo)(nil).RotateJWTKey), ctx, key, retireAt)
+}
+
+// UpdateAPIKeyLastUsed mocks base method.
+func (m *MockSystemRepo) UpdateAPIKeyLastUsed(ctx context.Context, keyID string, lastUsed time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAPIKeyLastUsed", ctx, keyID, lastUsed)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAPIKeyLastUsed indicates an expected call of UpdateAPIKeyLastUsed.
+func (mr *MockSystemRepoMockRecorder) UpdateAPIKeyLastUsed(ctx, keyID, lastUsed any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAPIKeyLastUsed", reflect.TypeOf((*MockSystemRepo)(nil).UpdateAPIKeyLastUsed), ctx, keyID, lastUsed)
+}
+
+// UpdateSessionRefreshToken mocks base method.
+func (m *MockSystemRepo) UpdateSessionRefreshToken(ctx context.Context, t *entity.Session) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSessionRefreshToken", ctx, t)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSessionRefreshToken indicates an expected call of UpdateSessionRefreshToken.
+func (mr *MockSystemRepoMockRecorder) UpdateSessionRefreshToken(ctx, t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSessionRefreshToken", reflect.TypeOf((*MockSystemRepo)(nil).UpdateSessionRefreshToken), ctx, t)
+}
+
+// UpdateTOTP mocks base method.
+func (m *MockSystemRepo) UpdateTOTP(ctx context.Context, userID int, enabled bool, lastUsedStep int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTOTP", ctx, userID, enabled, lastUsedStep)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTOTP indicates an expected call of UpdateTOTP.
+func (mr *MockSystemRepoMockRecorder) UpdateTOTP(ctx, userID, enabled, lastUsedStep any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTOTP", reflect.TypeOf((*MockSystemRepo)(nil).UpdateTOTP), ctx, userID, enabled, lastUsedStep)
+}
+
+// UpdateUserAuthTrade mocks base method.
+func (m *MockSystemRepo) UpdateUserAuthTrade(ctx context.Context, username string, authTrade bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserAuthTrade", ctx, username, authTrade)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserAuthTrade indicates an expected call of UpdateUserAuthTrade.
+func (mr *MockSystemRepoMockRecorder) UpdateUserAuthTrade(ctx, username, authTrade any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserAuthTrade", reflect.TypeOf((*MockSystemRepo)(nil).UpdateUserAuthTrade), ctx, username, authTrade)
+}
+
+// UpdateUserPassword mocks base method.
+func (m *MockSystemRepo) UpdateUserPassword(ctx context.Context, username, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserPassword", ctx, username, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserPassword indicates an expected call of UpdateUserPassword.
+func (mr *MockSystemRepoMockRecorder) UpdateUserPassword(ctx, username, password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserPassword", reflect.TypeOf((*MockSystemRepo)(nil).UpdateUserPassword), ctx, username, password)
+}
+
+// UpdateUserRole mocks base method.
+func (m *MockSystemRepo) UpdateUserRole(ctx context.Context, username, role string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserRole", ctx, username, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserRole indicates an expected call of UpdateUserRole.
+func (mr *MockSystemRepoMockRecorder) UpdateUserRole(ctx, username, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserRole", reflect.TypeOf((*MockSystemRepo)(nil).UpdateUserRole), ctx, username, role)
+}
+
+// UpdateUserTokenValidAfter mocks base method.
+func (m *MockSystemRepo) UpdateUserTokenValidAfter(ctx context.Context, username string, t time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserTokenValidAfter", ctx, username, t)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserTokenValidAfter indicates an expected call of UpdateUserTokenValidAfter.
+func (mr *MockSystemRepoMockRecorder) UpdateUserTokenValidAfter(ctx, username, t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserTokenValidAfter", reflect.TypeOf((*MockSystemRepo)(nil).UpdateUserTokenValidAfter), ctx, username, t)
+}
+
+// UpsertSetting mocks base method.
+func (m *MockSystemRepo) UpsertSetting(ctx context.Context, key, value string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertSetting", ctx, key, value)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertSetting indicates an expected call of UpsertSetting.
+func (mr *MockSystemRepoMockRecorder) UpsertSetting(ctx, key, value any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertSetting", reflect.TypeOf((*MockSystemRepo)(nil).UpsertSetting), ctx, key, value)
+}
+
+// UseEmailVerificationByUserID mocks base method.
+func (m *MockSystemRepo) UseEmailVerificationByUserID(ctx context.Context, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseEmailVerificationByUserID", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseEmailVerificationByUserID indicates an expected call of UseEmailVerificationByUserID.
+func (mr *MockSystemRepoMockRecorder) UseEmailVerificationByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseEmailVerificationByUserID", reflect.TypeOf((*MockSystemRepo)(nil).UseEmailVerificationByUserID), ctx, userID)
+}
+
+// UsePasswordResetByUserID mocks base method.
+func (m *MockSystemRepo) UsePasswordResetByUserID(ctx context.Context, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UsePasswordResetByUserID", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UsePasswordResetByUserID indicates an expected call of UsePasswordResetByUserID.
+func (mr *MockSystemRepoMockRecorder) UsePasswordResetByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UsePasswordResetByUserID", reflect.TypeOf((*MockSystemRepo)(nil).UsePasswordResetByUserID), ctx, userID)
+}
+
+// UseTOTPBackupCode mocks base method.
+func (m *MockSystemRepo) UseTOTPBackupCode(ctx context.Context, userID int, codeHash string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseTOTPBackupCode", ctx, userID, codeHash)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseTOTPBackupCode indicates an expected call of UseTOTPBackupCode.
+func (mr *MockSystemRepoMockRecorder) UseTOTPBackupCode(ctx, userID, codeHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseTOTPBackupCode", reflect.TypeOf((*MockSystemRepo)(nil).UseTOTPBackupCode), ctx, userID, codeHash)
+}
+
+// MockAlertRepo is a mock of AlertRepo interface.
+type MockAlertRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockAlertRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockAlertRepoMockRecorder is the mock recorder for MockAlertRepo.
+type MockAlertRepoMockRecorder struct {
+	mock *MockAlertRepo
+}
+
+// NewMockAlertRepo creates a new mock instance.
+func NewMockAlertRepo(ctrl *gomock.Controller) *MockAlertRepo {
+	mock := &MockAlertRepo{ctrl: ctrl}
+	mock.recorder = &MockAlertRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAlertRepo) EXPECT() *MockAlertRepoMockRecorder {
+	return m.recorder
+}
+
+// DeleteAlertRule mocks base method.
+func (m *MockAlertRepo) DeleteAlertRule(ctx context.Context, id int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InsertHistoryKbarArr", ctx, t)
+	ret := m.ctrl.Call(m, "DeleteAlertRule", ctx, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// InsertHistoryKbarArr indicates an expected call of InsertHistoryKbarArr.
-func (mr *MockHistoryRepoMockRecorder) InsertHistoryKbarArr(ctx, t any) *gomock.Call {
+// DeleteAlertRule indicates an expected call of DeleteAlertRule.
+func (mr *MockAlertRepoMockRecorder) DeleteAlertRule(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertHistoryKbarArr", reflect.TypeOf((*MockHistoryRepo)(nil).InsertHistoryKbarArr), ctx, t)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAlertRule", reflect.TypeOf((*MockAlertRepo)(nil).DeleteAlertRule), ctx, id)
 }
 
-// InsertHistoryTickArr mocks base method.
-func (m *MockHistoryRepo) InsertHistoryTickArr(ctx context.Context, t []*entity.StockHistoryTick) error {
+// InsertAlertHistory mocks base method.
+func (m *MockAlertRepo) InsertAlertHistory(ctx context.Context, t *entity.AlertHistory) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InsertHistoryTickArr", ctx, t)
+	ret := m.ctrl.Call(m, "InsertAlertHistory", ctx, t)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// InsertHistoryTickArr indicates an expected call of InsertHistoryTickArr.
-func (mr *MockHistoryRepoMockRecorder) InsertHistoryTickArr(ctx, t any) *gomock.Call {
+// InsertAlertHistory indicates an expected call of InsertAlertHistory.
+func (mr *MockAlertRepoMockRecorder) InsertAlertHistory(ctx, t any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertHistoryTickArr", reflect.TypeOf((*MockHistoryRepo)(nil).InsertHistoryTickArr), ctx, t)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertAlertHistory", reflect.TypeOf((*MockAlertRepo)(nil).InsertAlertHistory), ctx, t)
 }
 
-// InsertQuaterMA mocks base method.
-func (m *MockHistoryRepo) InsertQuaterMA(ctx context.Context, t *entity.StockHistoryAnalyze) error {
+// InsertAlertRule mocks base method.
+func (m *MockAlertRepo) InsertAlertRule(ctx context.Context, t *entity.AlertRule) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InsertQuaterMA", ctx, t)
+	ret := m.ctrl.Call(m, "InsertAlertRule", ctx, t)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// InsertQuaterMA indicates an expected call of InsertQuaterMA.
-func (mr *MockHistoryRepoMockRecorder) InsertQuaterMA(ctx, t any) *gomock.Call {
+// InsertAlertRule indicates an expected call of InsertAlertRule.
+func (mr *MockAlertRepoMockRecorder) InsertAlertRule(ctx, t any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertQuaterMA", reflect.TypeOf((*MockHistoryRepo)(nil).InsertQuaterMA), ctx, t)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertAlertRule", reflect.TypeOf((*MockAlertRepo)(nil).InsertAlertRule), ctx, t)
 }
 
-// QueryAllQuaterMAByStockNum mocks base method.
-func (m *MockHistoryRepo) QueryAllQuaterMAByStockNum(ctx context.Context, stockNum string) (map[time.Time]*entity.StockHistoryAnalyze, error) {
+// QueryAlertHistoryByUserID mocks base method.
+func (m *MockAlertRepo) QueryAlertHistoryByUserID(ctx context.Context, userID int, limit, offset uint64) ([]*entity.AlertHistory, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "QueryAllQuaterMAByStockNum", ctx, stockNum)
-	ret0, _ := ret[0].(map[time.Time]*entity.StockHistoryAnalyze)
+	ret := m.ctrl.Call(m, "QueryAlertHistoryByUserID", ctx, userID, limit, offset)
+	ret0, _ := ret[0].([]*entity.AlertHistory)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// QueryAllQuaterMAByStockNum indicates an expected call of QueryAllQuaterMAByStockNum.
-func (mr *MockHistoryRepoMockRecorder) QueryAllQuaterMAByStockNum(ctx, stockNum any) *gomock.Call {
+// QueryAlertHistoryByUserID indicates an expected call of QueryAlertHistoryByUserID.
+func (mr *MockAlertRepoMockRecorder) QueryAlertHistoryByUserID(ctx, userID, limit, offset any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryAllQuaterMAByStockNum", reflect.TypeOf((*MockHistoryRepo)(nil).QueryAllQuaterMAByStockNum), ctx, stockNum)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryAlertHistoryByUserID", reflect.TypeOf((*MockAlertRepo)(nil).QueryAlertHistoryByUserID), ctx, userID, limit, offset)
 }
 
-// QueryMultiStockKbarArrByDate mocks base method.
-func (m *MockHistoryRepo) QueryMultiStockKbarArrByDate(ctx context.Context, stockNumArr []string, date time.Time) (map[string][]*entity.StockHistoryKbar, error) {
+// QueryAlertRuleByUserID mocks base method.
+func (m *MockAlertRepo) QueryAlertRuleByUserID(ctx context.Context, userID int) ([]*entity.AlertRule, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "QueryMultiStockKbarArrByDate", ctx, stockNumArr, date)
-	ret0, _ := ret[0].(map[string][]*entity.StockHistoryKbar)
+	ret := m.ctrl.Call(m, "QueryAlertRuleByUserID", ctx, userID)
+	ret0, _ := ret[0].([]*entity.AlertRule)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// QueryMultiStockKbarArrByDate indicates an expected call of QueryMultiStockKbarArrByDate.
-func (mr *MockHistoryRepoMockRecorder) QueryMultiStockKbarArrByDate(ctx, stockNumArr, date any) *gomock.Call {
+// QueryAlertRuleByUserID indicates an expected call of QueryAlertRuleByUserID.
+func (mr *MockAlertRepoMockRecorder) QueryAlertRuleByUserID(ctx, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryMultiStockKbarArrByDate", reflect.TypeOf((*MockHistoryRepo)(nil).QueryMultiStockKbarArrByDate), ctx, stockNumArr, date)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryAlertRuleByUserID", reflect.TypeOf((*MockAlertRepo)(nil).QueryAlertRuleByUserID), ctx, userID)
 }
 
-// QueryMultiStockTickArrByDate mocks base method.
-func (m *MockHistoryRepo) QueryMultiStockTickArrByDate(ctx context.Context, stockNumArr []string, date time.Time) (map[string][]*entity.StockHistoryTick, error) {
+// QueryEnabledAlertRule mocks base method.
+func (m *MockAlertRepo) QueryEnabledAlertRule(ctx context.Context) ([]*entity.AlertRule, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "QueryMultiStockTickArrByDate", ctx, stockNumArr, date)
-	ret0, _ := ret[0].(map[string][]*entity.StockHistoryTick)
+	ret := m.ctrl.Call(m, "QueryEnabledAlertRule", ctx)
+	ret0, _ := ret[0].([]*entity.AlertRule)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// QueryMultiStockTickArrByDate indicates an expected call of QueryMultiStockTickArrByDate.
-func (mr *MockHistoryRepoMockRecorder) QueryMultiStockTickArrByDate(ctx, stockNumArr, date any) *gomock.Call {
+// QueryEnabledAlertRule indicates an expected call of QueryEnabledAlertRule.
+func (mr *MockAlertRepoMockRecorder) QueryEnabledAlertRule(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryMultiStockTickArrByDate", reflect.TypeOf((*MockHistoryRepo)(nil).QueryMultiStockTickArrByDate), ctx, stockNumArr, date)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryEnabledAlertRule", reflect.TypeOf((*MockAlertRepo)(nil).QueryEnabledAlertRule), ctx)
 }
 
-// QueryMutltiStockCloseByDate mocks base method.
-func (m *MockHistoryRepo) QueryMutltiStockCloseByDate(ctx context.Context, stockNumArr []string, date time.Time) (map[string]*entity.StockHistoryClose, error) {
+// UpdateAlertRule mocks base method.
+func (m *MockAlertRepo) UpdateAlertRule(ctx context.Context, t *entity.AlertRule) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "QueryMutltiStockCloseByDate", ctx, stockNumArr, date)
-	ret0, _ := ret[0].(map[string]*entity.StockHistoryClose)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "UpdateAlertRule", ctx, t)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// QueryMutltiStockCloseByDate indicates an expected call of QueryMutltiStockCloseByDate.
-func (mr *MockHistoryRepoMockRecorder) QueryMutltiStockCloseByDate(ctx, stockNumArr, date any) *gomock.Call {
+// UpdateAlertRule indicates an expected call of UpdateAlertRule.
+func (mr *MockAlertRepoMockRecorder) UpdateAlertRule(ctx, t any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryMutltiStockCloseByDate", reflect.TypeOf((*MockHistoryRepo)(nil).QueryMutltiStockCloseByDate), ctx, stockNumArr, date)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAlertRule", reflect.TypeOf((*MockAlertRepo)(nil).UpdateAlertRule), ctx, t)
 }
 
-// MockRealTimeRepo is a mock of RealTimeRepo interface.
-type MockRealTimeRepo struct {
+// MockReportRepo is a mock of ReportRepo interface.
+type MockReportRepo struct {
 	ctrl     *gomock.Controller
-	recorder *MockRealTimeRepoMockRecorder
+	recorder *MockReportRepoMockRecorder
 	isgomock struct{}
 }
 
-// MockRealTimeRepoMockRecorder is the mock recorder for MockRealTimeRepo.
-type MockRealTimeRepoMockRecorder struct {
-	mock *MockRealTimeRepo
+// MockReportRepoMockRecorder is the mock recorder for MockReportRepo.
+type MockReportRepoMockRecorder struct {
+	mock *MockReportRepo
 }
 
-// NewMockRealTimeRepo creates a new mock instance.
-func NewMockRealTimeRepo(ctrl *gomock.Controller) *MockRealTimeRepo {
-	mock := &MockRealTimeRepo{ctrl: ctrl}
-	mock.recorder = &MockRealTimeRepoMockRecorder{mock}
+// NewMockReportRepo creates a new mock instance.
+func NewMockReportRepo(ctrl *gomock.Controller) *MockReportRepo {
+	mock := &MockReportRepo{ctrl: ctrl}
+	mock.recorder = &MockReportRepoMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockRealTimeRepo) EXPECT() *MockRealTimeRepoMockRecorder {
+func (m *MockReportRepo) EXPECT() *MockReportRepoMockRecorder {
 	return m.recorder
 }
 
-// InsertEvent mocks base method.
-func (m *MockRealTimeRepo) InsertEvent(ctx context.Context, t *entity.SinopacEvent) error {
+// InsertOrUpdateDailyReport mocks base method.
+func (m *MockReportRepo) InsertOrUpdateDailyReport(ctx context.Context, t *entity.DailyReport) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InsertEvent", ctx, t)
+	ret := m.ctrl.Call(m, "InsertOrUpdateDailyReport", ctx, t)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// InsertEvent indicates an expected call of InsertEvent.
-func (mr *MockRealTimeRepoMockRecorder) InsertEvent(ctx, t any) *gomock.Call {
+// InsertOrUpdateDailyReport indicates an expected call of InsertOrUpdateDailyReport.
+func (mr *MockReportRepoMockRecorder) InsertOrUpdateDailyReport(ctx, t any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertEvent", reflect.TypeOf((*MockRealTimeRepo)(nil).InsertEvent), ctx, t)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertOrUpdateDailyReport", reflect.TypeOf((*MockReportRepo)(nil).InsertOrUpdateDailyReport), ctx, t)
 }
 
-// MockSystemRepo is a mock of SystemRepo interface.
-type MockSystemRepo struct {
+// QueryDailyReportByDate mocks base method.
+func (m *MockReportRepo) QueryDailyReportByDate(ctx context.Context, date time.Time) (*entity.DailyReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryDailyReportByDate", ctx, date)
+	ret0, _ := ret[0].(*entity.DailyReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryDailyReportByDate indicates an expected call of QueryDailyReportByDate.
+func (mr *MockReportRepoMockRecorder) QueryDailyReportByDate(ctx, date any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryDailyReportByDate", reflect.TypeOf((*MockReportRepo)(nil).QueryDailyReportByDate), ctx, date)
+}
+
+// MockWatchlistRepo is a mock of WatchlistRepo interface.
+type MockWatchlistRepo struct {
 	ctrl     *gomock.Controller
-	recorder *MockSystemRepoMockRecorder
+	recorder *MockWatchlistRepoMockRecorder
 	isgomock struct{}
 }
 
-// MockSystemRepoMockRecorder is the mock recorder for MockSystemRepo.
-type MockSystemRepoMockRecorder struct {
-	mock *MockSystemRepo
+// MockWatchlistRepoMockRecorder is the mock recorder for MockWatchlistRepo.
+type MockWatchlistRepoMockRecorder struct {
+	mock *MockWatchlistRepo
 }
 
-// NewMockSystemRepo creates a new mock instance.
-func NewMockSystemRepo(ctrl *gomock.Controller) *MockSystemRepo {
-	mock := &MockSystemRepo{ctrl: ctrl}
-	mock.recorder = &MockSystemRepoMockRecorder{mock}
+// NewMockWatchlistRepo creates a new mock instance.
+func NewMockWatchlistRepo(ctrl *gomock.Controller) *MockWatchlistRepo {
+	mock := &MockWatchlistRepo{ctrl: ctrl}
+	mock.recorder = &MockWatchlistRepoMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockSystemRepo) EXPECT() *MockSystemRepoMockRecorder {
+func (m *MockWatchlistRepo) EXPECT() *MockWatchlistRepoMockRecorder {
 	return m.recorder
 }
 
-// DeleteAllPushTokens mocks base method.
-func (m *MockSystemRepo) DeleteAllPushTokens(ctx context.Context) error {
+// DeleteWatchlist mocks base method.
+func (m *MockWatchlistRepo) DeleteWatchlist(ctx context.Context, id int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteAllPushTokens", ctx)
+	ret := m.ctrl.Call(m, "DeleteWatchlist", ctx, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteAllPushTokens indicates an expected call of DeleteAllPushTokens.
-func (mr *MockSystemRepoMockRecorder) DeleteAllPushTokens(ctx any) *gomock.Call {
+// DeleteWatchlist indicates an expected call of DeleteWatchlist.
+func (mr *MockWatchlistRepoMockRecorder) DeleteWatchlist(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAllPushTokens", reflect.TypeOf((*MockSystemRepo)(nil).DeleteAllPushTokens), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWatchlist", reflect.TypeOf((*MockWatchlistRepo)(nil).DeleteWatchlist), ctx, id)
 }
 
-// EmailVerification mocks base method.
-func (m *MockSystemRepo) EmailVerification(ctx context.Context, username string) error {
+// DeleteWatchlistItem mocks base method.
+func (m *MockWatchlistRepo) DeleteWatchlistItem(ctx context.Context, watchlistID int, codes []string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "EmailVerification", ctx, username)
+	ret := m.ctrl.Call(m, "DeleteWatchlistItem", ctx, watchlistID, codes)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// EmailVerification indicates an expected call of EmailVerification.
-func (mr *MockSystemRepoMockRecorder) EmailVerification(ctx, username any) *gomock.Call {
+// DeleteWatchlistItem indicates an expected call of DeleteWatchlistItem.
+func (mr *MockWatchlistRepoMockRecorder) DeleteWatchlistItem(ctx, watchlistID, codes any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EmailVerification", reflect.TypeOf((*MockSystemRepo)(nil).EmailVerification), ctx, username)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWatchlistItem", reflect.TypeOf((*MockWatchlistRepo)(nil).DeleteWatchlistItem), ctx, watchlistID, codes)
 }
 
-// GetAllPushTokens mocks base method.
-func (m *MockSystemRepo) GetAllPushTokens(ctx context.Context) ([]string, error) {
+// InsertOrUpdateWatchlistItem mocks base method.
+func (m *MockWatchlistRepo) InsertOrUpdateWatchlistItem(ctx context.Context, t []*entity.WatchlistItem) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllPushTokens", ctx)
-	ret0, _ := ret[0].([]string)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "InsertOrUpdateWatchlistItem", ctx, t)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetAllPushTokens indicates an expected call of GetAllPushTokens.
-func (mr *MockSystemRepoMockRecorder) GetAllPushTokens(ctx any) *gomock.Call {
+// InsertOrUpdateWatchlistItem indicates an expected call of InsertOrUpdateWatchlistItem.
+func (mr *MockWatchlistRepoMockRecorder) InsertOrUpdateWatchlistItem(ctx, t any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllPushTokens", reflect.TypeOf((*MockSystemRepo)(nil).GetAllPushTokens), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertOrUpdateWatchlistItem", reflect.TypeOf((*MockWatchlistRepo)(nil).InsertOrUpdateWatchlistItem), ctx, t)
 }
 
-// GetLastJWT mocks base method.
-func (m *MockSystemRepo) GetLastJWT(ctx context.Context) (string, error) {
+// InsertWatchlist mocks base method.
+func (m *MockWatchlistRepo) InsertWatchlist(ctx context.Context, t *entity.Watchlist) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLastJWT", ctx)
-	ret0, _ := ret[0].(string)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "InsertWatchlist", ctx, t)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetLastJWT indicates an expected call of GetLastJWT.
-func (mr *MockSystemRepoMockRecorder) GetLastJWT(ctx any) *gomock.Call {
+// InsertWatchlist indicates an expected call of InsertWatchlist.
+func (mr *MockWatchlistRepoMockRecorder) InsertWatchlist(ctx, t any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastJWT", reflect.TypeOf((*MockSystemRepo)(nil).GetLastJWT), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertWatchlist", reflect.TypeOf((*MockWatchlistRepo)(nil).InsertWatchlist), ctx, t)
 }
 
-// GetPushToken mocks base method.
-func (m *MockSystemRepo) GetPushToken(ctx context.Context, token string) (*entity.PushToken, error) {
+// QueryWatchlistByUserID mocks base method.
+func (m *MockWatchlistRepo) QueryWatchlistByUserID(ctx context.Context, userID int) ([]*entity.Watchlist, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPushToken", ctx, token)
-	ret0, _ := ret[0].(*entity.PushToken)
+	ret := m.ctrl.Call(m, "QueryWatchlistByUserID", ctx, userID)
+	ret0, _ := ret[0].([]*entity.Watchlist)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetPushToken indicates an expected call of GetPushToken.
-func (mr *MockSystemRepoMockRecorder) GetPushToken(ctx, token any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPushToken", reflect.TypeOf((*MockSystemRepo)(nil).GetPushToken), ctx, token)
-}
-
-// InsertJWT mocks base method.
-func (m *MockSystemRepo) InsertJWT(ctx context.Context, jwt string) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InsertJWT", ctx, jwt)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// InsertJWT indicates an expected call of InsertJWT.
-func (mr *MockSystemRepoMockRecorder) InsertJWT(ctx, jwt any) *gomock.Call {
+// QueryWatchlistByUserID indicates an expected call of QueryWatchlistByUserID.
+func (mr *MockWatchlistRepoMockRecorder) QueryWatchlistByUserID(ctx, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertJWT", reflect.TypeOf((*MockSystemRepo)(nil).InsertJWT), ctx, jwt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryWatchlistByUserID", reflect.TypeOf((*MockWatchlistRepo)(nil).QueryWatchlistByUserID), ctx, userID)
 }
 
-// InsertOrUpdatePushToken mocks base method.
-func (m *MockSystemRepo) InsertOrUpdatePushToken(ctx context.Context, token, username string, enabled bool) error {
+// UpdateWatchlist mocks base method.
+func (m *MockWatchlistRepo) UpdateWatchlist(ctx context.Context, t *entity.Watchlist) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InsertOrUpdatePushToken", ctx, token, username, enabled)
+	ret := m.ctrl.Call(m, "UpdateWatchlist", ctx, t)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// InsertOrUpdatePushToken indicates an expected call of InsertOrUpdatePushToken.
-func (mr *MockSystemRepoMockRecorder) InsertOrUpdatePushToken(ctx, token, username, enabled any) *gomock.Call {
+// UpdateWatchlist indicates an expected call of UpdateWatchlist.
+func (mr *MockWatchlistRepoMockRecorder) UpdateWatchlist(ctx, t any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertOrUpdatePushToken", reflect.TypeOf((*MockSystemRepo)(nil).InsertOrUpdatePushToken), ctx, token, username, enabled)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWatchlist", reflect.TypeOf((*MockWatchlistRepo)(nil).UpdateWatchlist), ctx, t)
 }
 
-// InsertUser mocks base method.
-func (m *MockSystemRepo) InsertUser(ctx context.Context, t *entity.NewUser) error {
+// UpdateWatchlistItemSort mocks base method.
+func (m *MockWatchlistRepo) UpdateWatchlistItemSort(ctx context.Context, watchlistID int, codes []string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InsertUser", ctx, t)
+	ret := m.ctrl.Call(m, "UpdateWatchlistItemSort", ctx, watchlistID, codes)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// InsertUser indicates an expected call of InsertUser.
-func (mr *MockSystemRepoMockRecorder) InsertUser(ctx, t any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertUser", reflect.TypeOf((*MockSystemRepo)(nil).InsertUser), ctx, t)
-}
-
-// QueryAllUser mocks base method.
-func (m *MockSystemRepo) QueryAllUser(ctx context.Context) ([]*entity.User, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "QueryAllUser", ctx)
-	ret0, _ := ret[0].([]*entity.User)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// QueryAllUser indicates an expected call of QueryAllUser.
-func (mr *MockSystemRepoMockRecorder) QueryAllUser(ctx any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryAllUser", reflect.TypeOf((*MockSystemRepo)(nil).QueryAllUser), ctx)
-}
-
-// QueryUserByUsername mocks base method.
-func (m *MockSystemRepo) QueryUserByUsername(ctx context.Context, username string) (*entity.User, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "QueryUserByUsername", ctx, username)
-	ret0, _ := ret[0].(*entity.User)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// QueryUserByUsername indicates an expected call of QueryUserByUsername.
-func (mr *MockSystemRepoMockRecorder) QueryUserByUsername(ctx, username any) *gomock.Call {
+// UpdateWatchlistItemSort indicates an expected call of UpdateWatchlistItemSort.
+func (mr *MockWatchlistRepoMockRecorder) UpdateWatchlistItemSort(ctx, watchlistID, codes any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryUserByUsername", reflect.TypeOf((*MockSystemRepo)(nil).QueryUserByUsername), ctx, username)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWatchlistItemSort", reflect.TypeOf((*MockWatchlistRepo)(nil).UpdateWatchlistItemSort), ctx, watchlistID, codes)
 }
 
 // MockTargetRepo is a mock of TargetRepo interface.
@@ -592,6 +1797,21 @@ func (mr *MockTradeRepoMockRecorder) ClearInventoryStockByUUID(ctx, uuid any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearInventoryStockByUUID", reflect.TypeOf((*MockTradeRepo)(nil).ClearInventoryStockByUUID), ctx, uuid)
 }
 
+// InsertFutureRoll mocks base method.
+func (m *MockTradeRepo) InsertFutureRoll(ctx context.Context, t *entity.FutureRoll) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertFutureRoll", ctx, t)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertFutureRoll indicates an expected call of InsertFutureRoll.
+func (mr *MockTradeRepoMockRecorder) InsertFutureRoll(ctx, t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertFutureRoll", reflect.TypeOf((*MockTradeRepo)(nil).InsertFutureRoll), ctx, t)
+}
+
 // InsertOrUpdateAccountBalance mocks base method.
 func (m *MockTradeRepo) InsertOrUpdateAccountBalance(ctx context.Context, t *entity.AccountBalance) error {
 	m.ctrl.T.Helper()
@@ -662,6 +1882,34 @@ func (mr *MockTradeRepoMockRecorder) InsertOrUpdateInventoryStock(ctx, t any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertOrUpdateInventoryStock", reflect.TypeOf((*MockTradeRepo)(nil).InsertOrUpdateInventoryStock), ctx, t)
 }
 
+// InsertOrUpdateOptionOrderByOrderID mocks base method.
+func (m *MockTradeRepo) InsertOrUpdateOptionOrderByOrderID(ctx context.Context, t *entity.OptionOrder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertOrUpdateOptionOrderByOrderID", ctx, t)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertOrUpdateOptionOrderByOrderID indicates an expected call of InsertOrUpdateOptionOrderByOrderID.
+func (mr *MockTradeRepoMockRecorder) InsertOrUpdateOptionOrderByOrderID(ctx, t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertOrUpdateOptionOrderByOrderID", reflect.TypeOf((*MockTradeRepo)(nil).InsertOrUpdateOptionOrderByOrderID), ctx, t)
+}
+
+// InsertOrUpdateOptionTradeBalance mocks base method.
+func (m *MockTradeRepo) InsertOrUpdateOptionTradeBalance(ctx context.Context, t *entity.OptionTradeBalance) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertOrUpdateOptionTradeBalance", ctx, t)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertOrUpdateOptionTradeBalance indicates an expected call of InsertOrUpdateOptionTradeBalance.
+func (mr *MockTradeRepoMockRecorder) InsertOrUpdateOptionTradeBalance(ctx, t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertOrUpdateOptionTradeBalance", reflect.TypeOf((*MockTradeRepo)(nil).InsertOrUpdateOptionTradeBalance), ctx, t)
+}
+
 // InsertOrUpdateOrderByOrderID mocks base method.
 func (m *MockTradeRepo) InsertOrUpdateOrderByOrderID(ctx context.Context, t *entity.StockOrder) error {
 	m.ctrl.T.Helper()
@@ -690,6 +1938,21 @@ func (mr *MockTradeRepoMockRecorder) InsertOrUpdateStockTradeBalance(ctx, t any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertOrUpdateStockTradeBalance", reflect.TypeOf((*MockTradeRepo)(nil).InsertOrUpdateStockTradeBalance), ctx, t)
 }
 
+// QueryAccountBalanceByDate mocks base method.
+func (m *MockTradeRepo) QueryAccountBalanceByDate(ctx context.Context, date time.Time) (*entity.AccountBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryAccountBalanceByDate", ctx, date)
+	ret0, _ := ret[0].(*entity.AccountBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryAccountBalanceByDate indicates an expected call of QueryAccountBalanceByDate.
+func (mr *MockTradeRepoMockRecorder) QueryAccountBalanceByDate(ctx, date any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryAccountBalanceByDate", reflect.TypeOf((*MockTradeRepo)(nil).QueryAccountBalanceByDate), ctx, date)
+}
+
 // QueryAllFutureOrder mocks base method.
 func (m *MockTradeRepo) QueryAllFutureOrder(ctx context.Context) ([]*entity.FutureOrder, error) {
 	m.ctrl.T.Helper()
@@ -735,6 +1998,51 @@ func (mr *MockTradeRepoMockRecorder) QueryAllFutureTradeBalance(ctx any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryAllFutureTradeBalance", reflect.TypeOf((*MockTradeRepo)(nil).QueryAllFutureTradeBalance), ctx)
 }
 
+// QueryAllOptionOrder mocks base method.
+func (m *MockTradeRepo) QueryAllOptionOrder(ctx context.Context) ([]*entity.OptionOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryAllOptionOrder", ctx)
+	ret0, _ := ret[0].([]*entity.OptionOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryAllOptionOrder indicates an expected call of QueryAllOptionOrder.
+func (mr *MockTradeRepoMockRecorder) QueryAllOptionOrder(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryAllOptionOrder", reflect.TypeOf((*MockTradeRepo)(nil).QueryAllOptionOrder), ctx)
+}
+
+// QueryAllOptionOrderByDate mocks base method.
+func (m *MockTradeRepo) QueryAllOptionOrderByDate(ctx context.Context, timeTange []time.Time) ([]*entity.OptionOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryAllOptionOrderByDate", ctx, timeTange)
+	ret0, _ := ret[0].([]*entity.OptionOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryAllOptionOrderByDate indicates an expected call of QueryAllOptionOrderByDate.
+func (mr *MockTradeRepoMockRecorder) QueryAllOptionOrderByDate(ctx, timeTange any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryAllOptionOrderByDate", reflect.TypeOf((*MockTradeRepo)(nil).QueryAllOptionOrderByDate), ctx, timeTange)
+}
+
+// QueryAllOptionTradeBalance mocks base method.
+func (m *MockTradeRepo) QueryAllOptionTradeBalance(ctx context.Context) ([]*entity.OptionTradeBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryAllOptionTradeBalance", ctx)
+	ret0, _ := ret[0].([]*entity.OptionTradeBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryAllOptionTradeBalance indicates an expected call of QueryAllOptionTradeBalance.
+func (mr *MockTradeRepoMockRecorder) QueryAllOptionTradeBalance(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryAllOptionTradeBalance", reflect.TypeOf((*MockTradeRepo)(nil).QueryAllOptionTradeBalance), ctx)
+}
+
 // QueryAllStockOrder mocks base method.
 func (m *MockTradeRepo) QueryAllStockOrder(ctx context.Context) ([]*entity.StockOrder, error) {
 	m.ctrl.T.Helper()
@@ -780,6 +2088,21 @@ func (mr *MockTradeRepoMockRecorder) QueryAllStockTradeBalance(ctx any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryAllStockTradeBalance", reflect.TypeOf((*MockTradeRepo)(nil).QueryAllStockTradeBalance), ctx)
 }
 
+// QueryFutureTradeBalanceByDate mocks base method.
+func (m *MockTradeRepo) QueryFutureTradeBalanceByDate(ctx context.Context, date time.Time) (*entity.FutureTradeBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryFutureTradeBalanceByDate", ctx, date)
+	ret0, _ := ret[0].(*entity.FutureTradeBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryFutureTradeBalanceByDate indicates an expected call of QueryFutureTradeBalanceByDate.
+func (mr *MockTradeRepoMockRecorder) QueryFutureTradeBalanceByDate(ctx, date any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryFutureTradeBalanceByDate", reflect.TypeOf((*MockTradeRepo)(nil).QueryFutureTradeBalanceByDate), ctx, date)
+}
+
 // QueryInventoryStockByDate mocks base method.
 func (m *MockTradeRepo) QueryInventoryStockByDate(ctx context.Context, date time.Time) ([]*entity.InventoryStock, error) {
 	m.ctrl.T.Helper()
@@ -824,3 +2147,33 @@ func (mr *MockTradeRepoMockRecorder) QueryLastAccountBalance(ctx any) *gomock.Ca
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryLastAccountBalance", reflect.TypeOf((*MockTradeRepo)(nil).QueryLastAccountBalance), ctx)
 }
+
+// QueryOptionTradeBalanceByDate mocks base method.
+func (m *MockTradeRepo) QueryOptionTradeBalanceByDate(ctx context.Context, date time.Time) (*entity.OptionTradeBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryOptionTradeBalanceByDate", ctx, date)
+	ret0, _ := ret[0].(*entity.OptionTradeBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryOptionTradeBalanceByDate indicates an expected call of QueryOptionTradeBalanceByDate.
+func (mr *MockTradeRepoMockRecorder) QueryOptionTradeBalanceByDate(ctx, date any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryOptionTradeBalanceByDate", reflect.TypeOf((*MockTradeRepo)(nil).QueryOptionTradeBalanceByDate), ctx, date)
+}
+
+// QueryStockTradeBalanceByDate mocks base method.
+func (m *MockTradeRepo) QueryStockTradeBalanceByDate(ctx context.Context, date time.Time) (*entity.StockTradeBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryStockTradeBalanceByDate", ctx, date)
+	ret0, _ := ret[0].(*entity.StockTradeBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryStockTradeBalanceByDate indicates an expected call of QueryStockTradeBalanceByDate.
+func (mr *MockTradeRepoMockRecorder) QueryStockTradeBalanceByDate(ctx, date any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryStockTradeBalanceByDate", reflect.TypeOf((*MockTradeRepo)(nil).QueryStockTradeBalanceByDate), ctx, date)
+}
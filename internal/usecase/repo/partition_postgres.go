@@ -0,0 +1,78 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/toc-taiwan/postgres"
+	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
+)
+
+type partition struct {
+	*postgres.Postgres
+}
+
+func NewPartition(pg *postgres.Postgres) PartitionRepo {
+	return &partition{pg}
+}
+
+// QueryPartitionNameArr returns names of all partitions of the table.
+func (r *partition) QueryPartitionNameArr(ctx context.Context, table entity.HistoryTable) ([]string, error) {
+	sql, args, err := r.Builder.
+		Select("child.relname").
+		From("pg_inherits").
+		Join("pg_class parent ON parent.oid = pg_inherits.inhparent").
+		Join("pg_class child ON child.oid = pg_inherits.inhrelid").
+		Where("parent.relname = ?", string(table)).
+		OrderBy("child.relname ASC").ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.Pool().Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		result = append(result, name)
+	}
+	return result, nil
+}
+
+// CreatePartition -. Bounds of DDL can not be bind parameters, they are formatted from time only.
+func (r *partition) CreatePartition(ctx context.Context, p *entity.HistoryPartition) error {
+	sql := fmt.Sprintf(
+		"CREATE TABLE IF NOT EXISTS %s PARTITION OF %s FOR VALUES FROM ('%s') TO ('%s')",
+		pgx.Identifier{p.Name}.Sanitize(), pgx.Identifier{string(p.Table)}.Sanitize(), p.From.Format(time.RFC3339), p.To.Format(time.RFC3339),
+	)
+	_, err := r.Pool().Exec(ctx, sql)
+	return err
+}
+
+// ArchivePartition writes all rows of the partition to w as csv with header.
+func (r *partition) ArchivePartition(ctx context.Context, p *entity.HistoryPartition, w io.Writer) error {
+	conn, err := r.Pool().Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	_, err = conn.Conn().PgConn().CopyTo(ctx, w, fmt.Sprintf("COPY %s TO STDOUT WITH (FORMAT csv, HEADER true)", pgx.Identifier{p.Name}.Sanitize()))
+	return err
+}
+
+// DropPartition -.
+func (r *partition) DropPartition(ctx context.Context, p *entity.HistoryPartition) error {
+	_, err := r.Pool().Exec(ctx, fmt.Sprintf("DROP TABLE IF EXISTS %s", pgx.Identifier{p.Name}.Sanitize()))
+	return err
+}
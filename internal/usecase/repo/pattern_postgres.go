@@ -0,0 +1,113 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/toc-taiwan/postgres"
+	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
+)
+
+type pattern struct {
+	*postgres.Postgres
+}
+
+func NewPattern(pg *postgres.Postgres) PatternRepo {
+	return &pattern{pg}
+}
+
+// InsertOrUpdatePatternHitArr -.
+func (r *pattern) InsertOrUpdatePatternHitArr(ctx context.Context, t []*entity.PatternHit) error {
+	split := [][]*entity.PatternHit{}
+	if len(t) > batchSize {
+		count := len(t)/batchSize + 1
+		for i := 0; i < count; i++ {
+			start := i * batchSize
+			end := (i + 1) * batchSize
+			if end > len(t) {
+				end = len(t)
+			}
+			if start != end {
+				split = append(split, t[start:end])
+			}
+		}
+	} else if len(t) != 0 {
+		split = append(split, t)
+	}
+
+	tx, err := r.BeginTransaction()
+	if err != nil {
+		return err
+	}
+	defer r.EndTransaction(tx, err)
+	var sql string
+	var args []interface{}
+
+	for _, s := range split {
+		builder := r.Builder.Insert(tableNameAnalyzePatternHit).Columns("date, stock_num, pattern, detail, value, created")
+		for _, d := range s {
+			builder = builder.Values(d.Date, d.StockNum, d.Pattern, d.Detail, d.Value, d.Created)
+		}
+		builder = builder.Suffix(`ON CONFLICT ("date", "stock_num", "pattern") DO UPDATE SET "detail" = EXCLUDED."detail", "value" = EXCLUDED."value", "created" = EXCLUDED."created"`)
+		if sql, args, err = builder.ToSql(); err != nil {
+			return err
+		} else if _, err = tx.Exec(ctx, sql, args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// QueryPatternHitByDate returns one page of hits on date sorted by pattern and stock, pattern is optional.
+func (r *pattern) QueryPatternHitByDate(ctx context.Context, date time.Time, patternName entity.PatternName, limit, offset uint64) (*entity.PatternHitList, error) {
+	where := squirrel.Eq{"date": date}
+	if patternName != "" {
+		where["pattern"] = patternName
+	}
+
+	result := &entity.PatternHitList{Hits: []*entity.PatternHit{}}
+	sql, args, err := r.Builder.Select("count(*)").From(tableNameAnalyzePatternHit).Where(where).ToSql()
+	if err != nil {
+		return nil, err
+	}
+	if err = r.Pool().QueryRow(ctx, sql, args...).Scan(&result.Total); err != nil {
+		return nil, err
+	}
+	if result.Total == 0 {
+		return result, nil
+	}
+
+	sql, args, err = r.Builder.
+		Select("date, stock_num, pattern, detail, value, created, number, name, exchange, category, day_trade, last_close, update_date").
+		From(tableNameAnalyzePatternHit).
+		Where(where).
+		Join("basic_stock ON analyze_pattern_hit.stock_num = basic_stock.number").
+		OrderBy("pattern ASC", "stock_num ASC").
+		Limit(limit).
+		Offset(offset).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.Pool().Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		e := entity.PatternHit{Stock: new(entity.Stock)}
+		var name string
+		if err := rows.Scan(
+			&e.Date, &e.StockNum, &name, &e.Detail, &e.Value, &e.Created,
+			&e.Stock.Number, &e.Stock.Name, &e.Stock.Exchange, &e.Stock.Category, &e.Stock.DayTrade, &e.Stock.LastClose, &e.Stock.UpdateDate,
+		); err != nil {
+			return nil, err
+		}
+		e.Pattern = entity.PatternName(name)
+		result.Hits = append(result.Hits, &e)
+	}
+	return result, nil
+}
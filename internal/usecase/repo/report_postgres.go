@@ -0,0 +1,74 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
+	"github.com/toc-taiwan/postgres"
+	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
+)
+
+type report struct {
+	*postgres.Postgres
+}
+
+func NewReport(pg *postgres.Postgres) ReportRepo {
+	return &report{pg}
+}
+
+// InsertOrUpdateDailyReport keeps one report per date, a regenerated report replaces the old one.
+func (r *report) InsertOrUpdateDailyReport(ctx context.Context, t *entity.DailyReport) error {
+	content, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+
+	builder := r.Builder.Insert(tableNameReportDaily).
+		Columns("date, content, created").
+		Values(t.Date, string(content), t.Created).
+		Suffix(`ON CONFLICT ("date") DO UPDATE SET "content" = EXCLUDED."content", "created" = EXCLUDED."created"`)
+
+	tx, err := r.BeginTransaction()
+	if err != nil {
+		return err
+	}
+	defer r.EndTransaction(tx, err)
+	var sql string
+	var args []interface{}
+
+	if sql, args, err = builder.ToSql(); err != nil {
+		return err
+	} else if _, err = tx.Exec(ctx, sql, args...); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *report) QueryDailyReportByDate(ctx context.Context, date time.Time) (*entity.DailyReport, error) {
+	sql, arg, err := r.Builder.
+		Select("content").
+		From(tableNameReportDaily).
+		Where(squirrel.Eq{"date": date}).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var content []byte
+	if err := r.Pool().QueryRow(ctx, sql, arg...).Scan(&content); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	e := entity.DailyReport{}
+	if err := json.Unmarshal(content, &e); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
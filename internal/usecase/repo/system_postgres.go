@@ -3,8 +3,10 @@ package repo
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
+	"github.com/Masterminds/squirrel"
 	"github.com/jackc/pgx/v4"
 	"github.com/toc-taiwan/postgres"
 	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
@@ -21,8 +23,8 @@ func NewSystemRepo(pg *postgres.Postgres) SystemRepo {
 
 func (r *system) InsertUser(ctx context.Context, t *entity.NewUser) error {
 	builder := r.Builder.Insert(tableNameSystemAccount).
-		Columns("username, password, email").
-		Values(t.Username, t.Password, t.Email)
+		Columns("username, password, email, created").
+		Values(t.Username, t.Password, t.Email, time.Now())
 
 	tx, err := r.BeginTransaction()
 	if err != nil {
@@ -62,10 +64,22 @@ func (r *system) EmailVerification(ctx context.Context, username string) error {
 }
 
 func (r *system) QueryUserByUsername(ctx context.Context, username string) (*entity.User, error) {
+	return r.queryUser(ctx, squirrel.Eq{"username": username})
+}
+
+func (r *system) QueryUserByID(ctx context.Context, id int) (*entity.User, error) {
+	return r.queryUser(ctx, squirrel.Eq{"id": id})
+}
+
+func (r *system) QueryUserByEmail(ctx context.Context, email string) (*entity.User, error) {
+	return r.queryUser(ctx, squirrel.Eq{"email": email})
+}
+
+func (r *system) queryUser(ctx context.Context, where squirrel.Eq) (*entity.User, error) {
 	sql, arg, err := r.Builder.
-		Select("username, password, email, email_verified, auth_trade").
+		Select("id, username, password, email, email_verified, auth_trade, role, token_valid_after").
 		From(tableNameSystemAccount).
-		Where("username = ?", username).
+		Where(where).
 		ToSql()
 	if err != nil {
 		return nil, err
@@ -73,7 +87,7 @@ func (r *system) QueryUserByUsername(ctx context.Context, username string) (*ent
 
 	row := r.Pool().QueryRow(ctx, sql, arg...)
 	e := entity.User{}
-	if err := row.Scan(&e.Username, &e.Password, &e.Email, &e.EmailVerified, &e.AuthTrade); err != nil {
+	if err := row.Scan(&e.ID, &e.Username, &e.Password, &e.Email, &e.EmailVerified, &e.AuthTrade, &e.Role, &e.TokenValidAfter); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil
 		}
@@ -105,8 +119,9 @@ func (r *system) queryUserIDByUsername(ctx context.Context, username string) (in
 
 func (r *system) QueryAllUser(ctx context.Context) ([]*entity.User, error) {
 	sql, arg, err := r.Builder.
-		Select("username, email, email_verified, auth_trade").
+		Select("id, username, email, email_verified, auth_trade, role").
 		From(tableNameSystemAccount).
+		OrderBy("id ASC").
 		ToSql()
 	if err != nil {
 		return nil, err
@@ -121,7 +136,7 @@ func (r *system) QueryAllUser(ctx context.Context) ([]*entity.User, error) {
 	var result []*entity.User
 	for rows.Next() {
 		e := entity.User{}
-		if err := rows.Scan(&e.Username, &e.Email, &e.EmailVerified, &e.AuthTrade); err != nil {
+		if err := rows.Scan(&e.ID, &e.Username, &e.Email, &e.EmailVerified, &e.AuthTrade, &e.Role); err != nil {
 			return nil, err
 		}
 		result = append(result, &e)
@@ -129,12 +144,12 @@ func (r *system) QueryAllUser(ctx context.Context) ([]*entity.User, error) {
 	return result, nil
 }
 
-func (r *system) InsertOrUpdatePushToken(ctx context.Context, token, username string, enabled bool) error {
+func (r *system) InsertOrUpdatePushToken(ctx context.Context, token, username string, enabled, orderPush bool) error {
 	dbToken, err := r.GetPushToken(ctx, token)
 	if err != nil {
 		return err
 	} else if dbToken != nil {
-		return r.updatePushToken(ctx, token, enabled)
+		return r.updatePushToken(ctx, token, enabled, orderPush)
 	}
 
 	userID, err := r.queryUserIDByUsername(ctx, username)
@@ -145,8 +160,8 @@ func (r *system) InsertOrUpdatePushToken(ctx context.Context, token, username st
 	}
 
 	builder := r.Builder.Insert(tableNameSystemPushToken).
-		Columns("created, token, user_id, enabled").
-		Values(time.Now(), token, userID, enabled)
+		Columns("created, token, user_id, enabled, order_push").
+		Values(time.Now(), token, userID, enabled, orderPush)
 
 	tx, err := r.BeginTransaction()
 	if err != nil {
@@ -164,10 +179,11 @@ func (r *system) InsertOrUpdatePushToken(ctx context.Context, token, username st
 	return nil
 }
 
-func (r *system) updatePushToken(ctx context.Context, token string, enabled bool) error {
+func (r *system) updatePushToken(ctx context.Context, token string, enabled, orderPush bool) error {
 	builder := r.Builder.Update(tableNameSystemPushToken).
 		Set("created", time.Now()).
 		Set("enabled", enabled).
+		Set("order_push", orderPush).
 		Where("token = ?", token)
 
 	tx, err := r.BeginTransaction()
@@ -188,7 +204,7 @@ func (r *system) updatePushToken(ctx context.Context, token string, enabled bool
 
 func (r *system) GetPushToken(ctx context.Context, token string) (*entity.PushToken, error) {
 	sql, arg, err := r.Builder.
-		Select("created, token, user_id, enabled").
+		Select("created, token, user_id, enabled, order_push").
 		From(tableNameSystemPushToken).
 		Where("token = ?", token).
 		ToSql()
@@ -198,7 +214,7 @@ func (r *system) GetPushToken(ctx context.Context, token string) (*entity.PushTo
 
 	row := r.Pool().QueryRow(ctx, sql, arg...)
 	e := entity.PushToken{}
-	if err := row.Scan(&e.Created, &e.Token, &e.UserID, &e.Enabled); err != nil {
+	if err := row.Scan(&e.Created, &e.Token, &e.UserID, &e.Enabled, &e.OrderPush); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil
 		}
@@ -237,6 +253,88 @@ func (r *system) GetAllPushTokens(ctx context.Context) ([]string, error) {
 	return result, nil
 }
 
+func (r *system) QueryPushTokensByUserID(ctx context.Context, userID int) ([]string, error) {
+	sql, arg, err := r.Builder.
+		Select("token").
+		From(tableNameSystemPushToken).
+		Where(squirrel.Eq{"user_id": userID}).
+		Where(squirrel.Eq{"enabled": true}).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.Pool().Query(ctx, sql, arg...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []string
+	for rows.Next() {
+		var token string
+		if err := rows.Scan(&token); err != nil {
+			return nil, err
+		}
+		if token == "" {
+			continue
+		}
+		result = append(result, token)
+	}
+	return result, nil
+}
+
+// QueryOrderNotifyUserID returns ids of the given users and users with a token opted in to order updates.
+func (r *system) QueryOrderNotifyUserID(ctx context.Context, usernames []string) ([]int, error) {
+	sql, arg, err := r.Builder.
+		Select("DISTINCT a.id").
+		From(tableNameSystemAccount + " a").
+		LeftJoin(tableNameSystemPushToken + " t ON t.user_id = a.id AND t.enabled AND t.order_push").
+		Where(squirrel.Or{
+			squirrel.Eq{"a.username": usernames},
+			squirrel.NotEq{"t.id": nil},
+		}).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.Pool().Query(ctx, sql, arg...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		result = append(result, id)
+	}
+	return result, nil
+}
+
+func (r *system) DeletePushTokens(ctx context.Context, tokens []string) error {
+	builder := r.Builder.Delete(tableNameSystemPushToken).Where(squirrel.Eq{"token": tokens})
+
+	tx, err := r.BeginTransaction()
+	if err != nil {
+		return err
+	}
+	defer r.EndTransaction(tx, err)
+	var sql string
+	var args []interface{}
+
+	if sql, args, err = builder.ToSql(); err != nil {
+		return err
+	} else if _, err = tx.Exec(ctx, sql, args...); err != nil {
+		return err
+	}
+	return nil
+}
+
 func (r *system) DeleteAllPushTokens(ctx context.Context) error {
 	builder := r.Builder.Delete(tableNameSystemPushToken)
 
@@ -256,32 +354,219 @@ func (r *system) DeleteAllPushTokens(ctx context.Context) error {
 	return nil
 }
 
-func (r *system) GetLastJWT(ctx context.Context) (string, error) {
+func (r *system) InsertJWT(ctx context.Context, jwt string) error {
+	builder := r.Builder.Insert(tableNameSystemJWT).
+		Columns("key, created").
+		Values(jwt, time.Now())
+
+	tx, err := r.BeginTransaction()
+	if err != nil {
+		return err
+	}
+	defer r.EndTransaction(tx, err)
+	var sql string
+	var args []interface{}
+
+	if sql, args, err = builder.ToSql(); err != nil {
+		return err
+	} else if _, err = tx.Exec(ctx, sql, args...); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *system) QueryAllRole(ctx context.Context) ([]*entity.Role, error) {
 	sql, arg, err := r.Builder.
-		Select("key").
-		From(tableNameSystemJWT).
+		Select("name, description, permission").
+		From(tableNameSystemRole).
+		LeftJoin("system_role_permission ON system_role.name = system_role_permission.role").
+		OrderBy("name ASC", "permission ASC").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.Pool().Query(ctx, sql, arg...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*entity.Role
+	roleMap := make(map[string]*entity.Role)
+	for rows.Next() {
+		var name, description string
+		var permission *string
+		if err := rows.Scan(&name, &description, &permission); err != nil {
+			return nil, err
+		}
+		role, ok := roleMap[name]
+		if !ok {
+			role = &entity.Role{Name: name, Description: description}
+			roleMap[name] = role
+			result = append(result, role)
+		}
+		if permission != nil {
+			role.Permissions = append(role.Permissions, entity.Permission(*permission))
+		}
+	}
+	return result, nil
+}
+
+func (r *system) UpdateUserRole(ctx context.Context, username, role string) error {
+	builder := r.Builder.Update(tableNameSystemAccount).
+		Set("role", role).
+		Where("username = ?", username)
+
+	tx, err := r.BeginTransaction()
+	if err != nil {
+		return err
+	}
+	defer r.EndTransaction(tx, err)
+	var sql string
+	var args []interface{}
+
+	if sql, args, err = builder.ToSql(); err != nil {
+		return err
+	} else if _, err = tx.Exec(ctx, sql, args...); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *system) UpdateUserPassword(ctx context.Context, username, password string) error {
+	builder := r.Builder.Update(tableNameSystemAccount).
+		Set("password", password).
+		Where("username = ?", username)
+
+	tx, err := r.BeginTransaction()
+	if err != nil {
+		return err
+	}
+	defer r.EndTransaction(tx, err)
+	var sql string
+	var args []interface{}
+
+	if sql, args, err = builder.ToSql(); err != nil {
+		return err
+	} else if _, err = tx.Exec(ctx, sql, args...); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *system) UpdateUserTokenValidAfter(ctx context.Context, username string, t time.Time) error {
+	builder := r.Builder.Update(tableNameSystemAccount).
+		Set("token_valid_after", t).
+		Where("username = ?", username)
+
+	tx, err := r.BeginTransaction()
+	if err != nil {
+		return err
+	}
+	defer r.EndTransaction(tx, err)
+	var sql string
+	var args []interface{}
+
+	if sql, args, err = builder.ToSql(); err != nil {
+		return err
+	} else if _, err = tx.Exec(ctx, sql, args...); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *system) InsertPasswordReset(ctx context.Context, t *entity.PasswordReset) error {
+	builder := r.Builder.Insert(tableNameSystemPasswordReset).
+		Columns("user_id, code_hash, expire_at, attempts, used, created").
+		Values(t.UserID, t.CodeHash, t.ExpireAt, t.Attempts, t.Used, t.Created)
+
+	tx, err := r.BeginTransaction()
+	if err != nil {
+		return err
+	}
+	defer r.EndTransaction(tx, err)
+	var sql string
+	var args []interface{}
+
+	if sql, args, err = builder.ToSql(); err != nil {
+		return err
+	} else if _, err = tx.Exec(ctx, sql, args...); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *system) QueryLastPasswordReset(ctx context.Context, userID int) (*entity.PasswordReset, error) {
+	sql, arg, err := r.Builder.
+		Select("id, user_id, code_hash, expire_at, attempts, used, created").
+		From(tableNameSystemPasswordReset).
+		Where(squirrel.Eq{"user_id": userID}).
 		OrderBy("id DESC").
 		Limit(1).
 		ToSql()
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	rows := r.Pool().QueryRow(ctx, sql, arg...)
-	var result string
-	if err := rows.Scan(&result); err != nil {
+	row := r.Pool().QueryRow(ctx, sql, arg...)
+	e := entity.PasswordReset{}
+	if err := row.Scan(&e.ID, &e.UserID, &e.CodeHash, &e.ExpireAt, &e.Attempts, &e.Used, &e.Created); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return "", nil
+			return nil, nil
 		}
-		return "", err
+		return nil, err
 	}
-	return result, nil
+	return &e, nil
 }
 
-func (r *system) InsertJWT(ctx context.Context, jwt string) error {
-	builder := r.Builder.Insert(tableNameSystemJWT).
-		Columns("key, created").
-		Values(jwt, time.Now())
+func (r *system) IncreasePasswordResetAttempts(ctx context.Context, id int) error {
+	builder := r.Builder.Update(tableNameSystemPasswordReset).
+		Set("attempts", squirrel.Expr("attempts + 1")).
+		Where(squirrel.Eq{"id": id})
+
+	tx, err := r.BeginTransaction()
+	if err != nil {
+		return err
+	}
+	defer r.EndTransaction(tx, err)
+	var sql string
+	var args []interface{}
+
+	if sql, args, err = builder.ToSql(); err != nil {
+		return err
+	} else if _, err = tx.Exec(ctx, sql, args...); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *system) UsePasswordResetByUserID(ctx context.Context, userID int) error {
+	builder := r.Builder.Update(tableNameSystemPasswordReset).
+		Set("used", true).
+		Where(squirrel.Eq{"user_id": userID}).
+		Where(squirrel.Eq{"used": false})
+
+	tx, err := r.BeginTransaction()
+	if err != nil {
+		return err
+	}
+	defer r.EndTransaction(tx, err)
+	var sql string
+	var args []interface{}
+
+	if sql, args, err = builder.ToSql(); err != nil {
+		return err
+	} else if _, err = tx.Exec(ctx, sql, args...); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *system) InsertEmailVerification(ctx context.Context, t *entity.EmailVerification) error {
+	builder := r.Builder.Insert(tableNameSystemEmailVerify).
+		Columns("user_id, code_hash, expire_at, attempts, used, created").
+		Values(t.UserID, t.CodeHash, t.ExpireAt, t.Attempts, t.Used, t.Created)
 
 	tx, err := r.BeginTransaction()
 	if err != nil {
@@ -298,3 +583,811 @@ func (r *system) InsertJWT(ctx context.Context, jwt string) error {
 	}
 	return nil
 }
+
+func (r *system) QueryLastEmailVerification(ctx context.Context, userID int) (*entity.EmailVerification, error) {
+	sql, arg, err := r.Builder.
+		Select("id, user_id, code_hash, expire_at, attempts, used, created").
+		From(tableNameSystemEmailVerify).
+		Where(squirrel.Eq{"user_id": userID}).
+		OrderBy("id DESC").
+		Limit(1).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	row := r.Pool().QueryRow(ctx, sql, arg...)
+	e := entity.EmailVerification{}
+	if err := row.Scan(&e.ID, &e.UserID, &e.CodeHash, &e.ExpireAt, &e.Attempts, &e.Used, &e.Created); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &e, nil
+}
+
+func (r *system) IncreaseEmailVerificationAttempts(ctx context.Context, id int) error {
+	builder := r.Builder.Update(tableNameSystemEmailVerify).
+		Set("attempts", squirrel.Expr("attempts + 1")).
+		Where(squirrel.Eq{"id": id})
+
+	tx, err := r.BeginTransaction()
+	if err != nil {
+		return err
+	}
+	defer r.EndTransaction(tx, err)
+	var sql string
+	var args []interface{}
+
+	if sql, args, err = builder.ToSql(); err != nil {
+		return err
+	} else if _, err = tx.Exec(ctx, sql, args...); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *system) UseEmailVerificationByUserID(ctx context.Context, userID int) error {
+	builder := r.Builder.Update(tableNameSystemEmailVerify).
+		Set("used", true).
+		Where(squirrel.Eq{"user_id": userID}).
+		Where(squirrel.Eq{"used": false})
+
+	tx, err := r.BeginTransaction()
+	if err != nil {
+		return err
+	}
+	defer r.EndTransaction(tx, err)
+	var sql string
+	var args []interface{}
+
+	if sql, args, err = builder.ToSql(); err != nil {
+		return err
+	} else if _, err = tx.Exec(ctx, sql, args...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteUnverifiedUserCreatedBefore removes unverified users without any pending verification code, returns deleted usernames.
+func (r *system) DeleteUnverifiedUserCreatedBefore(ctx context.Context, before time.Time) ([]string, error) {
+	sql, arg, err := r.Builder.
+		Select("id, username").
+		From(tableNameSystemAccount).
+		Where(squirrel.Eq{"email_verified": false}).
+		Where(squirrel.Lt{"created": before}).
+		Where(fmt.Sprintf("NOT EXISTS (SELECT 1 FROM %s v WHERE v.user_id = %s.id AND v.used = FALSE AND v.expire_at > ?)", tableNameSystemEmailVerify, tableNameSystemAccount), time.Now()).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.Pool().Query(ctx, sql, arg...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int
+	var usernames []string
+	for rows.Next() {
+		var id int
+		var username string
+		if err = rows.Scan(&id, &username); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+		usernames = append(usernames, username)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	builders := []squirrel.DeleteBuilder{
+		r.Builder.Delete(tableNameSystemEmailVerify).Where(squirrel.Eq{"user_id": ids}),
+		r.Builder.Delete(tableNameSystemPasswordReset).Where(squirrel.Eq{"user_id": ids}),
+		r.Builder.Delete(tableNameSystemPushToken).Where(squirrel.Eq{"user_id": ids}),
+		r.Builder.Delete(tableNameSystemSession).Where(squirrel.Eq{"user_id": ids}),
+		r.Builder.Delete(tableNameSystemTOTPBackupCode).Where(squirrel.Eq{"user_id": ids}),
+		r.Builder.Delete(tableNameSystemTOTP).Where(squirrel.Eq{"user_id": ids}),
+		r.Builder.Delete(tableNameSystemAPIKey).Where(squirrel.Eq{"user_id": ids}),
+		r.Builder.Delete(tableNameAlertHistory).Where(squirrel.Eq{"user_id": ids}),
+		r.Builder.Delete(tableNameAlertRule).Where(squirrel.Eq{"user_id": ids}),
+		r.Builder.Delete(tableNameSystemNotifyAddress).Where(squirrel.Eq{"user_id": ids}),
+		r.Builder.Delete(tableNameSystemNotifyPreference).Where(squirrel.Eq{"user_id": ids}),
+		r.Builder.Delete(tableNameWatchlistItem).Where(squirrel.Eq{"user_id": ids}),
+		r.Builder.Delete(tableNameWatchlist).Where(squirrel.Eq{"user_id": ids}),
+		r.Builder.Delete(tableNameSystemAccount).Where(squirrel.Eq{"id": ids}),
+	}
+
+	tx, err := r.BeginTransaction()
+	if err != nil {
+		return nil, err
+	}
+	defer r.EndTransaction(tx, err)
+
+	for _, builder := range builders {
+		var args []interface{}
+		if sql, args, err = builder.ToSql(); err != nil {
+			return nil, err
+		} else if _, err = tx.Exec(ctx, sql, args...); err != nil {
+			return nil, err
+		}
+	}
+	return usernames, nil
+}
+
+// QueryValidJWTKey returns keys not retired yet, ordered from oldest to newest.
+func (r *system) QueryValidJWTKey(ctx context.Context) ([]*entity.JWTKey, error) {
+	sql, arg, err := r.Builder.
+		Select("id, key, created, retire_at").
+		From(tableNameSystemJWT).
+		Where(squirrel.Or{
+			squirrel.Eq{"retire_at": nil},
+			squirrel.Gt{"retire_at": time.Now()},
+		}).
+		OrderBy("id ASC").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.Pool().Query(ctx, sql, arg...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*entity.JWTKey
+	for rows.Next() {
+		e := entity.JWTKey{}
+		if err := rows.Scan(&e.ID, &e.Key, &e.Created, &e.RetireAt); err != nil {
+			return nil, err
+		}
+		result = append(result, &e)
+	}
+	return result, nil
+}
+
+// RotateJWTKey schedules all active keys to retire at retireAt, and inserts the new key.
+func (r *system) RotateJWTKey(ctx context.Context, key string, retireAt time.Time) error {
+	retire := r.Builder.Update(tableNameSystemJWT).
+		Set("retire_at", retireAt).
+		Where(squirrel.Or{
+			squirrel.Eq{"retire_at": nil},
+			squirrel.Gt{"retire_at": retireAt},
+		})
+	insert := r.Builder.Insert(tableNameSystemJWT).
+		Columns("key, created").
+		Values(key, time.Now())
+
+	tx, err := r.BeginTransaction()
+	if err != nil {
+		return err
+	}
+	defer r.EndTransaction(tx, err)
+	var sql string
+	var args []interface{}
+
+	if sql, args, err = retire.ToSql(); err != nil {
+		return err
+	} else if _, err = tx.Exec(ctx, sql, args...); err != nil {
+		return err
+	}
+
+	if sql, args, err = insert.ToSql(); err != nil {
+		return err
+	} else if _, err = tx.Exec(ctx, sql, args...); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *system) InsertSession(ctx context.Context, t *entity.Session) error {
+	builder := r.Builder.Insert(tableNameSystemSession).
+		Columns("session_id, user_id, refresh_token_hash, user_agent, ip, created, last_seen, expire_at, revoked").
+		Values(t.SessionID, t.UserID, t.RefreshTokenHash, t.UserAgent, t.IP, t.Created, t.LastSeen, t.ExpireAt, t.Revoked)
+
+	tx, err := r.BeginTransaction()
+	if err != nil {
+		return err
+	}
+	defer r.EndTransaction(tx, err)
+	var sql string
+	var args []interface{}
+
+	if sql, args, err = builder.ToSql(); err != nil {
+		return err
+	} else if _, err = tx.Exec(ctx, sql, args...); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *system) QuerySessionBySessionID(ctx context.Context, sessionID string) (*entity.Session, error) {
+	return r.querySession(ctx, squirrel.Eq{"session_id": sessionID})
+}
+
+func (r *system) QuerySessionByRefreshTokenHash(ctx context.Context, hash string) (*entity.Session, error) {
+	return r.querySession(ctx, squirrel.Eq{"refresh_token_hash": hash})
+}
+
+func (r *system) querySession(ctx context.Context, where squirrel.Eq) (*entity.Session, error) {
+	sql, arg, err := r.Builder.
+		Select("id, session_id, user_id, refresh_token_hash, user_agent, ip, created, last_seen, expire_at, revoked").
+		From(tableNameSystemSession).
+		Where(where).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	row := r.Pool().QueryRow(ctx, sql, arg...)
+	e := entity.Session{}
+	if err := row.Scan(&e.ID, &e.SessionID, &e.UserID, &e.RefreshTokenHash, &e.UserAgent, &e.IP, &e.Created, &e.LastSeen, &e.ExpireAt, &e.Revoked); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &e, nil
+}
+
+func (r *system) QueryActiveSessionByUserID(ctx context.Context, userID int) ([]*entity.Session, error) {
+	sql, arg, err := r.Builder.
+		Select("id, session_id, user_id, refresh_token_hash, user_agent, ip, created, last_seen, expire_at, revoked").
+		From(tableNameSystemSession).
+		Where(squirrel.Eq{"user_id": userID}).
+		Where(squirrel.Eq{"revoked": false}).
+		Where(squirrel.Gt{"expire_at": time.Now()}).
+		OrderBy("last_seen DESC").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.Pool().Query(ctx, sql, arg...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*entity.Session
+	for rows.Next() {
+		e := entity.Session{}
+		if err := rows.Scan(&e.ID, &e.SessionID, &e.UserID, &e.RefreshTokenHash, &e.UserAgent, &e.IP, &e.Created, &e.LastSeen, &e.ExpireAt, &e.Revoked); err != nil {
+			return nil, err
+		}
+		result = append(result, &e)
+	}
+	return result, nil
+}
+
+func (r *system) UpdateSessionRefreshToken(ctx context.Context, t *entity.Session) error {
+	builder := r.Builder.Update(tableNameSystemSession).
+		Set("refresh_token_hash", t.RefreshTokenHash).
+		Set("user_agent", t.UserAgent).
+		Set("ip", t.IP).
+		Set("last_seen", t.LastSeen).
+		Set("expire_at", t.ExpireAt).
+		Where(squirrel.Eq{"session_id": t.SessionID})
+
+	tx, err := r.BeginTransaction()
+	if err != nil {
+		return err
+	}
+	defer r.EndTransaction(tx, err)
+	var sql string
+	var args []interface{}
+
+	if sql, args, err = builder.ToSql(); err != nil {
+		return err
+	} else if _, err = tx.Exec(ctx, sql, args...); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *system) RevokeSession(ctx context.Context, sessionID string) error {
+	builder := r.Builder.Update(tableNameSystemSession).
+		Set("revoked", true).
+		Where(squirrel.Eq{"session_id": sessionID})
+
+	tx, err := r.BeginTransaction()
+	if err != nil {
+		return err
+	}
+	defer r.EndTransaction(tx, err)
+	var sql string
+	var args []interface{}
+
+	if sql, args, err = builder.ToSql(); err != nil {
+		return err
+	} else if _, err = tx.Exec(ctx, sql, args...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// RevokeSessionByUserID revokes all active sessions of the user, returns revoked session ids.
+func (r *system) RevokeSessionByUserID(ctx context.Context, userID int) ([]string, error) {
+	builder := r.Builder.Update(tableNameSystemSession).
+		Set("revoked", true).
+		Where(squirrel.Eq{"user_id": userID}).
+		Where(squirrel.Eq{"revoked": false}).
+		Suffix("RETURNING session_id")
+
+	tx, err := r.BeginTransaction()
+	if err != nil {
+		return nil, err
+	}
+	defer r.EndTransaction(tx, err)
+	var sql string
+	var args []interface{}
+
+	if sql, args, err = builder.ToSql(); err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []string
+	for rows.Next() {
+		var sessionID string
+		if err = rows.Scan(&sessionID); err != nil {
+			return nil, err
+		}
+		result = append(result, sessionID)
+	}
+	return result, nil
+}
+
+// ReplaceTOTP removes existing secret and backup codes of the user, then inserts the new ones.
+func (r *system) ReplaceTOTP(ctx context.Context, t *entity.TOTP, backupCodeHashes []string) error {
+	builders := []interface {
+		ToSql() (string, []interface{}, error)
+	}{
+		r.Builder.Delete(tableNameSystemTOTPBackupCode).Where(squirrel.Eq{"user_id": t.UserID}),
+		r.Builder.Delete(tableNameSystemTOTP).Where(squirrel.Eq{"user_id": t.UserID}),
+		r.Builder.Insert(tableNameSystemTOTP).
+			Columns("user_id, secret, enabled, last_used_step, created").
+			Values(t.UserID, t.Secret, t.Enabled, t.LastUsedStep, t.Created),
+	}
+	if len(backupCodeHashes) > 0 {
+		insert := r.Builder.Insert(tableNameSystemTOTPBackupCode).Columns("user_id, code_hash")
+		for _, v := range backupCodeHashes {
+			insert = insert.Values(t.UserID, v)
+		}
+		builders = append(builders, insert)
+	}
+
+	tx, err := r.BeginTransaction()
+	if err != nil {
+		return err
+	}
+	defer r.EndTransaction(tx, err)
+
+	for _, builder := range builders {
+		var sql string
+		var args []interface{}
+		if sql, args, err = builder.ToSql(); err != nil {
+			return err
+		} else if _, err = tx.Exec(ctx, sql, args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *system) QueryTOTPByUserID(ctx context.Context, userID int) (*entity.TOTP, error) {
+	sql, arg, err := r.Builder.
+		Select("user_id, secret, enabled, last_used_step, created").
+		From(tableNameSystemTOTP).
+		Where(squirrel.Eq{"user_id": userID}).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	row := r.Pool().QueryRow(ctx, sql, arg...)
+	e := entity.TOTP{}
+	if err := row.Scan(&e.UserID, &e.Secret, &e.Enabled, &e.LastUsedStep, &e.Created); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &e, nil
+}
+
+func (r *system) QueryEnabledTOTPUserID(ctx context.Context) ([]int, error) {
+	sql, arg, err := r.Builder.
+		Select("user_id").
+		From(tableNameSystemTOTP).
+		Where(squirrel.Eq{"enabled": true}).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.Pool().Query(ctx, sql, arg...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []int
+	for rows.Next() {
+		var userID int
+		if err := rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+		result = append(result, userID)
+	}
+	return result, nil
+}
+
+func (r *system) UpdateTOTP(ctx context.Context, userID int, enabled bool, lastUsedStep int64) error {
+	builder := r.Builder.Update(tableNameSystemTOTP).
+		Set("enabled", enabled).
+		Set("last_used_step", lastUsedStep).
+		Where(squirrel.Eq{"user_id": userID})
+
+	tx, err := r.BeginTransaction()
+	if err != nil {
+		return err
+	}
+	defer r.EndTransaction(tx, err)
+	var sql string
+	var args []interface{}
+
+	if sql, args, err = builder.ToSql(); err != nil {
+		return err
+	} else if _, err = tx.Exec(ctx, sql, args...); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *system) DeleteTOTP(ctx context.Context, userID int) error {
+	builders := []squirrel.DeleteBuilder{
+		r.Builder.Delete(tableNameSystemTOTPBackupCode).Where(squirrel.Eq{"user_id": userID}),
+		r.Builder.Delete(tableNameSystemTOTP).Where(squirrel.Eq{"user_id": userID}),
+	}
+
+	tx, err := r.BeginTransaction()
+	if err != nil {
+		return err
+	}
+	defer r.EndTransaction(tx, err)
+
+	for _, builder := range builders {
+		var sql string
+		var args []interface{}
+		if sql, args, err = builder.ToSql(); err != nil {
+			return err
+		} else if _, err = tx.Exec(ctx, sql, args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UseTOTPBackupCode marks the backup code used, returns false if no unused code matched.
+func (r *system) UseTOTPBackupCode(ctx context.Context, userID int, codeHash string) (bool, error) {
+	builder := r.Builder.Update(tableNameSystemTOTPBackupCode).
+		Set("used", true).
+		Where(squirrel.Eq{"user_id": userID}).
+		Where(squirrel.Eq{"code_hash": codeHash}).
+		Where(squirrel.Eq{"used": false})
+
+	tx, err := r.BeginTransaction()
+	if err != nil {
+		return false, err
+	}
+	defer r.EndTransaction(tx, err)
+	var sql string
+	var args []interface{}
+
+	if sql, args, err = builder.ToSql(); err != nil {
+		return false, err
+	}
+	tag, err := tx.Exec(ctx, sql, args...)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *system) CountUnusedTOTPBackupCode(ctx context.Context, userID int) (int, error) {
+	sql, arg, err := r.Builder.
+		Select("COUNT(*)").
+		From(tableNameSystemTOTPBackupCode).
+		Where(squirrel.Eq{"user_id": userID}).
+		Where(squirrel.Eq{"used": false}).
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	if err := r.Pool().QueryRow(ctx, sql, arg...).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *system) UpdateUserAuthTrade(ctx context.Context, username string, authTrade bool) error {
+	builder := r.Builder.Update(tableNameSystemAccount).
+		Set("auth_trade", authTrade).
+		Where("username = ?", username)
+
+	tx, err := r.BeginTransaction()
+	if err != nil {
+		return err
+	}
+	defer r.EndTransaction(tx, err)
+	var sql string
+	var args []interface{}
+
+	if sql, args, err = builder.ToSql(); err != nil {
+		return err
+	} else if _, err = tx.Exec(ctx, sql, args...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// QuerySetting returns empty string if the key is not set.
+func (r *system) QuerySetting(ctx context.Context, key string) (string, error) {
+	sql, arg, err := r.Builder.
+		Select("value").
+		From(tableNameSystemSetting).
+		Where(squirrel.Eq{"key": key}).
+		ToSql()
+	if err != nil {
+		return "", err
+	}
+
+	var value string
+	if err := r.Pool().QueryRow(ctx, sql, arg...).Scan(&value); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", nil
+		}
+		return "", err
+	}
+	return value, nil
+}
+
+func (r *system) UpsertSetting(ctx context.Context, key, value string) error {
+	builder := r.Builder.Insert(tableNameSystemSetting).
+		Columns("key, value, updated").
+		Values(key, value, time.Now()).
+		Suffix(`ON CONFLICT ("key") DO UPDATE SET "value" = EXCLUDED."value", "updated" = EXCLUDED."updated"`)
+
+	tx, err := r.BeginTransaction()
+	if err != nil {
+		return err
+	}
+	defer r.EndTransaction(tx, err)
+	var sql string
+	var args []interface{}
+
+	if sql, args, err = builder.ToSql(); err != nil {
+		return err
+	} else if _, err = tx.Exec(ctx, sql, args...); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *system) InsertAPIKey(ctx context.Context, t *entity.APIKey) error {
+	scopes := make([]string, len(t.Scopes))
+	for i, v := range t.Scopes {
+		scopes[i] = string(v)
+	}
+	ipAllowlist := t.IPAllowlist
+	if ipAllowlist == nil {
+		ipAllowlist = []string{}
+	}
+
+	builder := r.Builder.Insert(tableNameSystemAPIKey).
+		Columns("key_id, user_id, name, key_hash, scopes, ip_allowlist, expire_at, revoked, created").
+		Values(t.KeyID, t.UserID, t.Name, t.KeyHash, scopes, ipAllowlist, t.ExpireAt, t.Revoked, t.Created)
+
+	tx, err := r.BeginTransaction()
+	if err != nil {
+		return err
+	}
+	defer r.EndTransaction(tx, err)
+	var sql string
+	var args []interface{}
+
+	if sql, args, err = builder.ToSql(); err != nil {
+		return err
+	} else if _, err = tx.Exec(ctx, sql, args...); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *system) QueryAPIKeyByKeyID(ctx context.Context, keyID string) (*entity.APIKey, error) {
+	result, err := r.queryAPIKey(ctx, squirrel.Eq{"key_id": keyID})
+	if err != nil {
+		return nil, err
+	}
+	if len(result) == 0 {
+		return nil, nil
+	}
+	return result[0], nil
+}
+
+func (r *system) QueryAPIKeyByUserID(ctx context.Context, userID int) ([]*entity.APIKey, error) {
+	return r.queryAPIKey(ctx, squirrel.Eq{"user_id": userID})
+}
+
+func (r *system) queryAPIKey(ctx context.Context, where squirrel.Eq) ([]*entity.APIKey, error) {
+	sql, arg, err := r.Builder.
+		Select("id, key_id, user_id, name, key_hash, scopes, ip_allowlist, expire_at, last_used, revoked, created").
+		From(tableNameSystemAPIKey).
+		Where(where).
+		OrderBy("id ASC").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.Pool().Query(ctx, sql, arg...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*entity.APIKey
+	for rows.Next() {
+		e := entity.APIKey{}
+		var scopes []string
+		if err := rows.Scan(&e.ID, &e.KeyID, &e.UserID, &e.Name, &e.KeyHash, &scopes, &e.IPAllowlist, &e.ExpireAt, &e.LastUsed, &e.Revoked, &e.Created); err != nil {
+			return nil, err
+		}
+		for _, v := range scopes {
+			e.Scopes = append(e.Scopes, entity.APIKeyScope(v))
+		}
+		result = append(result, &e)
+	}
+	return result, nil
+}
+
+func (r *system) RevokeAPIKey(ctx context.Context, keyID string) error {
+	builder := r.Builder.Update(tableNameSystemAPIKey).
+		Set("revoked", true).
+		Where(squirrel.Eq{"key_id": keyID})
+
+	tx, err := r.BeginTransaction()
+	if err != nil {
+		return err
+	}
+	defer r.EndTransaction(tx, err)
+	var sql string
+	var args []interface{}
+
+	if sql, args, err = builder.ToSql(); err != nil {
+		return err
+	} else if _, err = tx.Exec(ctx, sql, args...); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *system) UpdateAPIKeyLastUsed(ctx context.Context, keyID string, lastUsed time.Time) error {
+	builder := r.Builder.Update(tableNameSystemAPIKey).
+		Set("last_used", lastUsed).
+		Where(squirrel.Eq{"key_id": keyID})
+
+	tx, err := r.BeginTransaction()
+	if err != nil {
+		return err
+	}
+	defer r.EndTransaction(tx, err)
+	var sql string
+	var args []interface{}
+
+	if sql, args, err = builder.ToSql(); err != nil {
+		return err
+	} else if _, err = tx.Exec(ctx, sql, args...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// QueryNotifyPreference returns an empty preference if the user never set one.
+func (r *system) QueryNotifyPreference(ctx context.Context, userID int) (*entity.NotifyPreference, error) {
+	result := &entity.NotifyPreference{
+		Channels: make(map[entity.NotifyEvent][]entity.NotifyChannel),
+	}
+
+	sql, arg, err := r.Builder.
+		Select("slack_webhook, webhook_url").
+		From(tableNameSystemNotifyAddress).
+		Where(squirrel.Eq{"user_id": userID}).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+	if err = r.Pool().QueryRow(ctx, sql, arg...).Scan(&result.SlackWebhook, &result.WebhookURL); err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+
+	sql, arg, err = r.Builder.
+		Select("event, channels").
+		From(tableNameSystemNotifyPreference).
+		Where(squirrel.Eq{"user_id": userID}).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.Pool().Query(ctx, sql, arg...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var event string
+		var channels []string
+		if err := rows.Scan(&event, &channels); err != nil {
+			return nil, err
+		}
+		arr := []entity.NotifyChannel{}
+		for _, v := range channels {
+			arr = append(arr, entity.NotifyChannel(v))
+		}
+		result.Channels[entity.NotifyEvent(event)] = arr
+	}
+	return result, nil
+}
+
+// ReplaceNotifyPreference overwrites addresses and all event channels of the user.
+func (r *system) ReplaceNotifyPreference(ctx context.Context, userID int, t *entity.NotifyPreference) error {
+	builders := []squirrel.Sqlizer{
+		r.Builder.Insert(tableNameSystemNotifyAddress).
+			Columns("user_id, slack_webhook, webhook_url, updated").
+			Values(userID, t.SlackWebhook, t.WebhookURL, time.Now()).
+			Suffix(`ON CONFLICT ("user_id") DO UPDATE SET "slack_webhook" = EXCLUDED."slack_webhook", "webhook_url" = EXCLUDED."webhook_url", "updated" = EXCLUDED."updated"`),
+		r.Builder.Delete(tableNameSystemNotifyPreference).Where(squirrel.Eq{"user_id": userID}),
+	}
+	if len(t.Channels) != 0 {
+		insert := r.Builder.Insert(tableNameSystemNotifyPreference).Columns("user_id, event, channels")
+		for event, channels := range t.Channels {
+			arr := []string{}
+			for _, v := range channels {
+				arr = append(arr, string(v))
+			}
+			insert = insert.Values(userID, string(event), arr)
+		}
+		builders = append(builders, insert)
+	}
+
+	tx, err := r.BeginTransaction()
+	if err != nil {
+		return err
+	}
+	defer r.EndTransaction(tx, err)
+
+	for _, builder := range builders {
+		var sql string
+		var args []interface{}
+		if sql, args, err = builder.ToSql(); err != nil {
+			return err
+		} else if _, err = tx.Exec(ctx, sql, args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -39,11 +39,11 @@ func (r *target) InsertOrUpdateTargetArr(ctx context.Context, t []*entity.StockT
 	var args []interface{}
 
 	var insert int
-	builder := r.Builder.Insert(tableNameTarget).Columns("stock_num, trade_day, rank, volume")
+	builder := r.Builder.Insert(tableNameTarget).Columns("stock_num, trade_day, rank, volume, reason")
 	for _, v := range t {
 		if _, ok := inDBTargetsMap[v.StockNum]; !ok {
 			insert++
-			builder = builder.Values(v.StockNum, v.TradeDay, v.Rank, v.Volume)
+			builder = builder.Values(v.StockNum, v.TradeDay, v.Rank, v.Volume, v.Reason)
 		} else {
 			b := r.Builder.
 				Update(tableNameTarget).
@@ -51,6 +51,7 @@ func (r *target) InsertOrUpdateTargetArr(ctx context.Context, t []*entity.StockT
 				Set("trade_day", v.TradeDay).
 				Set("rank", v.Rank).
 				Set("volume", v.Volume).
+				Set("reason", v.Reason).
 				Where("stock_num = ?", v.StockNum).
 				Where("trade_day = ?", v.TradeDay)
 			if sql, args, err = b.ToSql(); err != nil {
@@ -74,7 +75,7 @@ func (r *target) InsertOrUpdateTargetArr(ctx context.Context, t []*entity.StockT
 // QueryTargetsByTradeDay -.
 func (r *target) QueryTargetsByTradeDay(ctx context.Context, tradeDay time.Time) ([]*entity.StockTarget, error) {
 	sql, args, err := r.Builder.
-		Select("id, rank, volume, trade_day, stock_num, number, name, exchange, category, day_trade, last_close, update_date").
+		Select("id, rank, volume, trade_day, reason, stock_num, number, name, exchange, category, day_trade, last_close, update_date").
 		From(tableNameTarget).
 		Where("trade_day = ?", tradeDay).
 		OrderBy("rank ASC").
@@ -93,7 +94,7 @@ func (r *target) QueryTargetsByTradeDay(ctx context.Context, tradeDay time.Time)
 	for rows.Next() {
 		e := entity.StockTarget{Stock: new(entity.Stock)}
 		if err := rows.Scan(
-			&e.ID, &e.Rank, &e.Volume, &e.TradeDay,
+			&e.ID, &e.Rank, &e.Volume, &e.TradeDay, &e.Reason,
 			&e.StockNum, &e.Stock.Number, &e.Stock.Name, &e.Stock.Exchange, &e.Stock.Category, &e.Stock.DayTrade, &e.Stock.LastClose, &e.Stock.UpdateDate,
 		); err != nil {
 			return nil, err
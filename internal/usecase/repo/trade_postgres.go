@@ -154,7 +154,7 @@ func (r *trade) QueryAllStockOrder(ctx context.Context) ([]*entity.StockOrder, e
 
 // InsertOrUpdateStockTradeBalance -.
 func (r *trade) InsertOrUpdateStockTradeBalance(ctx context.Context, t *entity.StockTradeBalance) error {
-	dbTradeBalance, err := r.queryStockTradeBalanceByDate(ctx, t.TradeDay)
+	dbTradeBalance, err := r.QueryStockTradeBalanceByDate(ctx, t.TradeDay)
 	if err != nil {
 		return err
 	}
@@ -197,8 +197,8 @@ func (r *trade) InsertOrUpdateStockTradeBalance(ctx context.Context, t *entity.S
 	return nil
 }
 
-// queryStockTradeBalanceByDate -.
-func (r *trade) queryStockTradeBalanceByDate(ctx context.Context, date time.Time) (*entity.StockTradeBalance, error) {
+// QueryStockTradeBalanceByDate -.
+func (r *trade) QueryStockTradeBalanceByDate(ctx context.Context, date time.Time) (*entity.StockTradeBalance, error) {
 	sql, arg, err := r.Builder.
 		Select("trade_count, forward, reverse, original_balance, discount, total, trade_day").
 		From(tableNameTradeStockBalance).
@@ -406,8 +406,8 @@ func (r *trade) QueryAllFutureOrderByDate(ctx context.Context, timeRange []time.
 	return result, nil
 }
 
-// queryFutureTradeBalanceByDate -.
-func (r *trade) queryFutureTradeBalanceByDate(ctx context.Context, date time.Time) (*entity.FutureTradeBalance, error) {
+// QueryFutureTradeBalanceByDate -.
+func (r *trade) QueryFutureTradeBalanceByDate(ctx context.Context, date time.Time) (*entity.FutureTradeBalance, error) {
 	sql, arg, err := r.Builder.
 		Select("trade_count, forward, reverse, total, trade_day").
 		From(tableNameFutureTradeBalance).
@@ -430,7 +430,7 @@ func (r *trade) queryFutureTradeBalanceByDate(ctx context.Context, date time.Tim
 
 // InsertOrUpdateFutureTradeBalance -.
 func (r *trade) InsertOrUpdateFutureTradeBalance(ctx context.Context, t *entity.FutureTradeBalance) error {
-	dbTradeBalance, err := r.queryFutureTradeBalanceByDate(ctx, t.TradeDay)
+	dbTradeBalance, err := r.QueryFutureTradeBalanceByDate(ctx, t.TradeDay)
 	if err != nil {
 		return err
 	}
@@ -471,6 +471,217 @@ func (r *trade) InsertOrUpdateFutureTradeBalance(ctx context.Context, t *entity.
 	return nil
 }
 
+// QueryAllOptionTradeBalance -.
+func (r *trade) QueryAllOptionTradeBalance(ctx context.Context) ([]*entity.OptionTradeBalance, error) {
+	sql, _, err := r.Builder.
+		Select("trade_count, forward, reverse, total, trade_day").
+		From(tableNameOptionTradeBalance).OrderBy("trade_day ASC").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.Pool().Query(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*entity.OptionTradeBalance
+	for rows.Next() {
+		e := entity.OptionTradeBalance{}
+		if err := rows.Scan(&e.TradeCount, &e.Forward, &e.Reverse, &e.Total, &e.TradeDay); err != nil {
+			return nil, err
+		}
+		result = append(result, &e)
+	}
+	return result, nil
+}
+
+// QueryOptionTradeBalanceByDate -.
+func (r *trade) QueryOptionTradeBalanceByDate(ctx context.Context, date time.Time) (*entity.OptionTradeBalance, error) {
+	sql, arg, err := r.Builder.
+		Select("trade_count, forward, reverse, total, trade_day").
+		From(tableNameOptionTradeBalance).
+		Where(squirrel.Eq{"trade_day": date}).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	row := r.Pool().QueryRow(ctx, sql, arg...)
+	e := entity.OptionTradeBalance{}
+	if err := row.Scan(&e.TradeCount, &e.Forward, &e.Reverse, &e.Total, &e.TradeDay); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &e, nil
+}
+
+// InsertOrUpdateOptionTradeBalance -.
+func (r *trade) InsertOrUpdateOptionTradeBalance(ctx context.Context, t *entity.OptionTradeBalance) error {
+	dbTradeBalance, err := r.QueryOptionTradeBalanceByDate(ctx, t.TradeDay)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.BeginTransaction()
+	if err != nil {
+		return err
+	}
+	defer r.EndTransaction(tx, err)
+	var sql string
+	var args []interface{}
+
+	if dbTradeBalance == nil {
+		builder := r.Builder.
+			Insert(tableNameOptionTradeBalance).
+			Columns("trade_count, forward, reverse, total, trade_day")
+		builder = builder.Values(t.TradeCount, t.Forward, t.Reverse, t.Total, t.TradeDay)
+		if sql, args, err = builder.ToSql(); err != nil {
+			return err
+		} else if _, err = tx.Exec(ctx, sql, args...); err != nil {
+			return err
+		}
+	} else {
+		builder := r.Builder.
+			Update(tableNameOptionTradeBalance).
+			Set("trade_count", t.TradeCount).
+			Set("forward", t.Forward).
+			Set("reverse", t.Reverse).
+			Set("total", t.Total).
+			Where(squirrel.Eq{"trade_day": t.TradeDay})
+		if sql, args, err = builder.ToSql(); err != nil {
+			return err
+		} else if _, err = tx.Exec(ctx, sql, args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+const optionOrderColumns = "order_id, status, order_time, trade_option_order.code, action, price, quantity, basic_option.code, symbol, name, category, delivery_month, delivery_date, strike_price, option_right, underlying_kind, unit, limit_up, limit_down, reference, update_date"
+
+func scanOptionOrder(row pgx.Row) (*entity.OptionOrder, error) {
+	e := entity.OptionOrder{Option: new(entity.Option)}
+	if err := row.Scan(&e.OrderID, &e.Status, &e.OrderTime, &e.Code, &e.Action, &e.Price, &e.Quantity,
+		&e.Option.Code, &e.Option.Symbol, &e.Option.Name, &e.Option.Category, &e.Option.DeliveryMonth, &e.Option.DeliveryDate, &e.Option.StrikePrice, &e.Option.OptionRight,
+		&e.Option.UnderlyingKind, &e.Option.Unit, &e.Option.LimitUp, &e.Option.LimitDown, &e.Option.Reference, &e.Option.UpdateDate); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// queryOptionOrderByID -.
+func (r *trade) queryOptionOrderByID(ctx context.Context, orderID string) (*entity.OptionOrder, error) {
+	sql, arg, err := r.Builder.
+		Select(optionOrderColumns).
+		From(tableNameTradeOptionOrder).
+		Where(squirrel.Eq{"order_id": orderID}).
+		Join("basic_option ON trade_option_order.code = basic_option.code").ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := scanOptionOrder(r.Pool().QueryRow(ctx, sql, arg...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return e, nil
+}
+
+// InsertOrUpdateOptionOrderByOrderID -.
+func (r *trade) InsertOrUpdateOptionOrderByOrderID(ctx context.Context, t *entity.OptionOrder) error {
+	dbOrder, err := r.queryOptionOrderByID(ctx, t.OrderID)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.BeginTransaction()
+	if err != nil {
+		return err
+	}
+	defer r.EndTransaction(tx, err)
+	var sql string
+	var args []interface{}
+
+	if dbOrder == nil {
+		builder := r.Builder.
+			Insert(tableNameTradeOptionOrder).
+			Columns("order_id, status, order_time, code, action, price, quantity")
+		builder = builder.Values(t.OrderID, t.Status, t.OrderTime, t.Code, t.Action, t.Price, t.Quantity)
+		if sql, args, err = builder.ToSql(); err != nil {
+			return err
+		} else if _, err = tx.Exec(ctx, sql, args...); err != nil {
+			return err
+		}
+	} else if !cmp.Equal(t.OrderDetail, dbOrder.OrderDetail) || t.Quantity != dbOrder.Quantity {
+		builder := r.Builder.
+			Update(tableNameTradeOptionOrder).
+			Set("status", t.Status).
+			Set("order_time", t.OrderTime).
+			Set("code", t.Code).
+			Set("action", t.Action).
+			Set("price", t.Price).
+			Set("quantity", t.Quantity).
+			Where(squirrel.Eq{"order_id": t.OrderID})
+		if sql, args, err = builder.ToSql(); err != nil {
+			return err
+		} else if _, err = tx.Exec(ctx, sql, args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// QueryAllOptionOrder -.
+func (r *trade) QueryAllOptionOrder(ctx context.Context) ([]*entity.OptionOrder, error) {
+	return r.queryOptionOrder(ctx, r.Builder.
+		Select(optionOrderColumns).
+		From(tableNameTradeOptionOrder).
+		OrderBy("order_time ASC").
+		Join("basic_option ON trade_option_order.code = basic_option.code"))
+}
+
+// QueryAllOptionOrderByDate -.
+func (r *trade) QueryAllOptionOrderByDate(ctx context.Context, timeRange []time.Time) ([]*entity.OptionOrder, error) {
+	return r.queryOptionOrder(ctx, r.Builder.
+		Select(optionOrderColumns).
+		From(tableNameTradeOptionOrder).
+		Where(squirrel.GtOrEq{"order_time": timeRange[0]}).
+		Where(squirrel.Lt{"order_time": timeRange[1]}).
+		OrderBy("order_time ASC").
+		Join("basic_option ON trade_option_order.code = basic_option.code"))
+}
+
+func (r *trade) queryOptionOrder(ctx context.Context, builder squirrel.SelectBuilder) ([]*entity.OptionOrder, error) {
+	sql, arg, err := builder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.Pool().Query(ctx, sql, arg...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*entity.OptionOrder
+	for rows.Next() {
+		e, err := scanOptionOrder(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, e)
+	}
+	return result, nil
+}
+
 func (r *trade) QueryLastAccountBalance(ctx context.Context) (*entity.AccountBalance, error) {
 	sql, arg, err := r.Builder.
 		Select("id, date, balance, today_margin, available_margin, yesterday_margin, risk_indicator").
@@ -492,7 +703,7 @@ func (r *trade) QueryLastAccountBalance(ctx context.Context) (*entity.AccountBal
 	return &e, nil
 }
 
-func (r *trade) queryAccountBalanceByDate(ctx context.Context, date time.Time) (*entity.AccountBalance, error) {
+func (r *trade) QueryAccountBalanceByDate(ctx context.Context, date time.Time) (*entity.AccountBalance, error) {
 	sql, arg, err := r.Builder.
 		Select("id, date, balance, today_margin, available_margin, yesterday_margin, risk_indicator").
 		From(tableNameAccountBalance).
@@ -514,7 +725,7 @@ func (r *trade) queryAccountBalanceByDate(ctx context.Context, date time.Time) (
 }
 
 func (r *trade) InsertOrUpdateAccountBalance(ctx context.Context, t *entity.AccountBalance) error {
-	dbStatus, err := r.queryAccountBalanceByDate(ctx, t.Date)
+	dbStatus, err := r.QueryAccountBalanceByDate(ctx, t.Date)
 	if err != nil {
 		return err
 	}
@@ -889,3 +1100,28 @@ func (r *trade) QueryInventoryStockByDate(ctx context.Context, date time.Time) (
 	}
 	return result, nil
 }
+
+// InsertFutureRoll returns false if the code was rolled before.
+func (r *trade) InsertFutureRoll(ctx context.Context, t *entity.FutureRoll) (bool, error) {
+	builder := r.Builder.Insert(tableNameTradeFutureRoll).
+		Columns("code, roll_to, direction, position, created").
+		Values(t.Code, t.RollTo, t.Direction, t.Position, t.Created).
+		Suffix(`ON CONFLICT ("code") DO NOTHING`)
+
+	tx, err := r.BeginTransaction()
+	if err != nil {
+		return false, err
+	}
+	defer r.EndTransaction(tx, err)
+	var sql string
+	var args []interface{}
+
+	if sql, args, err = builder.ToSql(); err != nil {
+		return false, err
+	}
+	tag, err := tx.Exec(ctx, sql, args...)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() != 0, nil
+}
@@ -0,0 +1,202 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/toc-taiwan/postgres"
+	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
+)
+
+type watchlist struct {
+	*postgres.Postgres
+}
+
+func NewWatchlist(pg *postgres.Postgres) WatchlistRepo {
+	return &watchlist{pg}
+}
+
+// InsertWatchlist inserts the list and fills its id, other lists of the user are no longer default if it is.
+func (r *watchlist) InsertWatchlist(ctx context.Context, t *entity.Watchlist) error {
+	tx, err := r.BeginTransaction()
+	if err != nil {
+		return err
+	}
+	defer r.EndTransaction(tx, err)
+	var sql string
+	var args []interface{}
+
+	if t.Default {
+		if sql, args, err = r.clearDefault(t.UserID).ToSql(); err != nil {
+			return err
+		} else if _, err = tx.Exec(ctx, sql, args...); err != nil {
+			return err
+		}
+	}
+
+	builder := r.Builder.Insert(tableNameWatchlist).
+		Columns("user_id, name, is_default, sort, created, updated").
+		Values(t.UserID, t.Name, t.Default, t.Sort, t.Created, t.Updated).
+		Suffix("RETURNING id")
+	if sql, args, err = builder.ToSql(); err != nil {
+		return err
+	} else if err = tx.QueryRow(ctx, sql, args...).Scan(&t.ID); err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpdateWatchlist updates name, default and sort, other lists of the user are no longer default if it is.
+func (r *watchlist) UpdateWatchlist(ctx context.Context, t *entity.Watchlist) error {
+	builders := []squirrel.Sqlizer{}
+	if t.Default {
+		builders = append(builders, r.clearDefault(t.UserID))
+	}
+	builders = append(builders, r.Builder.Update(tableNameWatchlist).
+		Set("name", t.Name).
+		Set("is_default", t.Default).
+		Set("sort", t.Sort).
+		Set("updated", t.Updated).
+		Where(squirrel.Eq{"id": t.ID}))
+	return r.execBuilders(ctx, builders)
+}
+
+func (r *watchlist) clearDefault(userID int) squirrel.UpdateBuilder {
+	return r.Builder.Update(tableNameWatchlist).
+		Set("is_default", false).
+		Where(squirrel.Eq{"user_id": userID, "is_default": true})
+}
+
+// DeleteWatchlist deletes the list with its items.
+func (r *watchlist) DeleteWatchlist(ctx context.Context, id int) error {
+	return r.execBuilders(ctx, []squirrel.Sqlizer{
+		r.Builder.Delete(tableNameWatchlistItem).Where(squirrel.Eq{"watchlist_id": id}),
+		r.Builder.Delete(tableNameWatchlist).Where(squirrel.Eq{"id": id}),
+	})
+}
+
+// QueryWatchlistByUserID returns lists with items, both in sort order.
+func (r *watchlist) QueryWatchlistByUserID(ctx context.Context, userID int) ([]*entity.Watchlist, error) {
+	sql, arg, err := r.Builder.
+		Select("id, user_id, name, is_default, sort, created, updated").
+		From(tableNameWatchlist).
+		Where(squirrel.Eq{"user_id": userID}).
+		OrderBy("sort ASC", "id ASC").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.Pool().Query(ctx, sql, arg...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*entity.Watchlist
+	listMap := make(map[int]*entity.Watchlist)
+	for rows.Next() {
+		e := entity.Watchlist{Items: []*entity.WatchlistItem{}}
+		if err := rows.Scan(&e.ID, &e.UserID, &e.Name, &e.Default, &e.Sort, &e.Created, &e.Updated); err != nil {
+			return nil, err
+		}
+		result = append(result, &e)
+		listMap[e.ID] = &e
+	}
+	if len(result) == 0 {
+		return result, nil
+	}
+
+	items, err := r.queryWatchlistItemByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range items {
+		if list := listMap[v.WatchlistID]; list != nil {
+			list.Items = append(list.Items, v)
+		}
+	}
+	return result, nil
+}
+
+func (r *watchlist) queryWatchlistItemByUserID(ctx context.Context, userID int) ([]*entity.WatchlistItem, error) {
+	sql, arg, err := r.Builder.
+		Select("watchlist_id, user_id, code, note, sort, created").
+		From(tableNameWatchlistItem).
+		Where(squirrel.Eq{"user_id": userID}).
+		OrderBy("sort ASC", "id ASC").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.Pool().Query(ctx, sql, arg...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*entity.WatchlistItem
+	for rows.Next() {
+		e := entity.WatchlistItem{}
+		if err := rows.Scan(&e.WatchlistID, &e.UserID, &e.Code, &e.Note, &e.Sort, &e.Created); err != nil {
+			return nil, err
+		}
+		result = append(result, &e)
+	}
+	return result, nil
+}
+
+// InsertOrUpdateWatchlistItem adds the items, existing codes get the new note and sort.
+func (r *watchlist) InsertOrUpdateWatchlistItem(ctx context.Context, t []*entity.WatchlistItem) error {
+	if len(t) == 0 {
+		return nil
+	}
+
+	builder := r.Builder.Insert(tableNameWatchlistItem).
+		Columns("watchlist_id, user_id, code, note, sort, created")
+	for _, v := range t {
+		builder = builder.Values(v.WatchlistID, v.UserID, v.Code, v.Note, v.Sort, v.Created)
+	}
+	builder = builder.Suffix(`ON CONFLICT ("watchlist_id", "code") DO UPDATE SET "note" = EXCLUDED."note", "sort" = EXCLUDED."sort"`)
+	return r.execBuilders(ctx, []squirrel.Sqlizer{builder})
+}
+
+func (r *watchlist) DeleteWatchlistItem(ctx context.Context, watchlistID int, codes []string) error {
+	if len(codes) == 0 {
+		return nil
+	}
+	return r.execBuilders(ctx, []squirrel.Sqlizer{
+		r.Builder.Delete(tableNameWatchlistItem).Where(squirrel.Eq{"watchlist_id": watchlistID, "code": codes}),
+	})
+}
+
+// UpdateWatchlistItemSort sets the sort of each code to its index.
+func (r *watchlist) UpdateWatchlistItemSort(ctx context.Context, watchlistID int, codes []string) error {
+	builders := []squirrel.Sqlizer{}
+	for i, code := range codes {
+		builders = append(builders, r.Builder.Update(tableNameWatchlistItem).
+			Set("sort", i).
+			Where(squirrel.Eq{"watchlist_id": watchlistID, "code": code}))
+	}
+	return r.execBuilders(ctx, builders)
+}
+
+func (r *watchlist) execBuilders(ctx context.Context, builders []squirrel.Sqlizer) error {
+	tx, err := r.BeginTransaction()
+	if err != nil {
+		return err
+	}
+	defer r.EndTransaction(tx, err)
+
+	for _, builder := range builders {
+		var sql string
+		var args []interface{}
+		if sql, args, err = builder.ToSql(); err != nil {
+			return err
+		} else if _, err = tx.Exec(ctx, sql, args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
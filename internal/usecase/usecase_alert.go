@@ -0,0 +1,357 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/toc-taiwan/toc-machine-trading/internal/config"
+	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
+	"github.com/toc-taiwan/toc-machine-trading/internal/usecase/cache"
+	"github.com/toc-taiwan/toc-machine-trading/internal/usecase/grpc"
+	"github.com/toc-taiwan/toc-machine-trading/internal/usecase/modules/calendar"
+	"github.com/toc-taiwan/toc-machine-trading/internal/usecase/mqtt"
+	"github.com/toc-taiwan/toc-machine-trading/internal/usecase/mqtt/inline"
+	"github.com/toc-taiwan/toc-machine-trading/internal/usecase/repo"
+	"github.com/toc-taiwan/toc-machine-trading/pkg/eventbus"
+	"github.com/toc-taiwan/toc-machine-trading/pkg/log"
+)
+
+const (
+	alertSnapshotInterval = 15 * time.Second
+	alertMinCooldown      = 60
+	alertHistoryMaxLimit  = 200
+)
+
+// AlertUseCase evaluates user alert rules, stock rules on snapshots and future rules on live ticks.
+type AlertUseCase struct {
+	repo       repo.AlertRepo
+	systemRepo repo.SystemRepo
+
+	gRPCRealtime grpc.RealTimegRPCAPI
+	gRPCSub      grpc.SubscribegRPCAPI
+	commonMQ     mqtt.MQTT
+
+	// ruleMap only keeps enabled rules, lastPriceMap is used to detect price cross
+	ruleMap      map[int]*entity.AlertRule
+	lastPriceMap map[int]float64
+	ruleMapLock  sync.Mutex
+
+	futureSubMap     map[string]struct{}
+	futureSubMapLock sync.Mutex
+	futureTickChan   chan *entity.RealTimeFutureTick
+
+	logger   *log.Log
+	cc       *cache.Cache
+	bus      *eventbus.Bus
+	tradeDay *calendar.Calendar
+}
+
+// NewAlert -.
+func NewAlert() Alert {
+	cfg := config.Get()
+	uc := &AlertUseCase{
+		repo:         repo.NewAlert(cfg.GetPostgresPool()),
+		systemRepo:   repo.NewSystemRepo(cfg.GetPostgresPool()),
+		gRPCRealtime: grpc.NewRealTime(cfg.GetSinopacConn()),
+		gRPCSub:      grpc.NewSubscribe(cfg.GetSinopacConn()),
+		commonMQ:     inline.NewInliner(),
+
+		ruleMap:        make(map[int]*entity.AlertRule),
+		lastPriceMap:   make(map[int]float64),
+		futureSubMap:   make(map[string]struct{}),
+		futureTickChan: make(chan *entity.RealTimeFutureTick),
+
+		logger:   log.Get(),
+		cc:       cache.Get(),
+		bus:      eventbus.Get(),
+		tradeDay: calendar.Get(),
+	}
+
+	rules, err := uc.repo.QueryEnabledAlertRule(context.Background())
+	if err != nil {
+		uc.logger.Fatal(err)
+	}
+	for _, v := range rules {
+		uc.ruleMap[v.ID] = v
+		uc.subscribeFuture(v)
+	}
+
+	go uc.evaluateFutureTick()
+	go uc.evaluateStockSnapshot()
+
+	return uc
+}
+
+func (uc *AlertUseCase) CreateAlertRule(ctx context.Context, username string, t *entity.NewAlertRule) (*entity.AlertRule, error) {
+	user, err := uc.systemRepo.QueryUserByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	if err = uc.checkAlertRule(t); err != nil {
+		return nil, err
+	}
+
+	rule := &entity.AlertRule{
+		UserID:    user.ID,
+		Type:      t.Type,
+		Code:      t.Code,
+		Threshold: t.Threshold,
+		Cooldown:  t.Cooldown,
+		Enabled:   t.Enabled,
+		Created:   time.Now(),
+	}
+	if err = uc.repo.InsertAlertRule(ctx, rule); err != nil {
+		return nil, err
+	}
+	uc.setRule(rule)
+	return rule, nil
+}
+
+func (uc *AlertUseCase) GetAlertRules(ctx context.Context, username string) ([]*entity.AlertRule, error) {
+	user, err := uc.systemRepo.QueryUserByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+	return uc.repo.QueryAlertRuleByUserID(ctx, user.ID)
+}
+
+func (uc *AlertUseCase) UpdateAlertRule(ctx context.Context, username string, id int, t *entity.NewAlertRule) (*entity.AlertRule, error) {
+	rule, err := uc.queryUserAlertRule(ctx, username, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = uc.checkAlertRule(t); err != nil {
+		return nil, err
+	}
+
+	rule.Type = t.Type
+	rule.Code = t.Code
+	rule.Threshold = t.Threshold
+	rule.Cooldown = t.Cooldown
+	rule.Enabled = t.Enabled
+	if err = uc.repo.UpdateAlertRule(ctx, rule); err != nil {
+		return nil, err
+	}
+	uc.setRule(rule)
+	return rule, nil
+}
+
+func (uc *AlertUseCase) DeleteAlertRule(ctx context.Context, username string, id int) error {
+	rule, err := uc.queryUserAlertRule(ctx, username, id)
+	if err != nil {
+		return err
+	}
+
+	if err = uc.repo.DeleteAlertRule(ctx, rule.ID); err != nil {
+		return err
+	}
+
+	uc.ruleMapLock.Lock()
+	delete(uc.ruleMap, rule.ID)
+	delete(uc.lastPriceMap, rule.ID)
+	uc.ruleMapLock.Unlock()
+	return nil
+}
+
+func (uc *AlertUseCase) GetAlertHistory(ctx context.Context, username string, limit, offset uint64) ([]*entity.AlertHistory, error) {
+	user, err := uc.systemRepo.QueryUserByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	if limit == 0 || limit > alertHistoryMaxLimit {
+		limit = alertHistoryMaxLimit
+	}
+	return uc.repo.QueryAlertHistoryByUserID(ctx, user.ID, limit, offset)
+}
+
+func (uc *AlertUseCase) queryUserAlertRule(ctx context.Context, username string, id int) (*entity.AlertRule, error) {
+	rules, err := uc.GetAlertRules(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range rules {
+		if v.ID == id {
+			return v, nil
+		}
+	}
+	return nil, ErrAlertRuleNotFound
+}
+
+func (uc *AlertUseCase) checkAlertRule(t *entity.NewAlertRule) error {
+	switch {
+	case uc.cc.GetStockDetail(t.Code) != nil:
+		if !t.Type.ForStock() {
+			return ErrAlertRuleInvalid
+		}
+	case uc.cc.GetFutureDetail(t.Code) != nil:
+		if !t.Type.ForFuture() {
+			return ErrAlertRuleInvalid
+		}
+	default:
+		return ErrAlertCodeNotFound
+	}
+
+	if t.Threshold <= 0 || t.Cooldown < alertMinCooldown {
+		return ErrAlertRuleInvalid
+	}
+	return nil
+}
+
+// setRule replaces the rule in memory, price cross state is reset since the threshold may change.
+func (uc *AlertUseCase) setRule(rule *entity.AlertRule) {
+	uc.ruleMapLock.Lock()
+	delete(uc.lastPriceMap, rule.ID)
+	if rule.Enabled {
+		uc.ruleMap[rule.ID] = rule
+	} else {
+		delete(uc.ruleMap, rule.ID)
+	}
+	uc.ruleMapLock.Unlock()
+
+	if rule.Enabled {
+		uc.subscribeFuture(rule)
+	}
+}
+
+func (uc *AlertUseCase) subscribeFuture(rule *entity.AlertRule) {
+	if uc.cc.GetFutureDetail(rule.Code) == nil {
+		return
+	}
+
+	uc.futureSubMapLock.Lock()
+	defer uc.futureSubMapLock.Unlock()
+	if _, ok := uc.futureSubMap[rule.Code]; ok {
+		return
+	}
+	uc.futureSubMap[rule.Code] = struct{}{}
+
+	uc.commonMQ.FutureTickConsumer(rule.Code, uc.futureTickChan)
+	failSubNumArr, err := uc.gRPCSub.SubscribeFutureTick([]string{rule.Code})
+	if err != nil {
+		uc.logger.Error(err)
+		return
+	}
+	if len(failSubNumArr) != 0 {
+		uc.logger.Errorf("subscribe future fail %v", failSubNumArr)
+	}
+}
+
+func (uc *AlertUseCase) evaluateFutureTick() {
+	for tick := range uc.futureTickChan {
+		future := uc.cc.GetFutureDetail(tick.Code)
+		if future == nil {
+			continue
+		}
+		uc.evaluate(tick.Code, future.Name, tick.TickTime, map[entity.AlertType]float64{
+			entity.AlertTypePriceCross:  tick.Close,
+			entity.AlertTypePctChange:   tick.PctChg,
+			entity.AlertTypeFutureBasis: tick.Close - tick.UnderlyingPrice,
+		})
+	}
+}
+
+func (uc *AlertUseCase) evaluateStockSnapshot() {
+	for range time.NewTicker(alertSnapshotInterval).C {
+		tradeDay := uc.tradeDay.GetStockTradeDay()
+		if !tradeDay.IsStockMarketOpenNow() {
+			continue
+		}
+
+		codes := uc.stockRuleCodes()
+		if len(codes) == 0 {
+			continue
+		}
+
+		snapshots, err := uc.gRPCRealtime.GetStockSnapshotByNumArr(codes)
+		if err != nil {
+			uc.logger.Error(err)
+			continue
+		}
+
+		for _, v := range snapshots {
+			stock := uc.cc.GetStockDetail(v.GetCode())
+			if stock == nil {
+				continue
+			}
+			uc.evaluate(v.GetCode(), stock.Name, time.Now(), map[entity.AlertType]float64{
+				entity.AlertTypePriceCross:  v.GetClose(),
+				entity.AlertTypePctChange:   v.GetChangeRate(),
+				entity.AlertTypeVolumeRatio: v.GetVolumeRatio(),
+			})
+		}
+	}
+}
+
+func (uc *AlertUseCase) stockRuleCodes() []string {
+	uc.ruleMapLock.Lock()
+	defer uc.ruleMapLock.Unlock()
+
+	codeMap := make(map[string]struct{})
+	for _, v := range uc.ruleMap {
+		if uc.cc.GetStockDetail(v.Code) != nil {
+			codeMap[v.Code] = struct{}{}
+		}
+	}
+
+	var result []string
+	for code := range codeMap {
+		result = append(result, code)
+	}
+	return result
+}
+
+// evaluate checks all rules of the code against the values, triggered rules are saved and published.
+func (uc *AlertUseCase) evaluate(code, name string, now time.Time, values map[entity.AlertType]float64) {
+	var triggered []*entity.AlertHistory
+
+	uc.ruleMapLock.Lock()
+	for _, rule := range uc.ruleMap {
+		if rule.Code != code {
+			continue
+		}
+		value, ok := values[rule.Type]
+		if !ok {
+			continue
+		}
+
+		var hit bool
+		switch rule.Type {
+		case entity.AlertTypePriceCross:
+			last, exist := uc.lastPriceMap[rule.ID]
+			uc.lastPriceMap[rule.ID] = value
+			hit = exist && ((last < rule.Threshold && value >= rule.Threshold) || (last > rule.Threshold && value <= rule.Threshold))
+		case entity.AlertTypePctChange, entity.AlertTypeFutureBasis:
+			hit = value >= rule.Threshold || value <= -rule.Threshold
+		case entity.AlertTypeVolumeRatio:
+			hit = value >= rule.Threshold
+		}
+
+		if !hit || rule.InCooldown(now) {
+			continue
+		}
+		rule.LastTriggered = &now
+		triggered = append(triggered, entity.NewAlertHistory(rule, name, value, now))
+	}
+	uc.ruleMapLock.Unlock()
+
+	for _, v := range triggered {
+		if err := uc.repo.InsertAlertHistory(context.Background(), v); err != nil {
+			uc.logger.Error(err)
+			continue
+		}
+		uc.bus.PublishTopicEvent(topicAlertTriggered, v)
+	}
+}
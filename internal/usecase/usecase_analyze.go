@@ -9,20 +9,22 @@ import (
 	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
 	"github.com/toc-taiwan/toc-machine-trading/internal/usecase/cache"
 	"github.com/toc-taiwan/toc-machine-trading/internal/usecase/modules/calendar"
+	"github.com/toc-taiwan/toc-machine-trading/internal/usecase/modules/scanner"
 	"github.com/toc-taiwan/toc-machine-trading/internal/usecase/repo"
 	"github.com/toc-taiwan/toc-machine-trading/pkg/eventbus"
 	"github.com/toc-taiwan/toc-machine-trading/pkg/log"
 )
 
+const patternHitMaxLimit = 200
+
 // AnalyzeUseCase -.
 type AnalyzeUseCase struct {
-	repo repo.HistoryRepo
-
-	targetArr []*entity.StockTarget
+	repo        repo.HistoryRepo
+	patternRepo repo.PatternRepo
 
-	lastBelowMAStock map[string]*entity.StockHistoryAnalyze
-	rebornMap        map[time.Time][]entity.Stock
-	rebornLock       sync.Mutex
+	scannerCfg config.Scanner
+	scanner    *scanner.Scanner
+	scanLock   sync.Mutex
 
 	tradeDay *calendar.Calendar
 
@@ -32,60 +34,97 @@ type AnalyzeUseCase struct {
 }
 
 func NewAnalyze() Analyze {
+	cfg := config.Get()
 	uc := &AnalyzeUseCase{
-		repo:             repo.NewHistory(config.Get().GetPostgresPool()),
-		lastBelowMAStock: make(map[string]*entity.StockHistoryAnalyze),
-		rebornMap:        make(map[time.Time][]entity.Stock),
-		tradeDay:         calendar.Get(),
-		logger:           log.Get(),
-		cc:               cache.Get(),
-		bus:              eventbus.Get(),
+		repo:        repo.NewHistory(cfg.GetPostgresPool()),
+		patternRepo: repo.NewPattern(cfg.GetPostgresPool()),
+		scannerCfg:  cfg.Scanner,
+		scanner:     scanner.NewScanner(cfg.Scanner),
+		tradeDay:    calendar.Get(),
+		logger:      log.Get(),
+		cc:          cache.Get(),
+		bus:         eventbus.Get(),
 	}
 
-	uc.bus.SubscribeAsync(topicAnalyzeStockTargets, true, uc.findBelowQuaterMATargets)
+	uc.bus.SubscribeAsync(topicAnalyzeStockTargets, true, uc.scanPatterns)
 	return uc
 }
 
-// GetRebornMap -.
-func (uc *AnalyzeUseCase) GetRebornMap(ctx context.Context) map[time.Time][]entity.Stock {
-	uc.rebornLock.Lock()
-	if len(uc.lastBelowMAStock) != 0 {
-		for _, s := range uc.lastBelowMAStock {
-			if open := uc.cc.GetHistoryOpen(s.Stock.Number, uc.tradeDay.GetStockTradeDay().TradeDay); open != 0 {
-				if open > s.QuaterMA {
-					uc.rebornMap[s.Date] = append(uc.rebornMap[s.Date], *s.Stock)
-				}
-				delete(uc.lastBelowMAStock, s.Stock.Number)
-			}
-		}
+// GetPatternHits returns one page of persisted pattern hits on date, pattern is optional.
+func (uc *AnalyzeUseCase) GetPatternHits(ctx context.Context, date, pattern string, limit, offset uint64) (*entity.PatternHitList, error) {
+	d, err := time.ParseInLocation(entity.ShortTimeLayout, date, time.Local)
+	if err != nil {
+		return nil, ErrPatternDateInvalid
+	}
+
+	name := entity.PatternName(pattern)
+	switch name {
+	case "", entity.PatternMACross, entity.PatternGap, entity.PatternBreakout, entity.PatternVolumeSpike, entity.PatternInsideBar, entity.PatternQuaterMA:
+	default:
+		return nil, ErrPatternInvalid
+	}
+
+	if limit == 0 || limit > patternHitMaxLimit {
+		limit = patternHitMaxLimit
 	}
-	uc.rebornLock.Unlock()
-	return uc.rebornMap
+	return uc.patternRepo.QueryPatternHitByDate(ctx, d, name, limit, offset)
 }
 
-func (uc *AnalyzeUseCase) findBelowQuaterMATargets(targetArr []*entity.StockTarget) {
-	defer uc.rebornLock.Unlock()
-	uc.rebornLock.Lock()
-	uc.targetArr = append(uc.targetArr, targetArr...)
+// scanPatterns runs the scanner over stored history of all stocks for the last ScanDays trade days.
+// It is triggered after history of targets and the scan window of all stocks is fetched.
+func (uc *AnalyzeUseCase) scanPatterns([]*entity.StockTarget) {
+	defer uc.scanLock.Unlock()
+	uc.scanLock.Lock()
+
+	if uc.scannerCfg.ScanDays <= 0 {
+		return
+	}
+	scanDateArr := uc.tradeDay.GetLastNStockTradeDay(uc.scannerCfg.ScanDays)
+	historyDateArr := uc.tradeDay.GetLastNTradeDayByDate(uc.scannerCfg.DayCount+1, scanDateArr[len(scanDateArr)-1])
+	start := historyDateArr[len(historyDateArr)-1]
+	end := scanDateArr[0].AddDate(0, 0, 1)
 
-	for _, t := range targetArr {
-		maMap, err := uc.repo.QueryAllQuaterMAByStockNum(context.Background(), t.StockNum)
-		if err != nil {
-			uc.logger.Fatal(err)
+	barsMap, err := uc.queryDayBars(start, end)
+	if err != nil {
+		uc.logger.Errorf("scan patterns fail: %s", err)
+		return
+	}
+
+	var total int
+	for _, d := range scanDateArr {
+		hits := uc.scanner.Scan(d, barsMap)
+		if err := uc.patternRepo.InsertOrUpdatePatternHitArr(context.Background(), hits); err != nil {
+			uc.logger.Errorf("insert pattern hits of %s fail: %s", d.Format(entity.ShortTimeLayout), err)
+			return
 		}
+		total += len(hits)
+	}
+	uc.logger.Infof("Scan patterns done, stocks: %d, hits: %d", len(barsMap), total)
+}
 
-		for _, ma := range maMap {
-			tmp := ma
-			if close := uc.cc.GetHistoryClose(ma.StockNum, ma.Date); close != 0 && close-ma.QuaterMA > 0 {
-				continue
+// queryDayBars aggregates stored kbars into day bars, the official close replaces the last kbar close if stored.
+func (uc *AnalyzeUseCase) queryDayBars(start, end time.Time) (map[string][]*entity.StockHistoryKbar, error) {
+	barsMap, err := uc.repo.QueryAllStockDayKbarByDateRange(context.Background(), start, end)
+	if err != nil {
+		return nil, err
+	}
+	closeMap, err := uc.repo.QueryAllStockCloseByDateRange(context.Background(), start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	for stockNum, closeArr := range closeMap {
+		dateCloseMap := make(map[string]float64)
+		for _, v := range closeArr {
+			if v.Close != 0 {
+				dateCloseMap[v.Date.Format(entity.ShortTimeLayout)] = v.Close
 			}
-			if nextTradeDay := uc.tradeDay.GetAbsNextTradeDayTime(ma.Date); nextTradeDay.Equal(uc.tradeDay.GetStockTradeDay().TradeDay) {
-				uc.lastBelowMAStock[tmp.StockNum] = tmp
-			} else if nextOpen := uc.cc.GetHistoryOpen(ma.StockNum, nextTradeDay); nextOpen != 0 && nextOpen-ma.QuaterMA > 0 {
-				uc.rebornMap[ma.Date] = append(uc.rebornMap[ma.Date], *tmp.Stock)
+		}
+		for _, bar := range barsMap[stockNum] {
+			if c, ok := dateCloseMap[bar.KbarTime.Format(entity.ShortTimeLayout)]; ok {
+				bar.Close = c
 			}
 		}
 	}
-
-	uc.logger.Info("Find below quaterMA targets done")
+	return barsMap, nil
 }
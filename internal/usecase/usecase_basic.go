@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 
 	"github.com/toc-taiwan/toc-machine-trading/internal/config"
@@ -25,6 +26,8 @@ type BasicUseCase struct {
 	allFutureDetail []*entity.Future
 	allOptionDetail []*entity.Option
 
+	continuous *continuousResolver
+
 	logger *log.Log
 	cc     *cache.Cache
 }
@@ -38,6 +41,8 @@ func NewBasic() Basic {
 		tradeDay: calendar.Get(),
 		logger:   log.Get(),
 		cc:       cache.Get(),
+
+		continuous: newContinuousResolver(),
 	}
 
 	uc.loginAll()
@@ -228,6 +233,7 @@ func (uc *BasicUseCase) updateRepoOption() error {
 		if _, ok := duplCodeMap[option.Code]; !ok {
 			duplCodeMap[option.Code] = struct{}{}
 			uc.allOptionDetail = append(uc.allOptionDetail, option)
+			uc.cc.SetOptionDetail(option)
 			searcher.AddOption(option)
 		}
 	}
@@ -250,8 +256,13 @@ func (uc *BasicUseCase) CreateStockSearchRoom(com chan string, dataChan chan []*
 	}
 }
 
+// GetFutureDetail -. A virtual code like MXF1! is the real contract of the current trade day.
 func (uc *BasicUseCase) GetFutureDetail(futureCode string) *entity.Future {
-	return uc.cc.GetFutureDetail(futureCode)
+	code, err := uc.continuous.resolve(context.Background(), futureCode, uc.tradeDay.GetFutureTradeDay().TradeDay)
+	if err != nil {
+		return nil
+	}
+	return uc.cc.GetFutureDetail(code)
 }
 
 func (uc *BasicUseCase) CreateFutureSearchRoom(com chan string, dataChan chan []*entity.Future) {
@@ -264,3 +275,19 @@ func (uc *BasicUseCase) CreateFutureSearchRoom(com chan string, dataChan chan []
 		dataChan <- searcher.SearchFuture(code)
 	}
 }
+
+// CreateOptionSearchRoom accepts a keyword or a json of entity.OptionSearchFilter.
+func (uc *BasicUseCase) CreateOptionSearchRoom(com chan string, dataChan chan []*entity.Option) {
+	searcher := searcher.Get()
+	for {
+		msg, ok := <-com
+		if !ok {
+			return
+		}
+		filter := &entity.OptionSearchFilter{}
+		if err := json.Unmarshal([]byte(msg), filter); err != nil {
+			filter = &entity.OptionSearchFilter{Keyword: msg}
+		}
+		dataChan <- searcher.FilterOption(filter)
+	}
+}
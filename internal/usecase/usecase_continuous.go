@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/toc-taiwan/toc-machine-trading/internal/config"
+	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
+	"github.com/toc-taiwan/toc-machine-trading/internal/usecase/cache"
+	"github.com/toc-taiwan/toc-machine-trading/internal/usecase/modules/calendar"
+	"github.com/toc-taiwan/toc-machine-trading/internal/usecase/modules/continuous"
+	"github.com/toc-taiwan/toc-machine-trading/internal/usecase/repo"
+)
+
+// continuousResolver maps virtual codes like MXF1! to real contracts, shared by history and trade.
+type continuousResolver struct {
+	repo     repo.BasicRepo
+	cc       *cache.Cache
+	tradeDay *calendar.Calendar
+	cfg      config.Continuous
+}
+
+func newContinuousResolver() *continuousResolver {
+	cfg := config.Get()
+	return &continuousResolver{
+		repo:     repo.NewBasic(cfg.GetPostgresPool()),
+		cc:       cache.Get(),
+		tradeDay: calendar.Get(),
+		cfg:      cfg.Continuous,
+	}
+}
+
+func (r *continuousResolver) adjustment() entity.ContinuousAdjustment {
+	if a := entity.ContinuousAdjustment(r.cfg.Adjustment); a.Valid() {
+		return a
+	}
+	return entity.ContinuousAdjustmentNone
+}
+
+// lastActiveDay is RollDaysBefore trade days before the delivery day.
+func (r *continuousResolver) lastActiveDay(delivery time.Time) time.Time {
+	deliveryDay := time.Date(delivery.Year(), delivery.Month(), delivery.Day(), 0, 0, 0, 0, time.Local)
+	if d := r.tradeDay.ShiftTradeDay(deliveryDay, -r.cfg.RollDaysBefore); !d.IsZero() {
+		return d
+	}
+	return deliveryDay.AddDate(0, 0, -r.cfg.RollDaysBefore)
+}
+
+// schedule uses expired contracts from postgres and listed ones from cache, broker R1 and R2 codes are skipped.
+func (r *continuousResolver) schedule(ctx context.Context, category string) (*continuous.Schedule, error) {
+	dbArr, err := r.repo.QueryFutureArrByCategory(ctx, category)
+	if err != nil {
+		return nil, err
+	}
+
+	contractMap := make(map[string]*entity.Future)
+	for _, v := range dbArr {
+		contractMap[v.Code] = v
+	}
+	for _, v := range r.cc.GetAllFutureDetail() {
+		if v.Category == category {
+			contractMap[v.Code] = v
+		}
+	}
+
+	var contracts []*entity.Future
+	for code, v := range contractMap {
+		if strings.Contains(strings.ToUpper(code), "R1") || strings.Contains(strings.ToUpper(code), "R2") {
+			continue
+		}
+		contracts = append(contracts, v)
+	}
+	return continuous.NewSchedule(contracts, r.lastActiveDay), nil
+}
+
+// resolve returns the real code of a virtual one on the trade day, other codes are returned as is.
+func (r *continuousResolver) resolve(ctx context.Context, code string, tradeDay time.Time) (string, error) {
+	category, nth, ok := entity.ParseContinuousCode(code)
+	if !ok {
+		return code, nil
+	}
+
+	s, err := r.schedule(ctx, category)
+	if err != nil {
+		return "", err
+	}
+	contract := s.Contract(nth, tradeDay)
+	if contract == nil {
+		return "", ErrContinuousContractNotFound
+	}
+	return contract.Code, nil
+}
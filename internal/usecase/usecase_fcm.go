@@ -2,27 +2,24 @@ package usecase
 
 import (
 	"context"
-	"encoding/json"
-	"os"
 	"sync"
 
-	firebase "firebase.google.com/go/v4"
-	"firebase.google.com/go/v4/messaging"
 	"github.com/toc-taiwan/toc-machine-trading/internal/config"
-	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
 	"github.com/toc-taiwan/toc-machine-trading/internal/usecase/cache"
 	"github.com/toc-taiwan/toc-machine-trading/internal/usecase/modules/calendar"
+	"github.com/toc-taiwan/toc-machine-trading/internal/usecase/modules/notifier"
 	"github.com/toc-taiwan/toc-machine-trading/internal/usecase/repo"
 	"github.com/toc-taiwan/toc-machine-trading/pkg/eventbus"
 	"github.com/toc-taiwan/toc-machine-trading/pkg/log"
-	"google.golang.org/api/option"
 )
 
+const fcmServiceAccountPath = "configs/service_account.json"
+
 type FcmUseCase struct {
 	repo repo.SystemRepo
 
 	cc       *cache.Cache
-	app      *firebase.App
+	fcm      *notifier.FCM
 	logger   *log.Log
 	bus      *eventbus.Bus
 	tradeDay *calendar.Calendar
@@ -33,66 +30,67 @@ type FcmUseCase struct {
 
 // NewFCM -.
 func NewFCM() FCM {
-	logger := log.Get()
-	fb, err := newFCM()
-	if err != nil {
-		logger.Fatal(err)
-	}
-
 	cfg := config.Get()
 	uc := &FcmUseCase{
 		repo:     repo.NewSystemRepo(cfg.GetPostgresPool()),
 		cc:       cache.Get(),
-		app:      fb,
-		logger:   logger,
+		logger:   log.Get(),
 		bus:      eventbus.Get(),
 		tradeDay: calendar.Get(),
 	}
 
+	fcm, err := newFCMNotifier(uc.repo, uc.bus)
+	if err != nil {
+		uc.logger.Warnf("FCM is disabled: %s", err)
+		return uc
+	}
+	uc.fcm = fcm
+
 	uc.updatePushToken()
 
-	uc.bus.SubscribeAsync(topicInsertOrUpdateStockOrder, true, uc.pushStockOrder)
+	// order pushes go through NotifyUseCase, which follows the notify preference of each user
 	uc.bus.SubscribeAsync(topicUpdatePushUser, true, uc.updatePushToken)
 
 	return uc
 }
 
-type srvAccount struct {
-	ProjectID string `json:"project_id"`
-}
-
-func newFCM() (*firebase.App, error) {
-	serviceAccountFilePath := "configs/service_account.json"
-	opt := option.WithCredentialsFile(serviceAccountFilePath)
-
-	data, err := os.ReadFile(serviceAccountFilePath)
-	if err != nil {
-		return nil, err
-	}
-
-	content := srvAccount{}
-	if err = json.Unmarshal(data, &content); err != nil {
-		return nil, err
-	}
+var (
+	fcmNotifier     *notifier.FCM
+	fcmNotifierErr  error
+	fcmNotifierOnce sync.Once
+)
 
-	config := &firebase.Config{ProjectID: content.ProjectID}
-	fb, err := firebase.NewApp(context.Background(), config, opt)
-	if err != nil {
-		return nil, err
-	}
-	return fb, nil
+// newFCMNotifier returns the notifier shared by FcmUseCase and NotifyUseCase, so there is only one firebase app,
+// it removes tokens reported invalid and tells every holder of tokens to reload.
+func newFCMNotifier(systemRepo repo.SystemRepo, bus *eventbus.Bus) (*notifier.FCM, error) {
+	fcmNotifierOnce.Do(func() {
+		fcmNotifier, fcmNotifierErr = notifier.NewFCM(fcmServiceAccountPath)
+		if fcmNotifierErr != nil {
+			return
+		}
+
+		logger := log.Get()
+		fcmNotifier.OnInvalidTokens(func(ctx context.Context, tokens []string) {
+			if err := systemRepo.DeletePushTokens(ctx, tokens); err != nil {
+				logger.Error(err)
+				return
+			}
+			logger.Warnf("removed %d invalid push tokens", len(tokens))
+			bus.PublishTopicEvent(topicUpdatePushUser)
+		})
+	})
+	return fcmNotifier, fcmNotifierErr
 }
 
 func (uc *FcmUseCase) updatePushToken() {
 	uc.pushTokensLock.Lock()
-	defer uc.pushTokensLock.Unlock()
-
 	tokens, err := uc.repo.GetAllPushTokens(context.Background())
 	if err != nil {
 		uc.logger.Error(err)
-		return
+	} else {
+		uc.pushTokens = tokens
 	}
-	uc.pushTokens = tokens
+	uc.pushTokensLock.Unlock()
 }
 
 func (uc *FcmUseCase) getAllPushToken() []string {
@@ -102,94 +100,21 @@ func (uc *FcmUseCase) getAllPushToken() []string {
 }
 
 func (uc *FcmUseCase) AnnounceMessage(msg string) error {
-	ctx := context.Background()
-	client, err := uc.app.Messaging(ctx)
-	if err != nil {
-		return err
+	if uc.fcm == nil {
+		return ErrFCMNotConfigured
 	}
-
-	message := &messaging.Message{
-		Notification: &messaging.Notification{
-			Title: "Announcement",
-			Body:  msg,
-		},
-		APNS:  uc.newAPNS(),
-		Topic: "announcement",
-	}
-
-	_, err = client.Send(ctx, message)
-	if err != nil {
-		return err
-	}
-	return nil
+	return uc.fcm.Announce(context.Background(), "announcement", &notifier.Message{
+		Title: "Announcement",
+		Body:  msg,
+	})
 }
 
 func (uc *FcmUseCase) PushNotification(title, msg string) error {
-	tokens := uc.getAllPushToken()
-	if len(tokens) == 0 {
-		return nil
-	}
-
-	ctx := context.Background()
-	client, err := uc.app.Messaging(ctx)
-	if err != nil {
-		return err
-	}
-
-	message := &messaging.MulticastMessage{
-		Notification: &messaging.Notification{
-			Title: title,
-			Body:  msg,
-		},
-		APNS:   uc.newAPNS(),
-		Tokens: tokens,
-	}
-
-	_, err = client.SendEachForMulticast(ctx, message)
-	if err != nil {
-		return err
-	}
-	return nil
-}
-
-func (uc *FcmUseCase) newAPNS() *messaging.APNSConfig {
-	return &messaging.APNSConfig{
-		Payload: &messaging.APNSPayload{
-			Aps: &messaging.Aps{
-				Sound:            "default",
-				ContentAvailable: true,
-			},
-		},
-	}
-}
-
-func (uc *FcmUseCase) pushStockOrder(order *entity.StockOrder) {
-	tokens := uc.getAllPushToken()
-	if len(tokens) == 0 {
-		return
-	}
-
-	ctx := context.Background()
-	client, err := uc.app.Messaging(ctx)
-	if err != nil {
-		return
-	}
-
-	data, err := json.Marshal(order)
-	if err != nil {
-		return
-	}
-
-	message := &messaging.MulticastMessage{
-		APNS:   uc.newAPNS(),
-		Tokens: tokens,
-		Data: map[string]string{
-			"order_update": string(data),
-		},
-	}
-
-	_, err = client.SendEachForMulticast(ctx, message)
-	if err != nil {
-		return
+	if uc.fcm == nil {
+		return ErrFCMNotConfigured
 	}
+	return uc.fcm.Multicast(context.Background(), uc.getAllPushToken(), &notifier.Message{
+		Title: title,
+		Body:  msg,
+	})
 }
@@ -1,17 +1,24 @@
 package usecase
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"os"
 	"sort"
 	"sync"
 	"time"
 
+	"github.com/robfig/cron/v3"
 	"github.com/toc-taiwan/toc-machine-trading/internal/config"
 	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
 	"github.com/toc-taiwan/toc-machine-trading/internal/usecase/cache"
 	"github.com/toc-taiwan/toc-machine-trading/internal/usecase/grpc"
+	"github.com/toc-taiwan/toc-machine-trading/internal/usecase/modules/adjust"
 	"github.com/toc-taiwan/toc-machine-trading/internal/usecase/modules/calendar"
+	"github.com/toc-taiwan/toc-machine-trading/internal/usecase/modules/continuous"
+	"github.com/toc-taiwan/toc-machine-trading/internal/usecase/modules/indicator"
 	"github.com/toc-taiwan/toc-machine-trading/internal/usecase/repo"
 	"github.com/toc-taiwan/toc-machine-trading/pkg/eventbus"
 	"github.com/toc-taiwan/toc-machine-trading/pkg/log"
@@ -25,10 +32,25 @@ type HistoryUseCase struct {
 	grpcapi grpc.HistorygRPCAPI
 
 	analyzeStockCfg config.AnalyzeStock
+	indicatorCfg    config.Indicator
+	indicator       *indicator.Calculator
 
 	fetchList map[string]*entity.StockTarget
 	mutex     sync.Mutex
 
+	// scanUniverseFetched is the trade day kbars of all stocks were fetched for the pattern scanner
+	scanUniverseFetched time.Time
+
+	// futureKbarLockMap locks by code and trade day, so fetching one day does not block others
+	futureKbarLockMap     map[string]*sync.Mutex
+	futureKbarLockMapLock sync.Mutex
+	continuous            *continuousResolver
+
+	partition *partitionMaintainer
+
+	// corporateActionMap is loaded on start and read only after
+	corporateActionMap map[string][]*entity.CorporateAction
+
 	tradeDay *calendar.Calendar
 	cfg      *config.Config
 
@@ -43,20 +65,38 @@ type HistoryUseCase struct {
 func NewHistory() History {
 	cfg := config.Get()
 	uc := &HistoryUseCase{
-		repo:            repo.NewHistory(cfg.GetPostgresPool()),
-		grpcapi:         grpc.NewHistory(cfg.GetSinopacConn()),
-		fetchList:       make(map[string]*entity.StockTarget),
-		tradeDay:        calendar.Get(),
-		analyzeStockCfg: cfg.AnalyzeStock,
-		cfg:             cfg,
-		slackMsgChan:    make(chan string),
-		logger:          log.Get(),
-		cc:              cache.Get(),
-		bus:             eventbus.Get(),
+		repo:              repo.NewHistory(cfg.GetPostgresPool()),
+		grpcapi:           grpc.NewHistory(cfg.GetSinopacConn()),
+		fetchList:         make(map[string]*entity.StockTarget),
+		futureKbarLockMap: make(map[string]*sync.Mutex),
+		tradeDay:          calendar.Get(),
+		analyzeStockCfg:   cfg.AnalyzeStock,
+		indicatorCfg:      cfg.Indicator,
+		indicator:         indicator.NewCalculator(cfg.Indicator, cfg.AnalyzeStock.RSIMinCount),
+		cfg:               cfg,
+		slackMsgChan:      make(chan string),
+		logger:            log.Get(),
+		cc:                cache.Get(),
+		bus:               eventbus.Get(),
+		continuous:        newContinuousResolver(),
+		partition:         newPartitionMaintainer(),
 	}
 
 	go uc.SendMessage()
 
+	if err := uc.importCorporateActions(); err != nil {
+		uc.logger.Errorf("import corporate actions fail: %s", err)
+	}
+
+	uc.partition.createPartitions()
+	go uc.partition.expirePartitions()
+
+	job := cron.New()
+	if _, err := job.AddFunc(partitionMaintainSpec, uc.partition.maintain); err != nil {
+		uc.logger.Fatal(err)
+	}
+	job.Start()
+
 	uc.bus.SubscribeAsync(topicFetchStockHistory, true, uc.FetchStockHistory)
 
 	return uc
@@ -88,6 +128,462 @@ func (uc *HistoryUseCase) GetDayKbarByStockNumMultiDate(stockNum string, date ti
 	return result, nil
 }
 
+const (
+	kbarRangeMinuteMaxDays = 31
+	kbarRangeDayMaxDays    = 366
+)
+
+// GetKbarRange returns kbars of a stock or future between the dates in the resolution, missing days are fetched from gRPC.
+func (uc *HistoryUseCase) GetKbarRange(ctx context.Context, code, from, to string, resolution entity.KbarResolution) (*entity.KbarRange, error) {
+	if !resolution.Valid() {
+		return nil, ErrKbarRangeInvalid
+	}
+	fromDate, err := time.ParseInLocation(entity.ShortTimeLayout, from, time.Local)
+	if err != nil {
+		return nil, ErrKbarRangeInvalid
+	}
+	toDate, err := time.ParseInLocation(entity.ShortTimeLayout, to, time.Local)
+	if err != nil || toDate.Before(fromDate) {
+		return nil, ErrKbarRangeInvalid
+	}
+
+	maxDays := kbarRangeMinuteMaxDays
+	if resolution == entity.KbarResolution1d {
+		maxDays = kbarRangeDayMaxDays
+	}
+	if toDate.After(fromDate.AddDate(0, 0, maxDays-1)) {
+		return nil, ErrKbarRangeTooLong
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	result := &entity.KbarRange{
+		Code:       code,
+		Resolution: resolution,
+		From:       fromDate,
+		To:         toDate,
+		Kbars:      []*entity.HistoryKbarBase{},
+	}
+	if category, nth, ok := entity.ParseContinuousCode(code); ok {
+		if err := uc.continuousKbarRange(ctx, result, category, nth, today); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+
+	isStock := uc.cc.GetStockDetail(code) != nil
+	if !isStock && uc.cc.GetFutureDetail(code) == nil {
+		return nil, ErrKbarRangeCodeNotFound
+	}
+
+	for d := fromDate; !d.After(toDate) && !d.After(today); d = d.AddDate(0, 0, 1) {
+		var arr []*entity.HistoryKbarBase
+		if isStock {
+			if _, err := uc.tradeDay.GetStockTradePeriodByDate(d.Format(entity.ShortTimeLayout)); err != nil {
+				continue
+			}
+			kbars, err := uc.queryStockMinuteKbarArr(ctx, code, d, d.Equal(today))
+			if err != nil {
+				return nil, err
+			}
+			for _, v := range kbars {
+				arr = append(arr, &v.HistoryKbarBase)
+			}
+		} else {
+			if _, err := uc.tradeDay.GetFutureTradePeriodByDate(d.Format(entity.ShortTimeLayout)); err != nil {
+				continue
+			}
+			if arr, err = uc.queryFutureMinuteKbarArr(code, d); err != nil {
+				return nil, err
+			}
+		}
+		result.Kbars = append(result.Kbars, resampleKbarArr(d, arr, resolution)...)
+	}
+	return result, nil
+}
+
+// continuousKbarRange fills kbars of the virtual code from the real contract of each trade day, adjusted to the latest one.
+func (uc *HistoryUseCase) continuousKbarRange(ctx context.Context, result *entity.KbarRange, category string, nth int, today time.Time) error {
+	contract, err := uc.continuousContract(ctx, category, nth, uc.futureTradeDays(result.From, result.To, today))
+	if err != nil {
+		return err
+	}
+	result.Continuous = contract
+
+	for _, segment := range contract.Segments {
+		for d := segment.From; !d.After(segment.To); d = d.AddDate(0, 0, 1) {
+			if _, err := uc.tradeDay.GetFutureTradePeriodByDate(d.Format(entity.ShortTimeLayout)); err != nil {
+				continue
+			}
+			arr, err := uc.queryFutureMinuteKbarArr(segment.Code, d)
+			if err != nil {
+				return err
+			}
+			adjusted := make([]*entity.HistoryKbarBase, 0, len(arr))
+			for _, v := range arr {
+				adjusted = append(adjusted, continuous.AdjustKbar(v, segment.Adjustment, contract.Adjustment))
+			}
+			result.Kbars = append(result.Kbars, resampleKbarArr(d, adjusted, result.Resolution)...)
+		}
+	}
+	return nil
+}
+
+// futureTradeDays returns ascending future trade days between the dates, not later than today.
+func (uc *HistoryUseCase) futureTradeDays(from, to, today time.Time) []time.Time {
+	var result []time.Time
+	for d := from; !d.After(to) && !d.After(today); d = d.AddDate(0, 0, 1) {
+		if _, err := uc.tradeDay.GetFutureTradePeriodByDate(d.Format(entity.ShortTimeLayout)); err == nil {
+			result = append(result, d)
+		}
+	}
+	return result
+}
+
+// continuousContract splits the trade days by real contract, the gap of each roll is the close of both contracts on the roll day.
+func (uc *HistoryUseCase) continuousContract(ctx context.Context, category string, nth int, tradeDays []time.Time) (*entity.ContinuousContract, error) {
+	schedule, err := uc.continuous.schedule(ctx, category)
+	if err != nil {
+		return nil, err
+	}
+	if schedule.Len() == 0 {
+		return nil, ErrContinuousContractNotFound
+	}
+
+	method := uc.continuous.adjustment()
+	segments := schedule.Segments(nth, tradeDays)
+	if method != entity.ContinuousAdjustmentNone && len(segments) > 1 {
+		rolls := make([]continuous.Roll, len(segments)-1)
+		for i := range rolls {
+			rollDay := segments[i].To
+			if rolls[i].From, err = uc.lastFutureClose(segments[i].Code, rollDay); err != nil {
+				return nil, err
+			}
+			if rolls[i].To, err = uc.lastFutureClose(segments[i+1].Code, rollDay); err != nil {
+				return nil, err
+			}
+		}
+		continuous.SetAdjustments(segments, rolls, method)
+	}
+
+	return &entity.ContinuousContract{
+		Code:       entity.ContinuousCode(category, nth),
+		Category:   category,
+		Nth:        nth,
+		Adjustment: method,
+		Segments:   segments,
+	}, nil
+}
+
+// lastFutureClose is the close of the last kbar of the trade day, 0 if there is none.
+func (uc *HistoryUseCase) lastFutureClose(code string, tradeDay time.Time) (float64, error) {
+	arr, err := uc.queryFutureKbarArrByTradeDay(code, tradeDay)
+	if err != nil {
+		return 0, err
+	}
+	if len(arr) == 0 {
+		return 0, nil
+	}
+	return arr[len(arr)-1].Close, nil
+}
+
+// GetContinuousContract returns the real contracts and adjustments of a virtual code between the dates.
+func (uc *HistoryUseCase) GetContinuousContract(ctx context.Context, code, from, to string) (*entity.ContinuousContract, error) {
+	category, nth, ok := entity.ParseContinuousCode(code)
+	if !ok {
+		return nil, ErrContinuousCodeInvalid
+	}
+	fromDate, err := time.ParseInLocation(entity.ShortTimeLayout, from, time.Local)
+	if err != nil {
+		return nil, ErrKbarRangeInvalid
+	}
+	toDate, err := time.ParseInLocation(entity.ShortTimeLayout, to, time.Local)
+	if err != nil || toDate.Before(fromDate) {
+		return nil, ErrKbarRangeInvalid
+	}
+	if toDate.After(fromDate.AddDate(0, 0, kbarRangeDayMaxDays-1)) {
+		return nil, ErrKbarRangeTooLong
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	return uc.continuousContract(ctx, category, nth, uc.futureTradeDays(fromDate, toDate, today))
+}
+
+// importCorporateActions saves actions of the file and loads all of them,
+// MA of stocks with new or changed actions is dropped to be calculated again on the next history fetch.
+func (uc *HistoryUseCase) importCorporateActions() error {
+	ctx := context.Background()
+	dbArr, err := uc.repo.QueryAllCorporateAction(ctx)
+	if err != nil {
+		return err
+	}
+
+	fileArr, err := uc.readCorporateActionFile()
+	if err != nil {
+		return err
+	}
+
+	dbMap := make(map[string]*entity.CorporateAction)
+	for _, v := range dbArr {
+		dbMap[corporateActionKey(v)] = v
+	}
+
+	var changed []*entity.CorporateAction
+	stockNumMap := make(map[string]struct{})
+	for _, v := range fileArr {
+		if uc.cc.GetStockDetail(v.StockNum) == nil {
+			uc.logger.Warnf("Corporate action of %s is skipped, stock not found", v.StockNum)
+			continue
+		}
+		if old, ok := dbMap[corporateActionKey(v)]; ok && old.Cash == v.Cash && old.Ratio == v.Ratio {
+			continue
+		}
+		dbMap[corporateActionKey(v)] = v
+		changed = append(changed, v)
+		stockNumMap[v.StockNum] = struct{}{}
+	}
+
+	if len(changed) != 0 {
+		if err := uc.repo.InsertOrUpdateCorporateActionArr(ctx, changed); err != nil {
+			return err
+		}
+		stockNumArr := make([]string, 0, len(stockNumMap))
+		for stockNum := range stockNumMap {
+			stockNumArr = append(stockNumArr, stockNum)
+		}
+		if err := uc.repo.DeleteQuaterMAByStockNumArr(ctx, stockNumArr); err != nil {
+			return err
+		}
+		uc.logger.Infof("Corporate actions imported: %d, stocks: %d", len(changed), len(stockNumArr))
+	}
+
+	actionMap := make(map[string][]*entity.CorporateAction)
+	for _, v := range dbMap {
+		actionMap[v.StockNum] = append(actionMap[v.StockNum], v)
+	}
+	for _, v := range actionMap {
+		sort.SliceStable(v, func(i, j int) bool {
+			return v[i].ExDate.Before(v[j].ExDate)
+		})
+	}
+	uc.corporateActionMap = actionMap
+	return nil
+}
+
+// readCorporateActionFile returns nothing if the path is empty or the file does not exist.
+func (uc *HistoryUseCase) readCorporateActionFile() ([]*entity.CorporateAction, error) {
+	path := uc.cfg.History.CorporateActionFilePath
+	if path == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			uc.logger.Warnf("Corporate action file %s not found, import skipped", path)
+			return nil, nil
+		}
+		return nil, err
+	}
+	return adjust.ParseCSV(bytes.NewReader(data))
+}
+
+func corporateActionKey(a *entity.CorporateAction) string {
+	return fmt.Sprintf("%s:%s:%s", a.StockNum, a.ExDate.Format(entity.ShortTimeLayout), a.Type)
+}
+
+// GetStockCloseSeries returns raw closes of the stock between the dates and the ones adjusted by corporate actions.
+func (uc *HistoryUseCase) GetStockCloseSeries(ctx context.Context, stockNum, from, to string) (*entity.StockCloseSeries, error) {
+	fromDate, err := time.ParseInLocation(entity.ShortTimeLayout, from, time.Local)
+	if err != nil {
+		return nil, ErrCloseRangeInvalid
+	}
+	toDate, err := time.ParseInLocation(entity.ShortTimeLayout, to, time.Local)
+	if err != nil || toDate.Before(fromDate) {
+		return nil, ErrCloseRangeInvalid
+	}
+	if toDate.After(fromDate.AddDate(0, 0, kbarRangeDayMaxDays-1)) {
+		return nil, ErrCloseRangeTooLong
+	}
+	if uc.cc.GetStockDetail(stockNum) == nil {
+		return nil, ErrCloseCodeNotFound
+	}
+
+	closeArr, err := uc.repo.QueryStockCloseByDateRange(ctx, stockNum, fromDate, toDate.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &entity.StockCloseSeries{
+		StockNum: stockNum,
+		From:     fromDate,
+		To:       toDate,
+		Raw:      []*entity.HistoryCloseBase{},
+		Actions:  []*entity.CorporateAction{},
+	}
+	for _, v := range closeArr {
+		result.Raw = append(result.Raw, &v.HistoryCloseBase)
+	}
+	for _, v := range uc.corporateActionMap[stockNum] {
+		if !v.ExDate.Before(fromDate) && !v.ExDate.After(toDate) {
+			result.Actions = append(result.Actions, v)
+		}
+	}
+	result.Adjusted = adjust.Closes(result.Raw, result.Actions)
+	return result, nil
+}
+
+// queryFutureMinuteKbarArr returns ascending minute kbars of the trade day, including the night session before it.
+func (uc *HistoryUseCase) queryFutureMinuteKbarArr(code string, tradeDay time.Time) ([]*entity.HistoryKbarBase, error) {
+	kbarArr, err := uc.queryFutureKbarArrByTradeDay(code, tradeDay)
+	if err != nil {
+		return nil, err
+	}
+
+	arr := make([]*entity.HistoryKbarBase, 0, len(kbarArr))
+	for _, v := range kbarArr {
+		arr = append(arr, &v.HistoryKbarBase)
+	}
+	return arr, nil
+}
+
+// resampleKbarArr merges ascending minute kbars of one trade day into the resolution.
+// Minute buckets are aligned to midnight of each bar and labelled by bucket start, day bars are labelled by trade day.
+func resampleKbarArr(tradeDay time.Time, arr []*entity.HistoryKbarBase, resolution entity.KbarResolution) []*entity.HistoryKbarBase {
+	if len(arr) == 0 || resolution == entity.KbarResolution1m {
+		return arr
+	}
+
+	var result []*entity.HistoryKbarBase
+	var current *entity.HistoryKbarBase
+	for _, v := range arr {
+		bucket := tradeDay
+		if minutes := resolution.Minutes(); minutes != 0 {
+			dayStart := time.Date(v.KbarTime.Year(), v.KbarTime.Month(), v.KbarTime.Day(), 0, 0, 0, 0, v.KbarTime.Location())
+			bucket = dayStart.Add(v.KbarTime.Sub(dayStart).Truncate(time.Duration(minutes) * time.Minute))
+		}
+
+		if current == nil || !current.KbarTime.Equal(bucket) {
+			current = &entity.HistoryKbarBase{KbarTime: bucket, Open: v.Open, High: v.High, Low: v.Low}
+			result = append(result, current)
+		}
+		if v.High > current.High {
+			current.High = v.High
+		}
+		if v.Low < current.Low {
+			current.Low = v.Low
+		}
+		current.Close = v.Close
+		current.Volume += v.Volume
+	}
+	return result
+}
+
+// GetIndicators returns indicators of the stock on date, day series end at date, minute series are the bars of date.
+// Intraday minute indicators are refreshed every minute, the others are cached until exit.
+func (uc *HistoryUseCase) GetIndicators(ctx context.Context, code string, interval entity.IndicatorInterval, date string) (*entity.Indicators, error) {
+	if interval != entity.IndicatorIntervalDay && interval != entity.IndicatorIntervalMinute {
+		return nil, ErrIndicatorIntervalInvalid
+	}
+	if uc.cc.GetStockDetail(code) == nil {
+		return nil, ErrIndicatorCodeNotFound
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	d := today
+	if date != "" {
+		var err error
+		if d, err = time.ParseInLocation(entity.ShortTimeLayout, date, time.Local); err != nil || d.After(today) {
+			return nil, ErrIndicatorDateInvalid
+		}
+	}
+
+	intraday := interval == entity.IndicatorIntervalMinute && d.Equal(today)
+	if cached := uc.cc.GetIndicators(code, interval, d); cached != nil {
+		if !intraday || time.Since(cached.Created) < time.Minute {
+			return cached, nil
+		}
+	}
+
+	var kbars []*entity.StockHistoryKbar
+	var err error
+	switch interval {
+	case entity.IndicatorIntervalDay:
+		kbars, err = uc.queryDayKbarForIndicator(code, d, today)
+	case entity.IndicatorIntervalMinute:
+		kbars, err = uc.queryStockMinuteKbarArr(ctx, code, d, intraday)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := &entity.Indicators{
+		Code:     code,
+		Interval: interval,
+		Date:     d,
+		Created:  time.Now(),
+	}
+	uc.indicator.Calculate(result, kbars)
+	uc.cc.SetIndicators(code, interval, d, result)
+	return result, nil
+}
+
+// queryDayKbarForIndicator returns ascending day kbars ending at date, today is excluded since its bar may not be closed.
+func (uc *HistoryUseCase) queryDayKbarForIndicator(code string, date, today time.Time) ([]*entity.StockHistoryKbar, error) {
+	if date.Before(today) {
+		date = date.AddDate(0, 0, 1)
+	}
+	kbars, err := uc.GetDayKbarByStockNumMultiDate(code, date, uc.indicatorCfg.DayCount)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(kbars, func(i, j int) bool {
+		return kbars[i].KbarTime.Before(kbars[j].KbarTime)
+	})
+	return kbars, nil
+}
+
+// queryStockMinuteKbarArr returns ascending minute kbars of date, intraday bars are not stored since they are incomplete.
+func (uc *HistoryUseCase) queryStockMinuteKbarArr(ctx context.Context, code string, date time.Time, intraday bool) ([]*entity.StockHistoryKbar, error) {
+	var kbars []*entity.StockHistoryKbar
+	if !intraday {
+		kbarArrMap, err := uc.repo.QueryMultiStockKbarArrByDate(ctx, []string{code}, date)
+		if err != nil {
+			return nil, err
+		}
+		kbars = kbarArrMap[code]
+	}
+
+	if len(kbars) == 0 {
+		kbarArr, err := uc.grpcapi.GetStockHistoryKbar([]string{code}, date.Format(entity.ShortTimeLayout))
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range kbarArr {
+			kbars = append(kbars, &entity.StockHistoryKbar{
+				StockNum: t.GetCode(),
+				HistoryKbarBase: entity.HistoryKbarBase{
+					KbarTime: time.Unix(0, t.GetTs()).Add(-8 * time.Hour),
+					Open:     t.GetOpen(), High: t.GetHigh(), Low: t.GetLow(),
+					Close: t.GetClose(), Volume: t.GetVolume(),
+				},
+			})
+		}
+		if !intraday && len(kbars) != 0 && uc.partition.writable(ctx, entity.HistoryTableStockKbar, date) {
+			if err := uc.repo.InsertHistoryKbarArr(ctx, kbars); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	sort.SliceStable(kbars, func(i, j int) bool {
+		return kbars[i].KbarTime.Before(kbars[j].KbarTime)
+	})
+	return kbars, nil
+}
+
 func (uc *HistoryUseCase) queryStockKbarByDate(stockNum string, date time.Time) (*entity.StockHistoryKbar, error) {
 	kbarArrMap, err := uc.repo.QueryMultiStockKbarArrByDate(context.Background(), []string{stockNum}, date)
 	if err != nil {
@@ -109,7 +605,7 @@ func (uc *HistoryUseCase) queryStockKbarByDate(stockNum string, date time.Time)
 				},
 			})
 		}
-		if len(arr) != 0 {
+		if len(arr) != 0 && uc.partition.writable(context.Background(), entity.HistoryTableStockKbar, date) {
 			if err := uc.repo.InsertHistoryKbarArr(context.Background(), arr); err != nil {
 				return nil, err
 			}
@@ -157,6 +653,11 @@ func (uc *HistoryUseCase) FetchStockHistory(targetArr []*entity.StockTarget) {
 		uc.logger.Fatal(err)
 	}
 
+	// patterns are scanned over all stocks, not only targets
+	if err = uc.fetchScanUniverseKbar(); err != nil {
+		uc.logger.Errorf("fetch scan universe kbar fail: %s", err)
+	}
+
 	uc.bus.PublishTopicEvent(topicAnalyzeStockTargets, fetchArr)
 }
 
@@ -351,12 +852,38 @@ func (uc *HistoryUseCase) findExistStockHistoryTick(fetchTradeDayArr []time.Time
 }
 
 func (uc *HistoryUseCase) fetchHistoryKbar(targetArr []*entity.StockTarget) error {
-	fetchTradeDayArr := uc.tradeDay.GetLastNStockTradeDay(uc.cfg.History.HistoryKbarPeriod)
 	stockNumArr := []string{}
 	for _, target := range targetArr {
 		stockNumArr = append(stockNumArr, target.StockNum)
 	}
+	return uc.fetchHistoryKbarByDays(stockNumArr, uc.tradeDay.GetLastNStockTradeDay(uc.cfg.History.HistoryKbarPeriod))
+}
+
+// fetchScanUniverseKbar fetches kbars of all stocks in the days read by the pattern scanner, once per trade day.
+func (uc *HistoryUseCase) fetchScanUniverseKbar() error {
+	if uc.cfg.Scanner.ScanDays <= 0 {
+		return nil
+	}
+
+	tradeDay := uc.tradeDay.GetStockTradeDay().TradeDay
+	if uc.scanUniverseFetched.Equal(tradeDay) {
+		return nil
+	}
 
+	stockNumArr := []string{}
+	for num := range uc.cc.GetAllStockDetail() {
+		stockNumArr = append(stockNumArr, num)
+	}
+	// each scanned day reads DayCount days before it
+	fetchTradeDayArr := uc.tradeDay.GetLastNStockTradeDay(uc.cfg.Scanner.ScanDays + uc.cfg.Scanner.DayCount)
+	if err := uc.fetchHistoryKbarByDays(stockNumArr, fetchTradeDayArr); err != nil {
+		return err
+	}
+	uc.scanUniverseFetched = tradeDay
+	return nil
+}
+
+func (uc *HistoryUseCase) fetchHistoryKbarByDays(stockNumArr []string, fetchTradeDayArr []time.Time) error {
 	stockNumArrInDayMap, total, err := uc.findExistHistoryKbar(fetchTradeDayArr, stockNumArr)
 	if err != nil {
 		return err
@@ -449,9 +976,18 @@ func (uc *HistoryUseCase) processCloseArr(arr []*entity.StockHistoryClose) {
 	})
 
 	stockNum := arr[0].StockNum
+	ascArr := make([]*entity.HistoryCloseBase, len(arr))
+	for i, v := range arr {
+		ascArr[len(arr)-1-i] = &v.HistoryCloseBase
+	}
+	ascFactors := adjust.Factors(ascArr, uc.corporateActionMap[stockNum])
+
 	closeArr := []float64{}
-	for _, v := range arr {
-		closeArr = append(closeArr, v.Close)
+	factorArr := []float64{}
+	for i, v := range arr {
+		factor := ascFactors[len(arr)-1-i]
+		closeArr = append(closeArr, v.Close*factor)
+		factorArr = append(factorArr, factor)
 		uc.cc.SetHistoryClose(stockNum, v.Date, v.Close)
 	}
 
@@ -460,8 +996,9 @@ func (uc *HistoryUseCase) processCloseArr(arr []*entity.StockHistoryClose) {
 		if i+int(uc.analyzeStockCfg.MAPeriod) > len(closeArr) {
 			break
 		}
+		// MA of adjusted closes, divided back to the price level of its date to compare with raw prices
 		tmp := closeArr[i : i+int(uc.analyzeStockCfg.MAPeriod)]
-		ma := utils.GenerareMAByCloseArr(tmp)
+		ma := utils.GenerareMAByCloseArr(tmp) / factorArr[i]
 		if err := uc.repo.InsertQuaterMA(context.Background(), &entity.StockHistoryAnalyze{
 			StockNum: stockNum,
 			HistoryAnalyzeBase: entity.HistoryAnalyzeBase{
@@ -560,11 +1097,114 @@ func (uc *HistoryUseCase) processKbarArr(arr []*entity.StockHistoryKbar) {
 	})
 }
 
-// GetFutureHistoryPBKbarByDate -.
+// GetFutureHistoryPBKbarByDate -. A virtual code is the real contract of the trade day.
 func (uc *HistoryUseCase) GetFutureHistoryPBKbarByDate(code string, date time.Time) (*pb.HistoryKbarResponse, error) {
-	kbarArr, err := uc.grpcapi.GetFutureHistoryKbar([]string{code}, date.Format(entity.ShortTimeLayout))
+	tradeDay := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.Local)
+	code, err := uc.continuous.resolve(context.Background(), code, tradeDay)
+	if err != nil {
+		return nil, err
+	}
+	kbarArr, err := uc.queryFutureKbarArrByTradeDay(code, tradeDay)
 	if err != nil {
 		return nil, err
 	}
-	return kbarArr, nil
+
+	result := &pb.HistoryKbarResponse{}
+	for _, v := range kbarArr {
+		result.Data = append(result.Data, &pb.HistoryKbarMessage{
+			Ts:     v.KbarTime.Add(8 * time.Hour).UnixNano(),
+			Close:  v.Close,
+			Open:   v.Open,
+			High:   v.High,
+			Low:    v.Low,
+			Volume: v.Volume,
+			Code:   v.Code,
+		})
+	}
+	return result, nil
+}
+
+// futureKbarCurrentExpire is shared by clients refreshing the current trade day every minute.
+const futureKbarCurrentExpire = 30 * time.Second
+
+// lockFutureKbar locks the code and trade day, the returned unlock also removes the lock from futureKbarLockMap,
+// callers waiting on the removed lock find the day in cache after it is released.
+func (uc *HistoryUseCase) lockFutureKbar(code string, tradeDay time.Time) func() {
+	key := fmt.Sprintf("%s:%s", code, tradeDay.Format(entity.ShortTimeLayout))
+
+	uc.futureKbarLockMapLock.Lock()
+	l, ok := uc.futureKbarLockMap[key]
+	if !ok {
+		l = &sync.Mutex{}
+		uc.futureKbarLockMap[key] = l
+	}
+	uc.futureKbarLockMapLock.Unlock()
+
+	l.Lock()
+	return func() {
+		uc.futureKbarLockMapLock.Lock()
+		if uc.futureKbarLockMap[key] == l {
+			delete(uc.futureKbarLockMap, key)
+		}
+		uc.futureKbarLockMapLock.Unlock()
+		l.Unlock()
+	}
+}
+
+// queryFutureKbarArrByTradeDay reads ascending kbars of the trade day through memory and postgres.
+// Completed days are fetched from gRPC once and stored even if empty,
+// the current trade day is cached for futureKbarCurrentExpire and never stored.
+func (uc *HistoryUseCase) queryFutureKbarArrByTradeDay(code string, tradeDay time.Time) ([]*entity.FutureHistoryKbar, error) {
+	if arr, ok := uc.cc.GetFutureKbarArr(code, tradeDay); ok {
+		return arr, nil
+	}
+
+	defer uc.lockFutureKbar(code, tradeDay)()
+	if arr, ok := uc.cc.GetFutureKbarArr(code, tradeDay); ok {
+		return arr, nil
+	}
+
+	completed := tradeDay.Before(uc.tradeDay.GetFutureTradeDay().TradeDay)
+	if completed {
+		arr, stored, err := uc.repo.QueryFutureHistoryKbarByTradeDay(context.Background(), code, tradeDay)
+		if err != nil {
+			return nil, err
+		}
+		if stored {
+			uc.cc.SetFutureKbarArr(code, tradeDay, arr)
+			return arr, nil
+		}
+	}
+
+	fetch, err := uc.grpcapi.GetFutureHistoryKbar([]string{code}, tradeDay.Format(entity.ShortTimeLayout))
+	if err != nil {
+		return nil, err
+	}
+	arr := []*entity.FutureHistoryKbar{}
+	for _, t := range fetch.GetData() {
+		arr = append(arr, &entity.FutureHistoryKbar{
+			Code: code,
+			HistoryKbarBase: entity.HistoryKbarBase{
+				KbarTime: time.Unix(0, t.GetTs()).Add(-8 * time.Hour),
+				Open:     t.GetOpen(), High: t.GetHigh(), Low: t.GetLow(),
+				Close: t.GetClose(), Volume: t.GetVolume(),
+			},
+		})
+	}
+	sort.SliceStable(arr, func(i, j int) bool {
+		return arr[i].KbarTime.Before(arr[j].KbarTime)
+	})
+	if !completed {
+		uc.cc.SetCurrentFutureKbarArr(code, tradeDay, arr, futureKbarCurrentExpire)
+		return arr, nil
+	}
+
+	if err := uc.repo.DeleteFutureHistoryKbarByTradeDay(context.Background(), code, tradeDay); err != nil {
+		return nil, err
+	}
+	if err := uc.repo.InsertFutureHistoryKbarArr(context.Background(), code, tradeDay, arr); err != nil {
+		return nil, err
+	}
+	uc.cc.SetFutureKbarArr(code, tradeDay, arr)
+	return arr, nil
 }
@@ -0,0 +1,345 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/robfig/cron/v3"
+	"github.com/toc-taiwan/toc-machine-trading/internal/config"
+	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
+	"github.com/toc-taiwan/toc-machine-trading/internal/usecase/modules/notifier"
+	"github.com/toc-taiwan/toc-machine-trading/internal/usecase/repo"
+	"github.com/toc-taiwan/toc-machine-trading/pkg/eventbus"
+	"github.com/toc-taiwan/toc-machine-trading/pkg/log"
+)
+
+// orderStatusClearSpec is between the night session close and the day session open
+const orderStatusClearSpec = "0 8 * * *"
+
+// NotifyUseCase routes order fills, alerts and reports to the channels each user chose.
+type NotifyUseCase struct {
+	repo repo.SystemRepo
+
+	notifiers map[entity.NotifyChannel]notifier.Notifier
+
+	authUsers     []string
+	authUsersLock sync.RWMutex
+
+	// orderStatusMap keeps the last pushed status of each order, cleared every trading day
+	orderStatusMap     map[string]entity.OrderStatus
+	orderStatusMapLock sync.Mutex
+
+	logger *log.Log
+	bus    *eventbus.Bus
+}
+
+// NewNotify -.
+func NewNotify() Notify {
+	cfg := config.Get()
+	uc := &NotifyUseCase{
+		repo:           repo.NewSystemRepo(cfg.GetPostgresPool()),
+		notifiers:      make(map[entity.NotifyChannel]notifier.Notifier),
+		orderStatusMap: make(map[string]entity.OrderStatus),
+		logger:         log.Get(),
+		bus:            eventbus.Get(),
+	}
+
+	if fcm, err := newFCMNotifier(uc.repo, uc.bus); err != nil {
+		uc.setNotifier(uc.standIn(cfg.Notify, entity.NotifyChannelFCM))
+	} else {
+		uc.setNotifier(fcm)
+	}
+
+	if smtp := notifier.NewSMTP(cfg.SMTP); smtp.Configured() {
+		uc.setNotifier(smtp)
+	} else {
+		uc.setNotifier(uc.standIn(cfg.Notify, entity.NotifyChannelEmail))
+	}
+
+	uc.setNotifier(notifier.NewSlack())
+	uc.setNotifier(notifier.NewWebhook())
+
+	uc.bus.SubscribeAsync(topicUpdateAuthTradeUser, true, uc.updateAuthUser)
+	uc.bus.SubscribeAsync(topicInsertOrUpdateStockOrder, true, uc.notifyStockOrder)
+	uc.bus.SubscribeAsync(topicInsertOrUpdateFutureOrder, true, uc.notifyFutureOrder)
+	uc.bus.SubscribeAsync(topicAlertTriggered, false, uc.notifyAlert)
+	uc.bus.SubscribeAsync(topicDailyReportCreated, false, uc.notifyDailyReport)
+	uc.bus.SubscribeAsync(topicFutureExpiryWarning, false, uc.notifyFutureExpiry)
+
+	job := cron.New()
+	if _, err := job.AddFunc(orderStatusClearSpec, uc.clearOrderStatus); err != nil {
+		uc.logger.Fatal(err)
+	}
+	job.Start()
+
+	return uc
+}
+
+func (uc *NotifyUseCase) setNotifier(n notifier.Notifier) {
+	uc.notifiers[n.Channel()] = n
+}
+
+// standIn is used for channels without config, messages go to the file for local runs.
+func (uc *NotifyUseCase) standIn(cfg config.Notify, channel entity.NotifyChannel) notifier.Notifier {
+	if cfg.FilePath == "" {
+		uc.logger.Warnf("Notify channel %s is not configured, messages are dropped", channel)
+		return notifier.NewNop(channel)
+	}
+	uc.logger.Warnf("Notify channel %s is not configured, messages are written to %s", channel, cfg.FilePath)
+	return notifier.NewFile(channel, cfg.FilePath)
+}
+
+func (uc *NotifyUseCase) GetNotifyPreference(ctx context.Context, username string) (*entity.NotifyPreference, error) {
+	user, err := uc.repo.QueryUserByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	pref, err := uc.repo.QueryNotifyPreference(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	for event := range entity.DefaultNotifyChannels {
+		pref.Channels[event] = pref.ChannelsOf(event)
+	}
+	return pref, nil
+}
+
+func (uc *NotifyUseCase) UpdateNotifyPreference(ctx context.Context, username string, t *entity.NotifyPreference) error {
+	user, err := uc.repo.QueryUserByUsername(ctx, username)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+
+	if err = checkNotifyPreference(ctx, t); err != nil {
+		return err
+	}
+	return uc.repo.ReplaceNotifyPreference(ctx, user.ID, t)
+}
+
+func checkNotifyPreference(ctx context.Context, t *entity.NotifyPreference) error {
+	if t.SlackWebhook != "" && notifier.CheckSlackWebhook(t.SlackWebhook) != nil {
+		return ErrNotifyAddressInvalid
+	}
+	if t.WebhookURL != "" && notifier.CheckWebhookURL(ctx, t.WebhookURL) != nil {
+		return ErrNotifyAddressInvalid
+	}
+
+	for event, channels := range t.Channels {
+		if _, ok := entity.DefaultNotifyChannels[event]; !ok {
+			return ErrNotifyChannelInvalid
+		}
+		for _, c := range channels {
+			switch c {
+			case entity.NotifyChannelFCM, entity.NotifyChannelEmail:
+			case entity.NotifyChannelSlack:
+				if t.SlackWebhook == "" {
+					return ErrNotifyAddressInvalid
+				}
+			case entity.NotifyChannelWebhook:
+				if t.WebhookURL == "" {
+					return ErrNotifyAddressInvalid
+				}
+			default:
+				return ErrNotifyChannelInvalid
+			}
+		}
+	}
+	return nil
+}
+
+// notifyUser sends the message to the channels the user chose for the event, failures are only logged.
+func (uc *NotifyUseCase) notifyUser(ctx context.Context, userID int, event entity.NotifyEvent, msg *notifier.Message) {
+	user, err := uc.repo.QueryUserByID(ctx, userID)
+	if err != nil {
+		uc.logger.Error(err)
+		return
+	}
+	if user == nil {
+		return
+	}
+
+	pref, err := uc.repo.QueryNotifyPreference(ctx, userID)
+	if err != nil {
+		uc.logger.Error(err)
+		return
+	}
+
+	to := &notifier.Recipient{
+		SlackWebhook: pref.SlackWebhook,
+		WebhookURL:   pref.WebhookURL,
+	}
+	if user.EmailVerified {
+		to.Email = user.Email
+	}
+
+	for _, c := range pref.ChannelsOf(event) {
+		n, ok := uc.notifiers[c]
+		if !ok {
+			continue
+		}
+		if c == entity.NotifyChannelFCM && to.PushTokens == nil {
+			if to.PushTokens, err = uc.repo.QueryPushTokensByUserID(ctx, userID); err != nil {
+				uc.logger.Error(err)
+				continue
+			}
+		}
+		if err = n.Notify(ctx, to, msg); err != nil {
+			uc.logger.Errorf("notify %s of %s to %s fail: %s", event, c, user.Username, err)
+		}
+	}
+}
+
+func (uc *NotifyUseCase) updateAuthUser(username []string) {
+	uc.authUsersLock.Lock()
+	defer uc.authUsersLock.Unlock()
+	uc.authUsers = username
+}
+
+func (uc *NotifyUseCase) clearOrderStatus() {
+	uc.orderStatusMapLock.Lock()
+	defer uc.orderStatusMapLock.Unlock()
+	uc.orderStatusMap = make(map[string]entity.OrderStatus)
+}
+
+// notifyOrderUpdate pushes each status change to trade authorized users and users opted in to order updates,
+// fills go to the channels users chose, other changes are data only pushes for the app to refresh.
+func (uc *NotifyUseCase) notifyOrderUpdate(detail entity.OrderDetail, key, body string, order interface{}) {
+	uc.orderStatusMapLock.Lock()
+	if last, ok := uc.orderStatusMap[detail.OrderID]; ok && last == detail.Status {
+		uc.orderStatusMapLock.Unlock()
+		return
+	}
+	uc.orderStatusMap[detail.OrderID] = detail.Status
+	uc.orderStatusMapLock.Unlock()
+
+	data, err := json.Marshal(order)
+	if err != nil {
+		return
+	}
+
+	uc.authUsersLock.RLock()
+	authUsers := uc.authUsers
+	uc.authUsersLock.RUnlock()
+
+	ctx := context.Background()
+	userIDs, err := uc.repo.QueryOrderNotifyUserID(ctx, authUsers)
+	if err != nil {
+		uc.logger.Error(err)
+		return
+	}
+
+	msg := &notifier.Message{
+		Body: body,
+		Data: map[string]string{
+			key: string(data),
+		},
+	}
+	if detail.Status != entity.StatusFilled {
+		for _, id := range userIDs {
+			uc.pushUser(ctx, id, msg)
+		}
+		return
+	}
+
+	msg.Title = "Order Filled"
+	for _, id := range userIDs {
+		uc.notifyUser(ctx, id, entity.NotifyEventOrderFill, msg)
+	}
+}
+
+// pushUser sends to push tokens of the user only, regardless of preference.
+func (uc *NotifyUseCase) pushUser(ctx context.Context, userID int, msg *notifier.Message) {
+	tokens, err := uc.repo.QueryPushTokensByUserID(ctx, userID)
+	if err != nil {
+		uc.logger.Error(err)
+		return
+	}
+	if len(tokens) == 0 {
+		return
+	}
+	if err = uc.notifiers[entity.NotifyChannelFCM].Notify(ctx, &notifier.Recipient{PushTokens: tokens}, msg); err != nil {
+		uc.logger.Errorf("push to user id %d fail: %s", userID, err)
+	}
+}
+
+func (uc *NotifyUseCase) notifyStockOrder(order *entity.StockOrder) {
+	uc.notifyOrderUpdate(order.OrderDetail, "order_update", order.StockOrderStatusString(), order)
+}
+
+func (uc *NotifyUseCase) notifyFutureOrder(order *entity.FutureOrder) {
+	uc.notifyOrderUpdate(order.OrderDetail, "future_order_update", order.FutureOrderStatusString(), order)
+}
+
+func (uc *NotifyUseCase) notifyAlert(history *entity.AlertHistory) {
+	data, err := json.Marshal(history)
+	if err != nil {
+		return
+	}
+
+	uc.notifyUser(context.Background(), history.UserID, entity.NotifyEventAlert, &notifier.Message{
+		Title: "Alert",
+		Body:  history.Message,
+		Data: map[string]string{
+			"alert": string(data),
+		},
+	})
+}
+
+// notifyDailyReport goes to the same users as order fills.
+func (uc *NotifyUseCase) notifyDailyReport(report *entity.DailyReport) {
+	uc.authUsersLock.RLock()
+	authUsers := uc.authUsers
+	uc.authUsersLock.RUnlock()
+
+	ctx := context.Background()
+	userIDs, err := uc.repo.QueryOrderNotifyUserID(ctx, authUsers)
+	if err != nil {
+		uc.logger.Error(err)
+		return
+	}
+
+	msg := &notifier.Message{
+		Title: fmt.Sprintf("Daily Report %s", report.Date.Format(entity.ShortTimeLayout)),
+		Body:  report.Summary(),
+		Data: map[string]string{
+			"daily_report": report.Date.Format(entity.ShortTimeLayout),
+		},
+	}
+	for _, id := range userIDs {
+		uc.notifyUser(ctx, id, entity.NotifyEventDailyReport, msg)
+	}
+}
+
+// notifyFutureExpiry goes to the same users as order fills.
+func (uc *NotifyUseCase) notifyFutureExpiry(warning *entity.FutureExpiryWarning) {
+	uc.authUsersLock.RLock()
+	authUsers := uc.authUsers
+	uc.authUsersLock.RUnlock()
+
+	ctx := context.Background()
+	userIDs, err := uc.repo.QueryOrderNotifyUserID(ctx, authUsers)
+	if err != nil {
+		uc.logger.Error(err)
+		return
+	}
+
+	msg := &notifier.Message{
+		Title: fmt.Sprintf("Future Expiry %s", warning.Code),
+		Body:  warning.String(),
+		Data: map[string]string{
+			"future_expiry": warning.Code,
+		},
+	}
+	for _, id := range userIDs {
+		uc.notifyUser(ctx, id, entity.NotifyEventFutureExpiry, msg)
+	}
+}
@@ -0,0 +1,266 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/toc-taiwan/toc-machine-trading/internal/config"
+	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
+	"github.com/toc-taiwan/toc-machine-trading/internal/usecase/cache"
+	"github.com/toc-taiwan/toc-machine-trading/internal/usecase/grpc"
+	"github.com/toc-taiwan/toc-machine-trading/internal/usecase/modules/blackscholes"
+	"github.com/toc-taiwan/toc-trade-protobuf/golang/pb"
+)
+
+// optionChainRefresh is how long a chain with quotes is shared between requests.
+const optionChainRefresh = 3 * time.Second
+
+// OptionUseCase groups imported options into chains and prices them against the underlying future.
+type OptionUseCase struct {
+	grpcapi grpc.RealTimegRPCAPI
+	rate    float64
+
+	chainCache map[string]*entity.OptionChain
+	chainLock  sync.Mutex
+
+	cc *cache.Cache
+}
+
+// NewOption -.
+func NewOption() Option {
+	cfg := config.Get()
+	return &OptionUseCase{
+		grpcapi:    grpc.NewRealTime(cfg.GetSinopacConn()),
+		rate:       cfg.Option.RiskFreeRate / 100,
+		chainCache: make(map[string]*entity.OptionChain),
+		cc:         cache.Get(),
+	}
+}
+
+// GetOptionChains returns all chains sorted by category and delivery date.
+func (uc *OptionUseCase) GetOptionChains(ctx context.Context) []*entity.OptionChainInfo {
+	chainMap := make(map[string]*entity.OptionChainInfo)
+	strikeMap := make(map[string]map[float64]struct{})
+	for _, v := range uc.cc.GetAllOptionDetail() {
+		key := optionChainKey(v.Category, v.DeliveryMonth)
+		if _, ok := chainMap[key]; !ok {
+			chainMap[key] = &entity.OptionChainInfo{
+				Category:       v.Category,
+				DeliveryMonth:  v.DeliveryMonth,
+				DeliveryDate:   v.DeliveryDate,
+				UnderlyingKind: v.UnderlyingKind,
+			}
+			strikeMap[key] = make(map[float64]struct{})
+		}
+		strikeMap[key][v.StrikePrice] = struct{}{}
+	}
+
+	result := make([]*entity.OptionChainInfo, 0, len(chainMap))
+	for key, v := range chainMap {
+		v.StrikeCount = len(strikeMap[key])
+		result = append(result, v)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Category != result[j].Category {
+			return result[i].Category < result[j].Category
+		}
+		return result[i].DeliveryDate.Before(result[j].DeliveryDate)
+	})
+	return result
+}
+
+// GetOptionChain returns the chain with quotes, iv and greeks. Underlying is optional, default is the nearest future of the category.
+func (uc *OptionUseCase) GetOptionChain(ctx context.Context, category, deliveryMonth, underlying string) (*entity.OptionChain, error) {
+	key := fmt.Sprintf("%s:%s", optionChainKey(category, deliveryMonth), underlying)
+	// only the map is locked, requests missing the cache at the same time may both fetch quotes
+	uc.chainLock.Lock()
+	cached := uc.chainCache[key]
+	uc.chainLock.Unlock()
+	if cached != nil && time.Since(cached.Updated) < optionChainRefresh {
+		return cached, nil
+	}
+
+	var options []*entity.Option
+	for _, v := range uc.cc.GetAllOptionDetail() {
+		if v.Category == category && v.DeliveryMonth == deliveryMonth {
+			options = append(options, v)
+		}
+	}
+	if len(options) == 0 {
+		return nil, ErrOptionChainNotFound
+	}
+
+	future, err := uc.underlyingFuture(options[0], underlying)
+	if err != nil {
+		return nil, err
+	}
+
+	codeArr := make([]string, 0, len(options))
+	for _, v := range options {
+		codeArr = append(codeArr, v.Code)
+	}
+	snapshots, err := uc.grpcapi.GetOptionSnapshotByCodeArr(codeArr)
+	if err != nil {
+		return nil, err
+	}
+	snapshotMap := make(map[string]*pb.SnapshotMessage)
+	for _, v := range snapshots {
+		snapshotMap[v.GetCode()] = v
+	}
+
+	chain := &entity.OptionChain{
+		OptionChainInfo: entity.OptionChainInfo{
+			Category:       category,
+			DeliveryMonth:  deliveryMonth,
+			DeliveryDate:   options[0].DeliveryDate,
+			UnderlyingKind: options[0].UnderlyingKind,
+		},
+		Underlying: future,
+		Strikes:    []*entity.OptionStrike{},
+		Updated:    time.Now(),
+	}
+	strikeMap := make(map[float64]*entity.OptionStrike)
+	for _, v := range options {
+		strike := strikeMap[v.StrikePrice]
+		if strike == nil {
+			strike = &entity.OptionStrike{StrikePrice: v.StrikePrice}
+			strikeMap[v.StrikePrice] = strike
+			chain.Strikes = append(chain.Strikes, strike)
+		}
+
+		quote := uc.optionQuote(v, snapshotMap[v.Code], future.Price)
+		if v.IsCall() {
+			strike.Call = quote
+		} else {
+			strike.Put = quote
+		}
+	}
+	sort.SliceStable(chain.Strikes, func(i, j int) bool {
+		return chain.Strikes[i].StrikePrice < chain.Strikes[j].StrikePrice
+	})
+	chain.StrikeCount = len(chain.Strikes)
+
+	uc.chainLock.Lock()
+	uc.chainCache[key] = chain
+	uc.chainLock.Unlock()
+	return chain, nil
+}
+
+// GetOptionSnapshot returns the quote of one option priced against the nearest future of its category.
+func (uc *OptionUseCase) GetOptionSnapshot(ctx context.Context, code string) (*entity.OptionQuote, error) {
+	option := uc.cc.GetOptionDetail(code)
+	if option == nil {
+		return nil, ErrOptionNotFound
+	}
+
+	future, err := uc.underlyingFuture(option, "")
+	if err != nil {
+		return nil, err
+	}
+	snapshots, err := uc.grpcapi.GetOptionSnapshotByCodeArr([]string{code})
+	if err != nil {
+		return nil, err
+	}
+	if len(snapshots) == 0 {
+		return nil, ErrOptionNotFound
+	}
+	return uc.optionQuote(option, snapshots[0], future.Price), nil
+}
+
+// TAIEX options without a future of its own category, e.g. weekly TX1 to TX5, are priced against TXF.
+const (
+	optionFallbackCategoryPrefix = "TX"
+	optionFallbackFutureCategory = "TXF"
+)
+
+// underlyingFuture uses the code if given, otherwise the future of the category with the nearest delivery not before the option.
+// Future category is the option category ending with F instead of O, e.g. TXO is priced against TXF.
+// Other TAIEX categories fall back to TXF, stock options have no matching future and need the underlying code from the caller.
+func (uc *OptionUseCase) underlyingFuture(option *entity.Option, code string) (*entity.OptionUnderlying, error) {
+	var future *entity.Future
+	if code != "" {
+		future = uc.cc.GetFutureDetail(code)
+	} else {
+		future = uc.nearestFuture(strings.TrimSuffix(option.Category, "O")+"F", option.DeliveryDate)
+		if future == nil && strings.HasPrefix(option.Category, optionFallbackCategoryPrefix) {
+			future = uc.nearestFuture(optionFallbackFutureCategory, option.DeliveryDate)
+		}
+	}
+	if future == nil {
+		return nil, ErrOptionUnderlyingNotFound
+	}
+
+	snapshot, err := uc.grpcapi.GetFutureSnapshotByCode(future.Code)
+	if err != nil {
+		return nil, err
+	}
+	return &entity.OptionUnderlying{
+		Code:  future.Code,
+		Price: snapshot.GetClose(),
+	}, nil
+}
+
+// nearestFuture returns the future of the category with the nearest delivery not before the date, spreads excluded.
+func (uc *OptionUseCase) nearestFuture(category string, date time.Time) *entity.Future {
+	var future *entity.Future
+	for _, v := range uc.cc.GetAllFutureDetail() {
+		if v.Category != category || v.DeliveryDate.Before(date) {
+			continue
+		}
+		if strings.Contains(strings.ToUpper(v.Code), "R1") || strings.Contains(strings.ToUpper(v.Code), "R2") {
+			continue
+		}
+		if future == nil || v.DeliveryDate.Before(future.DeliveryDate) {
+			future = v
+		}
+	}
+	return future
+}
+
+// optionQuote solves iv from the mid price if both sides are quoted, otherwise from the last price.
+func (uc *OptionUseCase) optionQuote(option *entity.Option, snapshot *pb.SnapshotMessage, futurePrice float64) *entity.OptionQuote {
+	quote := &entity.OptionQuote{Code: option.Code}
+	if snapshot == nil {
+		return quote
+	}
+	quote.Close = snapshot.GetClose()
+	quote.BuyPrice = snapshot.GetBuyPrice()
+	quote.SellPrice = snapshot.GetSellPrice()
+	quote.ChangePrice = snapshot.GetChangePrice()
+	quote.TotalVolume = snapshot.GetTotalVolume()
+
+	price := quote.Close
+	if quote.BuyPrice > 0 && quote.SellPrice > 0 {
+		price = (quote.BuyPrice + quote.SellPrice) / 2
+	}
+	in := blackscholes.Input{
+		Call:   option.IsCall(),
+		Future: futurePrice,
+		Strike: option.StrikePrice,
+		Years:  time.Until(option.DeliveryDate).Hours() / 24 / 365,
+		Rate:   uc.rate,
+	}
+	iv, ok := blackscholes.ImpliedVolatility(in, price)
+	if !ok {
+		return quote
+	}
+	quote.IV = iv
+	if greeks := blackscholes.CalculateGreeks(in, iv); greeks != nil {
+		quote.Greeks = &entity.OptionGreeks{
+			Delta: greeks.Delta,
+			Gamma: greeks.Gamma,
+			Theta: greeks.Theta,
+			Vega:  greeks.Vega,
+			Rho:   greeks.Rho,
+		}
+	}
+	return quote
+}
+
+func optionChainKey(category, deliveryMonth string) string {
+	return fmt.Sprintf("%s:%s", category, deliveryMonth)
+}
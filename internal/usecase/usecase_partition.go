@@ -0,0 +1,213 @@
+package usecase
+
+import (
+	"compress/gzip"
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/toc-taiwan/toc-machine-trading/internal/config"
+	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
+	"github.com/toc-taiwan/toc-machine-trading/internal/usecase/modules/calendar"
+	"github.com/toc-taiwan/toc-machine-trading/internal/usecase/modules/partition"
+	"github.com/toc-taiwan/toc-machine-trading/internal/usecase/repo"
+	"github.com/toc-taiwan/toc-machine-trading/pkg/log"
+)
+
+// partitionMaintainSpec is before the day session opens.
+const partitionMaintainSpec = "0 8 * * *"
+
+type partitionPolicy struct {
+	table           entity.HistoryTable
+	retentionMonths int
+	historyPeriod   int64
+}
+
+// partitionMaintainer keeps monthly partitions of stock history, created ahead and archived after retention.
+type partitionMaintainer struct {
+	repo     repo.PartitionRepo
+	tradeDay *calendar.Calendar
+	cfg      config.Partition
+	policies []partitionPolicy
+	logger   *log.Log
+
+	// existMap is partitions known to exist, an insert out of it creates the partition first
+	existMap  map[string]struct{}
+	existLock sync.Mutex
+}
+
+func newPartitionMaintainer() *partitionMaintainer {
+	cfg := config.Get()
+	return &partitionMaintainer{
+		repo:     repo.NewPartition(cfg.GetPostgresPool()),
+		tradeDay: calendar.Get(),
+		cfg:      cfg.Partition,
+		policies: []partitionPolicy{
+			{table: entity.HistoryTableStockTick, retentionMonths: cfg.Partition.TickRetentionMonths, historyPeriod: cfg.History.HistoryTickPeriod},
+			{table: entity.HistoryTableStockKbar, retentionMonths: cfg.Partition.KbarRetentionMonths, historyPeriod: cfg.History.HistoryKbarPeriod},
+			{table: entity.HistoryTableStockClose, retentionMonths: cfg.Partition.CloseRetentionMonths, historyPeriod: cfg.History.HistoryClosePeriod},
+		},
+		logger:   log.Get(),
+		existMap: make(map[string]struct{}),
+	}
+}
+
+// fetchFrom is the oldest trade day fetched into the table, or the current month if nothing is fetched.
+func (m *partitionMaintainer) fetchFrom(p partitionPolicy, now time.Time) time.Time {
+	if p.historyPeriod > 0 {
+		if arr := m.tradeDay.GetLastNStockTradeDay(p.historyPeriod); len(arr) != 0 {
+			return arr[len(arr)-1]
+		}
+	}
+	return partition.MonthStart(now)
+}
+
+// keepFrom is the start of retention, never after the oldest trade day fetched, zero keeps all.
+func (m *partitionMaintainer) keepFrom(p partitionPolicy, now time.Time) time.Time {
+	if p.retentionMonths <= 0 {
+		return time.Time{}
+	}
+	keep := partition.MonthStart(now).AddDate(0, -p.retentionMonths, 0)
+	if fetchFrom := m.fetchFrom(p, now); fetchFrom.Before(keep) {
+		keep = fetchFrom
+	}
+	return partition.MonthStart(keep)
+}
+
+func (m *partitionMaintainer) plan(ctx context.Context, p partitionPolicy, now time.Time) ([]*entity.HistoryPartition, []*entity.HistoryPartition, error) {
+	existing, err := m.repo.QueryPartitionNameArr(ctx, p.table)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m.existLock.Lock()
+	for _, name := range existing {
+		m.existMap[name] = struct{}{}
+	}
+	m.existLock.Unlock()
+
+	until := partition.MonthStart(now).AddDate(0, m.cfg.PremakeMonths, 0)
+	create, expired := partition.Plan(p.table, existing, m.fetchFrom(p, now), until, m.keepFrom(p, now))
+	return create, expired, nil
+}
+
+// maintain creates partitions first, then archives and drops expired ones.
+func (m *partitionMaintainer) maintain() {
+	m.createPartitions()
+	m.expirePartitions()
+}
+
+// createPartitions covers the fetch period and PremakeMonths ahead, it must finish before history is fetched.
+func (m *partitionMaintainer) createPartitions() {
+	ctx := context.Background()
+	now := time.Now()
+	for _, p := range m.policies {
+		create, _, err := m.plan(ctx, p, now)
+		if err != nil {
+			m.logger.Errorf("plan partitions of %s fail: %s", p.table, err)
+			continue
+		}
+		for _, v := range create {
+			if err := m.create(ctx, v); err != nil {
+				m.logger.Errorf("create partition %s fail: %s", v.Name, err)
+			}
+		}
+	}
+}
+
+func (m *partitionMaintainer) expirePartitions() {
+	ctx := context.Background()
+	now := time.Now()
+	for _, p := range m.policies {
+		_, expired, err := m.plan(ctx, p, now)
+		if err != nil {
+			m.logger.Errorf("plan partitions of %s fail: %s", p.table, err)
+			continue
+		}
+		for _, v := range expired {
+			if err := m.expire(ctx, v); err != nil {
+				m.logger.Errorf("expire partition %s fail: %s", v.Name, err)
+				break
+			}
+		}
+	}
+}
+
+func (m *partitionMaintainer) create(ctx context.Context, p *entity.HistoryPartition) error {
+	m.existLock.Lock()
+	defer m.existLock.Unlock()
+
+	if _, ok := m.existMap[p.Name]; ok {
+		return nil
+	}
+	if err := m.repo.CreatePartition(ctx, p); err != nil {
+		return err
+	}
+	m.existMap[p.Name] = struct{}{}
+	m.logger.Infof("Partition %s created", p.Name)
+	return nil
+}
+
+// expire drops the partition only after it is archived, if ArchiveDir is set.
+func (m *partitionMaintainer) expire(ctx context.Context, p *entity.HistoryPartition) error {
+	if m.cfg.ArchiveDir != "" {
+		if err := m.archive(ctx, p); err != nil {
+			return err
+		}
+	}
+	if err := m.repo.DropPartition(ctx, p); err != nil {
+		return err
+	}
+
+	m.existLock.Lock()
+	delete(m.existMap, p.Name)
+	m.existLock.Unlock()
+	m.logger.Infof("Partition %s expired", p.Name)
+	return nil
+}
+
+// archive writes a temp file and renames it, so a file in ArchiveDir is always complete.
+func (m *partitionMaintainer) archive(ctx context.Context, p *entity.HistoryPartition) error {
+	if err := os.MkdirAll(m.cfg.ArchiveDir, 0o750); err != nil {
+		return err
+	}
+	path := filepath.Join(m.cfg.ArchiveDir, p.Name+".csv.gz")
+	tmp := path + ".tmp"
+
+	file, err := os.Create(filepath.Clean(tmp))
+	if err != nil {
+		return err
+	}
+	gz := gzip.NewWriter(file)
+	if err = m.repo.ArchivePartition(ctx, p, gz); err == nil {
+		err = gz.Close()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// writable creates the partition of t if it is missing, false if t is out of retention and should not be stored.
+func (m *partitionMaintainer) writable(ctx context.Context, table entity.HistoryTable, t time.Time) bool {
+	for _, p := range m.policies {
+		if p.table != table {
+			continue
+		}
+		if keepFrom := m.keepFrom(p, time.Now()); !keepFrom.IsZero() && t.Before(keepFrom) {
+			return false
+		}
+	}
+
+	if err := m.create(ctx, partition.Of(table, t)); err != nil {
+		m.logger.Errorf("create partition of %s fail: %s", t.Format(entity.ShortTimeLayout), err)
+		return false
+	}
+	return true
+}
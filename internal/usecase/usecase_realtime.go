@@ -234,6 +234,8 @@ func (uc *RealTimeUseCase) ReceiveOrderStatus(ctx context.Context) {
 				uc.bus.PublishTopicEvent(topicInsertOrUpdateStockOrder, t)
 			case *entity.FutureOrder:
 				uc.bus.PublishTopicEvent(topicInsertOrUpdateFutureOrder, t)
+			case *entity.OptionOrder:
+				uc.bus.PublishTopicEvent(topicInsertOrUpdateOptionOrder, t)
 			}
 		}
 	}()
@@ -0,0 +1,253 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/toc-taiwan/toc-machine-trading/internal/config"
+	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
+	"github.com/toc-taiwan/toc-machine-trading/internal/usecase/grpc"
+	"github.com/toc-taiwan/toc-machine-trading/internal/usecase/modules/calendar"
+	"github.com/toc-taiwan/toc-machine-trading/internal/usecase/modules/quota"
+	"github.com/toc-taiwan/toc-machine-trading/internal/usecase/repo"
+	"github.com/toc-taiwan/toc-machine-trading/pkg/eventbus"
+	"github.com/toc-taiwan/toc-machine-trading/pkg/log"
+)
+
+// dailyReportSpec is after the future close at 13:45 and before the exit at 14:40.
+const (
+	dailyReportSpec   = "50 13 * * *"
+	dailyReportHour   = 13
+	dailyReportMinute = 50
+)
+
+// ReportUseCase generates the end-of-day report from balances, orders, account and inventory.
+type ReportUseCase struct {
+	repo      repo.ReportRepo
+	tradeRepo repo.TradeRepo
+	sc        grpc.TradegRPCAPI
+
+	quota    *quota.Quota
+	tradeDay *calendar.Calendar
+
+	logger *log.Log
+	bus    *eventbus.Bus
+}
+
+// NewReport -.
+func NewReport() Report {
+	cfg := config.Get()
+	uc := &ReportUseCase{
+		repo:      repo.NewReport(cfg.GetPostgresPool()),
+		tradeRepo: repo.NewTrade(cfg.GetPostgresPool()),
+		sc:        grpc.NewTrade(cfg.GetSinopacConn(), cfg.Simulation),
+		quota:     quota.NewQuota(cfg.Quota),
+		tradeDay:  calendar.Get(),
+		logger:    log.Get(),
+		bus:       eventbus.Get(),
+	}
+
+	job := cron.New()
+	if _, err := job.AddFunc(dailyReportSpec, uc.generateTodayReport); err != nil {
+		uc.logger.Fatal(err)
+	}
+	job.Start()
+
+	// the cron does not run while the service is down, catch up if restarted after the spec time
+	go uc.generateMissedTodayReport()
+
+	return uc
+}
+
+func (uc *ReportUseCase) GetDailyReport(ctx context.Context, date string) (*entity.DailyReport, error) {
+	d, err := time.ParseInLocation(entity.ShortTimeLayout, date, time.Local)
+	if err != nil {
+		return nil, ErrReportDateInvalid
+	}
+
+	report, err := uc.repo.QueryDailyReportByDate(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+	if report == nil {
+		return nil, ErrReportNotFound
+	}
+	return report, nil
+}
+
+func (uc *ReportUseCase) generateTodayReport() {
+	date := time.Now().Format(entity.ShortTimeLayout)
+	if _, err := uc.tradeDay.GetStockTradePeriodByDate(date); err != nil {
+		return
+	}
+
+	report, err := uc.generateDailyReport(context.Background(), date)
+	if err != nil {
+		uc.logger.Errorf("generate daily report of %s fail: %s", date, err)
+		return
+	}
+	uc.logger.Infof("Daily report of %s generated, net pnl %d", date, report.NetPnL)
+	uc.bus.PublishTopicEvent(topicDailyReportCreated, report)
+}
+
+// generateMissedTodayReport generates today's report if it is past the spec time and not generated yet.
+func (uc *ReportUseCase) generateMissedTodayReport() {
+	now := time.Now()
+	if now.Before(time.Date(now.Year(), now.Month(), now.Day(), dailyReportHour, dailyReportMinute, 0, 0, time.Local)) {
+		return
+	}
+
+	date := now.Format(entity.ShortTimeLayout)
+	period, err := uc.tradeDay.GetStockTradePeriodByDate(date)
+	if err != nil {
+		return
+	}
+
+	report, err := uc.repo.QueryDailyReportByDate(context.Background(), period.TradeDay)
+	if err != nil {
+		uc.logger.Error(err)
+		return
+	}
+	if report != nil {
+		return
+	}
+	uc.generateTodayReport()
+}
+
+func (uc *ReportUseCase) generateDailyReport(ctx context.Context, date string) (*entity.DailyReport, error) {
+	stockPeriod, err := uc.tradeDay.GetStockTradePeriodByDate(date)
+	if err != nil {
+		return nil, err
+	}
+	futurePeriod, err := uc.tradeDay.GetFutureTradePeriodByDate(date)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &entity.DailyReport{
+		Date:    stockPeriod.TradeDay,
+		Created: time.Now(),
+	}
+
+	if report.Stock, err = uc.stockTradeSummary(ctx, stockPeriod); err != nil {
+		return nil, err
+	}
+	if report.Future, err = uc.futureTradeSummary(ctx, futurePeriod); err != nil {
+		return nil, err
+	}
+	report.NetPnL = report.Stock.Net + report.Future.Net
+
+	if report.StockPositions, err = uc.stockPositions(ctx, report.Date); err != nil {
+		return nil, err
+	}
+	if report.FuturePositions, err = uc.futurePositions(); err != nil {
+		return nil, err
+	}
+	if report.Account, err = uc.tradeRepo.QueryAccountBalanceByDate(ctx, report.Date); err != nil {
+		return nil, err
+	}
+
+	if err = uc.repo.InsertOrUpdateDailyReport(ctx, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func (uc *ReportUseCase) stockTradeSummary(ctx context.Context, period calendar.TradePeriod) (entity.DailyReportTrade, error) {
+	result := entity.DailyReportTrade{}
+	orders, err := uc.tradeRepo.QueryAllStockOrderByDate(ctx, period.ToStartEndArray())
+	if err != nil {
+		return result, err
+	}
+	for _, v := range orders {
+		result.OrderCount++
+		if v.Status != entity.StatusFilled {
+			continue
+		}
+		result.Fee += uc.quota.GetStockTradeFee(v.Price, v.Lot, v.Share)
+		if v.Action == entity.ActionSell {
+			result.Tax += uc.quota.GetStockTradeTax(v.Price, v.Lot, v.Share)
+		}
+	}
+
+	balance, err := uc.tradeRepo.QueryStockTradeBalanceByDate(ctx, period.TradeDay)
+	if err != nil || balance == nil {
+		return result, err
+	}
+	result.TradeCount = balance.TradeCount
+	result.Forward = balance.Forward
+	result.Reverse = balance.Reverse
+	result.Discount = balance.Discount
+	result.Net = balance.Total
+	return result, nil
+}
+
+func (uc *ReportUseCase) futureTradeSummary(ctx context.Context, period calendar.TradePeriod) (entity.DailyReportTrade, error) {
+	result := entity.DailyReportTrade{}
+	orders, err := uc.tradeRepo.QueryAllFutureOrderByDate(ctx, period.ToStartEndArray())
+	if err != nil {
+		return result, err
+	}
+	for _, v := range orders {
+		result.OrderCount++
+		if v.Status != entity.StatusFilled {
+			continue
+		}
+		result.Fee += uc.quota.GetFutureTradeFee(v.Position)
+		result.Tax += uc.quota.GetFutureTradeTax(v.Price, v.Position)
+	}
+
+	balance, err := uc.tradeRepo.QueryFutureTradeBalanceByDate(ctx, period.TradeDay)
+	if err != nil || balance == nil {
+		return result, err
+	}
+	result.TradeCount = balance.TradeCount
+	result.Forward = balance.Forward
+	result.Reverse = balance.Reverse
+	result.Net = balance.Total
+	return result, nil
+}
+
+func (uc *ReportUseCase) stockPositions(ctx context.Context, date time.Time) ([]*entity.DailyReportPosition, error) {
+	inv, err := uc.tradeRepo.QueryInventoryStockByDate(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*entity.DailyReportPosition
+	for _, v := range inv {
+		p := &entity.DailyReportPosition{
+			Code:     v.StockNum,
+			Quantity: int64(v.Lot*1000 + v.Share),
+			Price:    v.AvgPrice,
+		}
+		for _, d := range v.Position {
+			p.Direction = d.Direction
+			p.LastPrice = d.LastPrice
+			p.Pnl += d.Pnl
+		}
+		result = append(result, p)
+	}
+	return result, nil
+}
+
+func (uc *ReportUseCase) futurePositions() ([]*entity.DailyReportPosition, error) {
+	query, err := uc.sc.GetFuturePosition()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*entity.DailyReportPosition
+	for _, v := range query.GetPositionArr() {
+		result = append(result, &entity.DailyReportPosition{
+			Code:      v.GetCode(),
+			Direction: v.GetDirection(),
+			Quantity:  int64(v.GetQuantity()),
+			Price:     v.GetPrice(),
+			LastPrice: v.GetLastPrice(),
+			Pnl:       v.GetPnl(),
+		})
+	}
+	return result, nil
+}
@@ -2,29 +2,75 @@ package usecase
 
 import (
 	"context"
-	"crypto/tls"
-	"errors"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
 	"fmt"
+	"net"
 	"net/mail"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/toc-taiwan/toc-machine-trading/internal/config"
 	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
+	"github.com/toc-taiwan/toc-machine-trading/internal/usecase/modules/notifier"
 	"github.com/toc-taiwan/toc-machine-trading/internal/usecase/repo"
 	"github.com/toc-taiwan/toc-machine-trading/pkg/eventbus"
 	"github.com/toc-taiwan/toc-machine-trading/pkg/log"
+	"github.com/toc-taiwan/toc-machine-trading/pkg/totp"
+	"github.com/toc-taiwan/toc-machine-trading/pkg/utils"
 	"golang.org/x/crypto/bcrypt"
-	"gopkg.in/gomail.v2"
+)
+
+const (
+	passwordMinLength   = 8
+	passwordResetLength = 8
+	passwordResetExpire = 30 * time.Minute
+	passwordResetMaxTry = 5
+
+	verifyCodeExpire      = 30 * time.Minute
+	verifyMaxAttempts     = 5
+	verifyResendInterval  = time.Minute
+	unverifiedUserRetain  = 24 * time.Hour
+	unverifiedUserCleanUp = time.Hour
+
+	sessionExpire      = 30 * 24 * time.Hour
+	sessionCachePrune  = time.Hour
+	refreshTokenLength = 48
+
+	totpIssuer           = "TMT"
+	totpSkew             = 1
+	totpBackupCodeCount  = 10
+	totpBackupCodeLength = 10
+
+	settingTOTPRequiredForTrade = "totp_required_for_trade"
+
+	apiKeyPrefix         = "tmt"
+	apiKeyIDLength       = 12
+	apiKeySecretLength   = 40
+	apiKeyLastUsedPeriod = time.Minute
 )
 
 type SystemUseCase struct {
 	repo    repo.SystemRepo
-	smtpCfg config.SMTP
+	mailer  *notifier.SMTP
+	authCfg config.Auth
+
+	roleMap     map[string]*entity.Role
+	roleMapLock sync.RWMutex
 
-	activationCodeMap     map[string]time.Time
-	activationCodeMapLock sync.RWMutex
+	tokenValidAfterMap     map[string]time.Time
+	tokenValidAfterMapLock sync.RWMutex
+
+	sessionRevokedMap     map[string]*sessionRevoked
+	sessionRevokedMapLock sync.RWMutex
+
+	apiKeyLastUsedMap     map[string]time.Time
+	apiKeyLastUsedMapLock sync.Mutex
 
 	logger *log.Log
 	bus    *eventbus.Bus
@@ -33,28 +79,106 @@ type SystemUseCase struct {
 func NewSystem() *SystemUseCase {
 	cfg := config.Get()
 	uc := &SystemUseCase{
-		repo:              repo.NewSystemRepo(cfg.GetPostgresPool()),
-		activationCodeMap: make(map[string]time.Time),
-		smtpCfg:           config.Get().SMTP,
-		logger:            log.Get(),
-		bus:               eventbus.Get(),
+		repo:               repo.NewSystemRepo(cfg.GetPostgresPool()),
+		tokenValidAfterMap: make(map[string]time.Time),
+		sessionRevokedMap:  make(map[string]*sessionRevoked),
+		apiKeyLastUsedMap:  make(map[string]time.Time),
+		mailer:             notifier.NewSMTP(cfg.SMTP),
+		authCfg:            cfg.Auth,
+		roleMap:            make(map[string]*entity.Role),
+		logger:             log.Get(),
+		bus:                eventbus.Get(),
 	}
 
+	if err := uc.updateRoleMap(); err != nil {
+		uc.logger.Fatal(err)
+	}
+	uc.promoteAdminUser()
 	uc.UpdateAuthTradeUser()
+
+	go uc.cleanUnverifiedUser()
+	go uc.pruneSessionCache()
 	return uc
 }
 
+type sessionRevoked struct {
+	revoked  bool
+	cachedAt time.Time
+}
+
+func (uc *SystemUseCase) updateRoleMap() error {
+	roles, err := uc.repo.QueryAllRole(context.Background())
+	if err != nil {
+		return err
+	}
+
+	uc.roleMapLock.Lock()
+	defer uc.roleMapLock.Unlock()
+	uc.roleMap = make(map[string]*entity.Role)
+	for _, v := range roles {
+		uc.roleMap[v.Name] = v
+	}
+	return nil
+}
+
+// promoteAdminUser grants admin role to the user set by AUTH_ADMIN_USER, so the first admin can be bootstrapped.
+func (uc *SystemUseCase) promoteAdminUser() {
+	if uc.authCfg.AdminUser == "" {
+		return
+	}
+
+	user, err := uc.repo.QueryUserByUsername(context.Background(), uc.authCfg.AdminUser)
+	if err != nil {
+		uc.logger.Fatal(err)
+	}
+
+	if user == nil {
+		uc.logger.Warnf("admin user %s not found", uc.authCfg.AdminUser)
+		return
+	}
+
+	if user.Role == entity.RoleAdmin {
+		return
+	}
+
+	if err := uc.repo.UpdateUserRole(context.Background(), user.Username, entity.RoleAdmin); err != nil {
+		uc.logger.Fatal(err)
+	}
+	uc.logger.Warnf("user %s is promoted to admin", user.Username)
+}
+
 func (uc *SystemUseCase) UpdateAuthTradeUser() {
 	allUser, err := uc.repo.QueryAllUser(context.Background())
 	if err != nil {
 		uc.logger.Fatal(err)
 	}
 
+	setting, err := uc.GetSetting(context.Background())
+	if err != nil {
+		uc.logger.Fatal(err)
+	}
+
+	totpUser := make(map[int]struct{})
+	if setting.TOTPRequiredForTrade {
+		userIDs, err := uc.repo.QueryEnabledTOTPUserID(context.Background())
+		if err != nil {
+			uc.logger.Fatal(err)
+		}
+		for _, v := range userIDs {
+			totpUser[v] = struct{}{}
+		}
+	}
+
 	authUserName := []string{}
 	for _, user := range allUser {
-		if user.AuthTrade {
-			authUserName = append(authUserName, user.Username)
+		if !user.AuthTrade {
+			continue
 		}
+		if _, ok := totpUser[user.ID]; setting.TOTPRequiredForTrade && !ok {
+			uc.logger.Warnf("user %s is not authorized to trade until totp is enabled", user.Username)
+			continue
+		}
+		authUserName = append(authUserName, user.Username)
 	}
 
 	uc.bus.PublishTopicEvent(topicUpdateAuthTradeUser, authUserName)
@@ -84,76 +208,204 @@ func (uc *SystemUseCase) AddUser(ctx context.Context, t *entity.NewUser) error {
 	if err != nil {
 		return err
 	}
-	if err := uc.repo.InsertUser(ctx, t); err != nil {
+	if err = uc.repo.InsertUser(ctx, t); err != nil {
 		return err
 	}
 
-	return uc.SendOTP(ctx, t)
+	user, err := uc.repo.QueryUserByUsername(ctx, t.Username)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+	return uc.SendOTP(ctx, user)
 }
 
-func (uc *SystemUseCase) Login(ctx context.Context, username, password string) error {
+func (uc *SystemUseCase) Login(ctx context.Context, username, password, totpCode string) (*entity.User, error) {
 	user, err := uc.repo.QueryUserByUsername(ctx, username)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if user == nil {
-		return ErrUserNotFound
+		return nil, ErrUserNotFound
 	}
 	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password))
 	if err != nil {
-		return ErrPasswordNotMatch
+		return nil, ErrPasswordNotMatch
 	}
 	if !user.EmailVerified {
-		return ErrEmailNotVerified
+		return nil, ErrEmailNotVerified
 	}
-	return nil
+	if err = uc.verifySecondFactor(ctx, user, totpCode); err != nil {
+		return nil, err
+	}
+	return user, nil
 }
 
-func (uc *SystemUseCase) SendOTP(ctx context.Context, t *entity.NewUser) error {
-	if uc.smtpCfg.Host == "" || uc.smtpCfg.Port == 0 || uc.smtpCfg.Username == "" || uc.smtpCfg.Password == "" {
-		return errors.New("smtp config not set")
+// verifySecondFactor requires a totp or backup code if the user enabled totp.
+func (uc *SystemUseCase) verifySecondFactor(ctx context.Context, user *entity.User, code string) error {
+	t, err := uc.repo.QueryTOTPByUserID(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	if t == nil || !t.Enabled {
+		return nil
+	}
+	if code == "" {
+		return ErrTOTPRequired
 	}
+	return uc.validateTOTP(ctx, t, code, true)
+}
 
-	activationCode := uuid.NewString()
-	uc.addActivationCode(activationCode)
-
-	m := gomail.NewMessage()
-	m.SetHeader("From", fmt.Sprintf("TMT <%s>", uc.smtpCfg.Username))
-	m.SetHeader("To", t.Email)
-	m.SetHeader("Subject", "Please verify your email address")
-	m.SetBody(
-		"text/html",
-		fmt.Sprintf("Please click the following link in 30 minutes to verify your email address: <a href='https://tocraw.com/user/verify/%s/%s'>Verify</a>", t.Username, activationCode),
-	)
+// validateTOTP accepts each time step only once to prevent replay, backup code is accepted if allowBackup.
+func (uc *SystemUseCase) validateTOTP(ctx context.Context, t *entity.TOTP, code string, allowBackup bool) error {
+	code = strings.TrimSpace(code)
+	if step, ok := totp.ValidateOnce(t.Secret, code, time.Now(), totpSkew, t.LastUsedStep); ok {
+		return uc.repo.UpdateTOTP(ctx, t.UserID, true, step)
+	}
 
-	d := gomail.NewDialer(uc.smtpCfg.Host, 587, uc.smtpCfg.Username, uc.smtpCfg.Password)
-	d.TLSConfig = &tls.Config{InsecureSkipVerify: true}
-	if err := d.DialAndSend(m); err != nil {
+	if !allowBackup {
+		return ErrTOTPInvalid
+	}
+
+	used, err := uc.repo.UseTOTPBackupCode(ctx, t.UserID, hashCode(strings.ToLower(code)))
+	if err != nil {
 		return err
 	}
+	if !used {
+		return ErrTOTPInvalid
+	}
+	uc.logger.Warnf("user id %d logged in by totp backup code", t.UserID)
 	return nil
 }
 
+func (uc *SystemUseCase) SendOTP(ctx context.Context, user *entity.User) error {
+	activationCode := uuid.NewString()
+	now := time.Now()
+	if err := uc.repo.InsertEmailVerification(ctx, &entity.EmailVerification{
+		UserID:   user.ID,
+		CodeHash: hashCode(activationCode),
+		ExpireAt: now.Add(verifyCodeExpire),
+		Created:  now,
+	}); err != nil {
+		return err
+	}
+
+	return uc.mailer.Send(
+		user.Email,
+		"Please verify your email address",
+		fmt.Sprintf("Please click the following link in %d minutes to verify your email address: <a href='https://tocraw.com/user/verify/%s/%s'>Verify</a>", int(verifyCodeExpire.Minutes()), user.Username, activationCode),
+	)
+}
+
 func (uc *SystemUseCase) VerifyEmail(ctx context.Context, username, code string) error {
-	defer uc.activationCodeMapLock.Unlock()
-	uc.activationCodeMapLock.Lock()
+	user, err := uc.repo.QueryUserByUsername(ctx, username)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+	if user.EmailVerified {
+		return ErrEmailAlreadyVerified
+	}
 
-	expire, ok := uc.activationCodeMap[code]
-	if !ok {
-		return errors.New("invalid activation code")
+	verification, err := uc.repo.QueryLastEmailVerification(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	if verification == nil || verification.Used || time.Now().After(verification.ExpireAt) {
+		return ErrVerifyCodeExpired
+	}
+	if verification.Attempts >= verifyMaxAttempts {
+		return ErrVerifyAttemptsExceeded
+	}
+	if verification.CodeHash != hashCode(code) {
+		if err = uc.repo.IncreaseEmailVerificationAttempts(ctx, verification.ID); err != nil {
+			return err
+		}
+		return ErrVerifyCodeInvalid
+	}
+
+	if err = uc.repo.EmailVerification(ctx, username); err != nil {
+		return err
+	}
+	return uc.repo.UseEmailVerificationByUserID(ctx, user.ID)
+}
+
+// ResendVerification sends a new verification code, unknown or verified email is ignored to avoid leaking registered accounts.
+func (uc *SystemUseCase) ResendVerification(ctx context.Context, email string) error {
+	user, err := uc.repo.QueryUserByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+	if user == nil || user.EmailVerified {
+		return nil
+	}
+
+	last, err := uc.repo.QueryLastEmailVerification(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	if last != nil && time.Since(last.Created) < verifyResendInterval {
+		return ErrResendTooFrequent
+	}
+
+	if err = uc.repo.UseEmailVerificationByUserID(ctx, user.ID); err != nil {
+		return err
+	}
+	return uc.SendOTP(ctx, user)
+}
+
+// cleanUnverifiedUser removes users who never verified their email and have no pending code.
+func (uc *SystemUseCase) cleanUnverifiedUser() {
+	clean := func() {
+		deleted, err := uc.repo.DeleteUnverifiedUserCreatedBefore(context.Background(), time.Now().Add(-unverifiedUserRetain))
+		if err != nil {
+			uc.logger.Error(err)
+			return
+		}
+		if len(deleted) > 0 {
+			uc.logger.Warnf("unverified users removed: %v", deleted)
+		}
+	}
+
+	clean()
+	for range time.NewTicker(unverifiedUserCleanUp).C {
+		clean()
 	}
-	if time.Now().After(expire.Add(30 * time.Minute)) {
-		return errors.New("activation code expired")
+}
+
+// pruneSessionCache drops cached entries older than the refresh token lifetime,
+// sessions behind them are expired anyway and the rest will be loaded from db again.
+func (uc *SystemUseCase) pruneSessionCache() {
+	for range time.NewTicker(sessionCachePrune).C {
+		before := time.Now().Add(-sessionExpire)
+
+		uc.tokenValidAfterMapLock.Lock()
+		for k, v := range uc.tokenValidAfterMap {
+			if v.Before(before) {
+				delete(uc.tokenValidAfterMap, k)
+			}
+		}
+		uc.tokenValidAfterMapLock.Unlock()
+
+		uc.sessionRevokedMapLock.Lock()
+		for k, v := range uc.sessionRevokedMap {
+			if v.cachedAt.Before(before) {
+				delete(uc.sessionRevokedMap, k)
+			}
+		}
+		uc.sessionRevokedMapLock.Unlock()
 	}
-	delete(uc.activationCodeMap, code)
-	return uc.repo.EmailVerification(ctx, username)
 }
 
-func (uc *SystemUseCase) addActivationCode(code string) {
-	defer uc.activationCodeMapLock.Unlock()
-	uc.activationCodeMapLock.Lock()
-	uc.activationCodeMap[code] = time.Now()
+func (uc *SystemUseCase) setSessionRevoked(sessionID string, revoked bool) {
+	uc.sessionRevokedMapLock.Lock()
+	defer uc.sessionRevokedMapLock.Unlock()
+	uc.sessionRevokedMap[sessionID] = &sessionRevoked{revoked: revoked, cachedAt: time.Now()}
 }
 
 func (uc *SystemUseCase) EncryptPassword(ctx context.Context, password string) (string, error) {
@@ -178,8 +430,8 @@ func (uc *SystemUseCase) IsPushTokenEnabled(ctx context.Context, token string) (
 	return dbToken.Enabled, nil
 }
 
-func (uc *SystemUseCase) InsertPushToken(ctx context.Context, token, username string, enabled bool) error {
-	if err := uc.repo.InsertOrUpdatePushToken(ctx, token, username, enabled); err != nil {
+func (uc *SystemUseCase) InsertPushToken(ctx context.Context, token, username string, enabled, orderPush bool) error {
+	if err := uc.repo.InsertOrUpdatePushToken(ctx, token, username, enabled, orderPush); err != nil {
 		return err
 	}
 	uc.bus.PublishTopicEvent(topicUpdatePushUser)
@@ -198,10 +450,694 @@ func (uc *SystemUseCase) GetUserInfo(ctx context.Context, username string) (*ent
 	return uc.repo.QueryUserByUsername(ctx, username)
 }
 
-func (uc *SystemUseCase) GetLastJWT(ctx context.Context) (string, error) {
-	return uc.repo.GetLastJWT(ctx)
+// GetJWTKeys returns signing keys still accepted, the last one is used for signing. A key is created if none exists.
+func (uc *SystemUseCase) GetJWTKeys(ctx context.Context) ([]*entity.JWTKey, error) {
+	keys, err := uc.repo.QueryValidJWTKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) > 0 {
+		return keys, nil
+	}
+
+	if err = uc.repo.InsertJWT(ctx, uuid.NewString()); err != nil {
+		return nil, err
+	}
+	return uc.repo.QueryValidJWTKey(ctx)
+}
+
+// RotateJWTKey adds a new signing key, tokens signed by old keys are still accepted in the grace window.
+func (uc *SystemUseCase) RotateJWTKey(ctx context.Context, grace time.Duration) error {
+	if grace < 0 {
+		grace = 0
+	}
+	return uc.repo.RotateJWTKey(ctx, uuid.NewString(), time.Now().Add(grace))
+}
+
+func (uc *SystemUseCase) GetAllUser(ctx context.Context) ([]*entity.User, error) {
+	return uc.repo.QueryAllUser(ctx)
+}
+
+func (uc *SystemUseCase) GetAllRole() []*entity.Role {
+	uc.roleMapLock.RLock()
+	defer uc.roleMapLock.RUnlock()
+
+	result := make([]*entity.Role, 0, len(uc.roleMap))
+	for _, v := range uc.roleMap {
+		result = append(result, v)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+func (uc *SystemUseCase) GetRole(name string) *entity.Role {
+	uc.roleMapLock.RLock()
+	defer uc.roleMapLock.RUnlock()
+	return uc.roleMap[name]
+}
+
+func (uc *SystemUseCase) UpdateUserRole(ctx context.Context, username, role string) error {
+	if uc.GetRole(role) == nil {
+		return ErrRoleNotFound
+	}
+
+	allUser, err := uc.repo.QueryAllUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	var target *entity.User
+	var adminCount int
+	for _, user := range allUser {
+		if user.Role == entity.RoleAdmin {
+			adminCount++
+		}
+		if user.Username == username {
+			target = user
+		}
+	}
+
+	if target == nil {
+		return ErrUserNotFound
+	}
+
+	if target.Role == entity.RoleAdmin && role != entity.RoleAdmin && adminCount <= 1 {
+		return ErrCannotRemoveAdmin
+	}
+	if err = uc.repo.UpdateUserRole(ctx, username, role); err != nil {
+		return err
+	}
+	// permissions are in the token, tokens signed with the old role must not be used or refreshed
+	return uc.revokeUserTokens(ctx, target)
+}
+
+func (uc *SystemUseCase) ChangePassword(ctx context.Context, username, oldPassword, newPassword string) error {
+	if len(newPassword) < passwordMinLength {
+		return ErrPasswordInvalid
+	}
+
+	user, err := uc.repo.QueryUserByUsername(ctx, username)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+	if err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(oldPassword)); err != nil {
+		return ErrPasswordNotMatch
+	}
+
+	encrypted, err := uc.EncryptPassword(ctx, newPassword)
+	if err != nil {
+		return err
+	}
+	if err = uc.repo.UpdateUserPassword(ctx, username, encrypted); err != nil {
+		return err
+	}
+	return uc.revokeUserTokens(ctx, user)
+}
+
+// SendPasswordResetCode mails a reset code to the user, unknown email is ignored to avoid leaking registered accounts.
+func (uc *SystemUseCase) SendPasswordResetCode(ctx context.Context, email string) error {
+	user, err := uc.repo.QueryUserByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		uc.logger.Warnf("password reset requested for unknown email %s", email)
+		return nil
+	}
+
+	code := utils.RandomASCIILowerOctdigitsString(passwordResetLength)
+	now := time.Now()
+	if err = uc.repo.InsertPasswordReset(ctx, &entity.PasswordReset{
+		UserID:   user.ID,
+		CodeHash: hashCode(code),
+		ExpireAt: now.Add(passwordResetExpire),
+		Created:  now,
+	}); err != nil {
+		return err
+	}
+
+	return uc.mailer.Send(
+		user.Email,
+		"Reset your password",
+		fmt.Sprintf("Your password reset code is <b>%s</b>, it will expire in %d minutes.", code, int(passwordResetExpire.Minutes())),
+	)
+}
+
+func (uc *SystemUseCase) ResetPassword(ctx context.Context, email, code, newPassword string) error {
+	if len(newPassword) < passwordMinLength {
+		return ErrPasswordInvalid
+	}
+
+	user, err := uc.repo.QueryUserByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrResetCodeInvalid
+	}
+
+	reset, err := uc.repo.QueryLastPasswordReset(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	if reset == nil || reset.Used || time.Now().After(reset.ExpireAt) {
+		return ErrResetCodeInvalid
+	}
+	if reset.Attempts >= passwordResetMaxTry {
+		return ErrResetAttemptsExceeded
+	}
+	if reset.CodeHash != hashCode(code) {
+		if err = uc.repo.IncreasePasswordResetAttempts(ctx, reset.ID); err != nil {
+			return err
+		}
+		return ErrResetCodeInvalid
+	}
+
+	encrypted, err := uc.EncryptPassword(ctx, newPassword)
+	if err != nil {
+		return err
+	}
+	if err = uc.repo.UpdateUserPassword(ctx, user.Username, encrypted); err != nil {
+		return err
+	}
+	if err = uc.repo.UsePasswordResetByUserID(ctx, user.ID); err != nil {
+		return err
+	}
+	return uc.revokeUserTokens(ctx, user)
+}
+
+// revokeUserTokens makes every token logged in before now invalid, and revokes all sessions.
+func (uc *SystemUseCase) revokeUserTokens(ctx context.Context, user *entity.User) error {
+	// token claims only keep seconds, truncate to avoid revoking the token just logged in
+	now := time.Now().Truncate(time.Second)
+	if err := uc.repo.UpdateUserTokenValidAfter(ctx, user.Username, now); err != nil {
+		return err
+	}
+
+	uc.tokenValidAfterMapLock.Lock()
+	uc.tokenValidAfterMap[user.Username] = now
+	uc.tokenValidAfterMapLock.Unlock()
+
+	revoked, err := uc.repo.RevokeSessionByUserID(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range revoked {
+		uc.setSessionRevoked(v, true)
+	}
+	return nil
 }
 
-func (uc *SystemUseCase) InsertJWT(ctx context.Context, jwt string) error {
-	return uc.repo.InsertJWT(ctx, jwt)
+func (uc *SystemUseCase) IsTokenRevoked(ctx context.Context, username, sessionID string, loginAt time.Time) bool {
+	if uc.isSessionRevoked(ctx, sessionID) {
+		return true
+	}
+
+	uc.tokenValidAfterMapLock.RLock()
+	validAfter, ok := uc.tokenValidAfterMap[username]
+	uc.tokenValidAfterMapLock.RUnlock()
+
+	if !ok {
+		user, err := uc.repo.QueryUserByUsername(ctx, username)
+		if err != nil {
+			uc.logger.Error(err)
+			return true
+		}
+		if user == nil {
+			return true
+		}
+		validAfter = user.TokenValidAfter
+
+		uc.tokenValidAfterMapLock.Lock()
+		uc.tokenValidAfterMap[username] = validAfter
+		uc.tokenValidAfterMapLock.Unlock()
+	}
+	return loginAt.Before(validAfter)
+}
+
+func (uc *SystemUseCase) isSessionRevoked(ctx context.Context, sessionID string) bool {
+	uc.sessionRevokedMapLock.RLock()
+	cached, ok := uc.sessionRevokedMap[sessionID]
+	uc.sessionRevokedMapLock.RUnlock()
+	if ok {
+		return cached.revoked
+	}
+
+	session, err := uc.repo.QuerySessionBySessionID(ctx, sessionID)
+	if err != nil {
+		uc.logger.Error(err)
+		return true
+	}
+	revoked := session == nil || session.Revoked
+	uc.setSessionRevoked(sessionID, revoked)
+	return revoked
+}
+
+// CreateSession starts a session for the logged in device, returns the session and its plain refresh token.
+func (uc *SystemUseCase) CreateSession(ctx context.Context, user *entity.User, userAgent, ip string) (*entity.Session, string, error) {
+	refreshToken := utils.RandomASCIILowerOctdigitsString(refreshTokenLength)
+	now := time.Now()
+	session := &entity.Session{
+		SessionID:        uuid.NewString(),
+		UserID:           user.ID,
+		RefreshTokenHash: hashCode(refreshToken),
+		UserAgent:        userAgent,
+		IP:               ip,
+		Created:          now,
+		LastSeen:         now,
+		ExpireAt:         now.Add(sessionExpire),
+	}
+	if err := uc.repo.InsertSession(ctx, session); err != nil {
+		return nil, "", err
+	}
+
+	uc.setSessionRevoked(session.SessionID, false)
+	return session, refreshToken, nil
+}
+
+// RefreshSession exchanges the refresh token for a new one, the old refresh token can not be used again.
+func (uc *SystemUseCase) RefreshSession(ctx context.Context, refreshToken, userAgent, ip string) (*entity.User, *entity.Session, string, error) {
+	session, err := uc.repo.QuerySessionByRefreshTokenHash(ctx, hashCode(refreshToken))
+	if err != nil {
+		return nil, nil, "", err
+	}
+	if session == nil || session.Revoked || time.Now().After(session.ExpireAt) {
+		return nil, nil, "", ErrRefreshTokenInvalid
+	}
+
+	user, err := uc.repo.QueryUserByID(ctx, session.UserID)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	if user == nil {
+		return nil, nil, "", ErrUserNotFound
+	}
+
+	newRefreshToken := utils.RandomASCIILowerOctdigitsString(refreshTokenLength)
+	now := time.Now()
+	session.RefreshTokenHash = hashCode(newRefreshToken)
+	session.UserAgent = userAgent
+	session.IP = ip
+	session.LastSeen = now
+	session.ExpireAt = now.Add(sessionExpire)
+	if err = uc.repo.UpdateSessionRefreshToken(ctx, session); err != nil {
+		return nil, nil, "", err
+	}
+	return user, session, newRefreshToken, nil
+}
+
+func (uc *SystemUseCase) GetActiveSessions(ctx context.Context, username, currentSessionID string) ([]*entity.Session, error) {
+	user, err := uc.repo.QueryUserByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	sessions, err := uc.repo.QueryActiveSessionByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range sessions {
+		v.Current = v.SessionID == currentSessionID
+	}
+	return sessions, nil
+}
+
+func (uc *SystemUseCase) RevokeSession(ctx context.Context, username, sessionID string) error {
+	user, err := uc.repo.QueryUserByUsername(ctx, username)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+
+	session, err := uc.repo.QuerySessionBySessionID(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if session == nil || session.UserID != user.ID {
+		return ErrSessionNotFound
+	}
+
+	if err = uc.repo.RevokeSession(ctx, sessionID); err != nil {
+		return err
+	}
+
+	uc.setSessionRevoked(sessionID, true)
+	return nil
+}
+
+func hashCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
+
+// EnrollTOTP generates a new secret and backup codes, totp takes effect after ConfirmTOTP.
+func (uc *SystemUseCase) EnrollTOTP(ctx context.Context, username string) (*entity.TOTPEnrollment, error) {
+	user, err := uc.repo.QueryUserByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	t, err := uc.repo.QueryTOTPByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if t != nil && t.Enabled {
+		return nil, ErrTOTPAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	backupCodes := make([]string, totpBackupCodeCount)
+	backupCodeHashes := make([]string, totpBackupCodeCount)
+	for i := range backupCodes {
+		backupCodes[i] = utils.RandomASCIILowerOctdigitsString(totpBackupCodeLength)
+		backupCodeHashes[i] = hashCode(backupCodes[i])
+	}
+
+	if err = uc.repo.ReplaceTOTP(ctx, &entity.TOTP{
+		UserID:  user.ID,
+		Secret:  secret,
+		Created: time.Now(),
+	}, backupCodeHashes); err != nil {
+		return nil, err
+	}
+
+	return &entity.TOTPEnrollment{
+		Secret:      secret,
+		URI:         totp.URI(totpIssuer, user.Username, secret),
+		BackupCodes: backupCodes,
+	}, nil
+}
+
+func (uc *SystemUseCase) ConfirmTOTP(ctx context.Context, username, code string) error {
+	user, err := uc.repo.QueryUserByUsername(ctx, username)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+
+	t, err := uc.repo.QueryTOTPByUserID(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	if t == nil {
+		return ErrTOTPNotEnrolled
+	}
+	if t.Enabled {
+		return ErrTOTPAlreadyEnabled
+	}
+	if err = uc.validateTOTP(ctx, t, code, false); err != nil {
+		return err
+	}
+
+	uc.UpdateAuthTradeUser()
+	return nil
+}
+
+func (uc *SystemUseCase) DisableTOTP(ctx context.Context, username, code string) error {
+	user, err := uc.repo.QueryUserByUsername(ctx, username)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+
+	t, err := uc.repo.QueryTOTPByUserID(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	if t == nil || !t.Enabled {
+		return ErrTOTPNotEnrolled
+	}
+
+	setting, err := uc.GetSetting(ctx)
+	if err != nil {
+		return err
+	}
+	if setting.TOTPRequiredForTrade && user.AuthTrade {
+		return ErrTOTPRequiredForTrade
+	}
+
+	if err = uc.validateTOTP(ctx, t, code, true); err != nil {
+		return err
+	}
+	return uc.repo.DeleteTOTP(ctx, user.ID)
+}
+
+func (uc *SystemUseCase) GetTOTPStatus(ctx context.Context, username string) (*entity.TOTPStatus, error) {
+	user, err := uc.repo.QueryUserByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	t, err := uc.repo.QueryTOTPByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil || !t.Enabled {
+		return &entity.TOTPStatus{}, nil
+	}
+
+	left, err := uc.repo.CountUnusedTOTPBackupCode(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &entity.TOTPStatus{
+		Enabled:         true,
+		BackupCodesLeft: left,
+	}, nil
+}
+
+func (uc *SystemUseCase) GetSetting(ctx context.Context) (*entity.SystemSetting, error) {
+	value, err := uc.repo.QuerySetting(ctx, settingTOTPRequiredForTrade)
+	if err != nil {
+		return nil, err
+	}
+
+	setting := &entity.SystemSetting{}
+	if value != "" {
+		if setting.TOTPRequiredForTrade, err = strconv.ParseBool(value); err != nil {
+			return nil, err
+		}
+	}
+	return setting, nil
+}
+
+func (uc *SystemUseCase) UpdateSetting(ctx context.Context, setting *entity.SystemSetting) error {
+	if err := uc.repo.UpsertSetting(ctx, settingTOTPRequiredForTrade, strconv.FormatBool(setting.TOTPRequiredForTrade)); err != nil {
+		return err
+	}
+	uc.UpdateAuthTradeUser()
+	return nil
+}
+
+// UpdateUserAuthTrade grants or revokes trade authorization, totp is checked if required by setting.
+func (uc *SystemUseCase) UpdateUserAuthTrade(ctx context.Context, username string, authTrade bool) error {
+	user, err := uc.repo.QueryUserByUsername(ctx, username)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+
+	if authTrade {
+		setting, err := uc.GetSetting(ctx)
+		if err != nil {
+			return err
+		}
+		if setting.TOTPRequiredForTrade {
+			t, err := uc.repo.QueryTOTPByUserID(ctx, user.ID)
+			if err != nil {
+				return err
+			}
+			if t == nil || !t.Enabled {
+				return ErrTOTPRequiredForTrade
+			}
+		}
+	}
+
+	if err = uc.repo.UpdateUserAuthTrade(ctx, username, authTrade); err != nil {
+		return err
+	}
+	uc.UpdateAuthTradeUser()
+	return nil
+}
+
+// CreateAPIKey creates a key for the user, scopes can not exceed the user's role.
+func (uc *SystemUseCase) CreateAPIKey(ctx context.Context, username string, t *entity.NewAPIKey) (*entity.CreatedAPIKey, error) {
+	user, err := uc.repo.QueryUserByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	if t.Name == "" || len(t.Scopes) == 0 {
+		return nil, ErrAPIKeyScopeInvalid
+	}
+	role := uc.GetRole(user.Role)
+	for _, v := range t.Scopes {
+		p := v.Permission()
+		if p == "" {
+			return nil, ErrAPIKeyScopeInvalid
+		}
+		if role == nil || !role.HasPermission(p) {
+			return nil, ErrPermissionDenied
+		}
+	}
+	for _, v := range t.IPAllowlist {
+		if net.ParseIP(v) != nil {
+			continue
+		}
+		if _, _, err = net.ParseCIDR(v); err != nil {
+			return nil, ErrAPIKeyIPInvalid
+		}
+	}
+	if t.ExpireAt != nil && t.ExpireAt.Before(time.Now()) {
+		return nil, ErrAPIKeyExpired
+	}
+
+	keyID := utils.RandomASCIILowerOctdigitsString(apiKeyIDLength)
+	key := fmt.Sprintf("%s_%s_%s", apiKeyPrefix, keyID, utils.RandomASCIILowerOctdigitsString(apiKeySecretLength))
+	apiKey := &entity.APIKey{
+		KeyID:       keyID,
+		UserID:      user.ID,
+		Name:        t.Name,
+		KeyHash:     hashCode(key),
+		Scopes:      t.Scopes,
+		IPAllowlist: t.IPAllowlist,
+		ExpireAt:    t.ExpireAt,
+		Created:     time.Now(),
+	}
+	if err = uc.repo.InsertAPIKey(ctx, apiKey); err != nil {
+		return nil, err
+	}
+	return &entity.CreatedAPIKey{APIKey: apiKey, Key: key}, nil
+}
+
+func (uc *SystemUseCase) GetAPIKeys(ctx context.Context, username string) ([]*entity.APIKey, error) {
+	user, err := uc.repo.QueryUserByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+	return uc.repo.QueryAPIKeyByUserID(ctx, user.ID)
+}
+
+func (uc *SystemUseCase) RevokeAPIKey(ctx context.Context, username, keyID string) error {
+	user, err := uc.repo.QueryUserByUsername(ctx, username)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+
+	apiKey, err := uc.repo.QueryAPIKeyByKeyID(ctx, keyID)
+	if err != nil {
+		return err
+	}
+	if apiKey == nil || apiKey.UserID != user.ID {
+		return ErrAPIKeyNotFound
+	}
+	return uc.repo.RevokeAPIKey(ctx, keyID)
+}
+
+// AuthenticateAPIKey returns the owner and the key if the key is usable from ip.
+func (uc *SystemUseCase) AuthenticateAPIKey(ctx context.Context, key, ip string) (*entity.User, *entity.APIKey, error) {
+	parts := strings.Split(key, "_")
+	if len(parts) != 3 || parts[0] != apiKeyPrefix {
+		return nil, nil, ErrAPIKeyInvalid
+	}
+
+	apiKey, err := uc.repo.QueryAPIKeyByKeyID(ctx, parts[1])
+	if err != nil {
+		return nil, nil, err
+	}
+	if apiKey == nil || apiKey.Revoked || subtle.ConstantTimeCompare([]byte(apiKey.KeyHash), []byte(hashCode(key))) != 1 {
+		return nil, nil, ErrAPIKeyInvalid
+	}
+	if apiKey.ExpireAt != nil && time.Now().After(*apiKey.ExpireAt) {
+		return nil, nil, ErrAPIKeyExpired
+	}
+	if !isIPAllowed(ip, apiKey.IPAllowlist) {
+		return nil, nil, ErrAPIKeyIPNotAllowed
+	}
+
+	user, err := uc.repo.QueryUserByID(ctx, apiKey.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if user == nil {
+		return nil, nil, ErrAPIKeyInvalid
+	}
+
+	uc.recordAPIKeyUsed(ctx, apiKey.KeyID)
+	return user, apiKey, nil
+}
+
+// recordAPIKeyUsed writes last used time at most once per period for each key.
+func (uc *SystemUseCase) recordAPIKeyUsed(ctx context.Context, keyID string) {
+	now := time.Now()
+	uc.apiKeyLastUsedMapLock.Lock()
+	if last, ok := uc.apiKeyLastUsedMap[keyID]; ok && now.Sub(last) < apiKeyLastUsedPeriod {
+		uc.apiKeyLastUsedMapLock.Unlock()
+		return
+	}
+	uc.apiKeyLastUsedMap[keyID] = now
+	uc.apiKeyLastUsedMapLock.Unlock()
+
+	if err := uc.repo.UpdateAPIKeyLastUsed(ctx, keyID, now); err != nil {
+		uc.logger.Error(err)
+	}
+}
+
+func isIPAllowed(ip string, allowlist []string) bool {
+	if len(allowlist) == 0 {
+		return true
+	}
+
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	for _, v := range allowlist {
+		if allowed := net.ParseIP(v); allowed != nil {
+			if allowed.Equal(parsed) {
+				return true
+			}
+			continue
+		}
+		if _, cidr, err := net.ParseCIDR(v); err == nil && cidr.Contains(parsed) {
+			return true
+		}
+	}
+	return false
 }
@@ -2,8 +2,10 @@ package usecase
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"sort"
+	"sync"
 	"time"
 
 	"github.com/toc-taiwan/toc-machine-trading/internal/config"
@@ -11,16 +13,28 @@ import (
 	"github.com/toc-taiwan/toc-machine-trading/internal/usecase/cache"
 	"github.com/toc-taiwan/toc-machine-trading/internal/usecase/grpc"
 	"github.com/toc-taiwan/toc-machine-trading/internal/usecase/modules/calendar"
+	"github.com/toc-taiwan/toc-machine-trading/internal/usecase/modules/searcher"
 	"github.com/toc-taiwan/toc-machine-trading/internal/usecase/repo"
 	"github.com/toc-taiwan/toc-machine-trading/pkg/eventbus"
 	"github.com/toc-taiwan/toc-machine-trading/pkg/log"
 	"github.com/toc-taiwan/toc-trade-protobuf/golang/pb"
 )
 
+const (
+	settingTargetRule = "target_rule"
+
+	targetRuleMaxCount = 200
+
+	targetRealTimeInterval = time.Minute
+	// targetRealTimeRankBase keeps intraday targets ranked after the ones of the trade day
+	targetRealTimeRankBase = 100
+)
+
 // TargetUseCase -.
 type TargetUseCase struct {
-	repo    repo.TargetRepo
-	gRPCAPI grpc.RealTimegRPCAPI
+	repo       repo.TargetRepo
+	systemRepo repo.SystemRepo
+	gRPCAPI    grpc.RealTimegRPCAPI
 
 	cfg      *config.Config
 	tradeDay *calendar.Calendar
@@ -30,19 +44,34 @@ type TargetUseCase struct {
 	bus    *eventbus.Bus
 
 	rankFromSnapshot *pb.StockVolumeRankResponse
+
+	rule     *entity.TargetRule
+	ruleLock sync.RWMutex
+
+	newTargetSub     map[chan []*entity.StockTarget]struct{}
+	newTargetSubLock sync.Mutex
 }
 
 func NewTarget() Target {
 	cfg := config.Get()
 	uc := &TargetUseCase{
-		repo:     repo.NewTarget(cfg.GetPostgresPool()),
-		gRPCAPI:  grpc.NewRealTime(cfg.GetSinopacConn()),
-		cfg:      cfg,
-		tradeDay: calendar.Get(),
-		logger:   log.Get(),
-		cc:       cache.Get(),
-		bus:      eventbus.Get(),
+		repo:       repo.NewTarget(cfg.GetPostgresPool()),
+		systemRepo: repo.NewSystemRepo(cfg.GetPostgresPool()),
+		gRPCAPI:    grpc.NewRealTime(cfg.GetSinopacConn()),
+		cfg:        cfg,
+		tradeDay:   calendar.Get(),
+		logger:     log.Get(),
+		cc:         cache.Get(),
+		bus:        eventbus.Get(),
+
+		newTargetSub: make(map[chan []*entity.StockTarget]struct{}),
+	}
+
+	rule, err := uc.loadTargetRule(context.Background())
+	if err != nil {
+		uc.logger.Fatal(err)
 	}
+	uc.rule = rule
 
 	// query targets from db
 	tDay := uc.tradeDay.GetStockTradeDay().TradeDay
@@ -68,6 +97,8 @@ func NewTarget() Target {
 	uc.cc.AppendStockTargets(targetArr)
 	uc.publishNewStockTargets(targetArr)
 
+	go uc.realTimeAddTargets()
+
 	return uc
 }
 
@@ -76,6 +107,91 @@ func (uc *TargetUseCase) GetTargets(ctx context.Context) []*entity.StockTarget {
 	return uc.cc.GetStockTargets()
 }
 
+// loadTargetRule uses the rule saved by api, or the one in config if never saved.
+func (uc *TargetUseCase) loadTargetRule(ctx context.Context) (*entity.TargetRule, error) {
+	value, err := uc.systemRepo.QuerySetting(ctx, settingTargetRule)
+	if err != nil {
+		return nil, err
+	}
+	if value != "" {
+		rule := &entity.TargetRule{}
+		if err = json.Unmarshal([]byte(value), rule); err != nil {
+			return nil, err
+		}
+		return rule, nil
+	}
+
+	cfg := uc.cfg.TargetRule
+	return &entity.TargetRule{
+		PriceLow:        cfg.PriceLimit.Low,
+		PriceHigh:       cfg.PriceLimit.High,
+		MinVolume:       cfg.MinVolume,
+		IncludeExchange: cfg.IncludeExchange,
+		ExcludeExchange: cfg.ExcludeExchange,
+		IncludeCategory: cfg.IncludeCategory,
+		ExcludeCategory: cfg.ExcludeCategory,
+		DayTradeOnly:    cfg.DayTradeOnly,
+		MinVolumeRatio:  cfg.MinVolumeRatio,
+		MaxCount:        cfg.MaxCount,
+		RealTimeRank:    cfg.RealTimeRank,
+	}, nil
+}
+
+func (uc *TargetUseCase) GetTargetRule(ctx context.Context) *entity.TargetRule {
+	uc.ruleLock.RLock()
+	defer uc.ruleLock.RUnlock()
+	return uc.rule
+}
+
+// UpdateTargetRule saves the rule, it applies from the next target search.
+func (uc *TargetUseCase) UpdateTargetRule(ctx context.Context, rule *entity.TargetRule) error {
+	if rule.MaxCount <= 0 || rule.MaxCount > targetRuleMaxCount || rule.RealTimeRank < 0 || rule.RealTimeRank > targetRuleMaxCount ||
+		rule.PriceLow < 0 || rule.PriceHigh < 0 || (rule.PriceHigh > 0 && rule.PriceHigh < rule.PriceLow) ||
+		rule.MinVolume < 0 || rule.MinVolumeRatio < 0 {
+		return ErrTargetRuleInvalid
+	}
+
+	value, err := json.Marshal(rule)
+	if err != nil {
+		return err
+	}
+	if err = uc.systemRepo.UpsertSetting(ctx, settingTargetRule, string(value)); err != nil {
+		return err
+	}
+
+	uc.ruleLock.Lock()
+	uc.rule = rule
+	uc.ruleLock.Unlock()
+	return nil
+}
+
+// selectTargets keeps candidates passing the rule in rank order, up to max count of the rule.
+func (uc *TargetUseCase) selectTargets(tradeDay time.Time, candidates []*entity.TargetCandidate) []*entity.StockTarget {
+	rule := uc.GetTargetRule(context.Background())
+
+	var result []*entity.StockTarget
+	for _, v := range candidates {
+		if len(result) >= rule.MaxCount {
+			break
+		}
+
+		reason, ok := rule.Check(v)
+		if !ok {
+			continue
+		}
+
+		result = append(result, &entity.StockTarget{
+			Rank:     len(result) + 1,
+			StockNum: v.Stock.Number,
+			Volume:   v.Volume,
+			TradeDay: tradeDay,
+			Stock:    v.Stock,
+			Reason:   reason,
+		})
+	}
+	return result
+}
+
 func (uc *TargetUseCase) publishNewStockTargets(targetArr []*entity.StockTarget) {
 	if err := uc.repo.InsertOrUpdateTargetArr(context.Background(), targetArr); err != nil {
 		uc.logger.Fatal(err)
@@ -115,26 +231,28 @@ func (uc *TargetUseCase) searchTradeDayTargets(tradeDay time.Time) ([]*entity.St
 		return uc.searchTradeDayTargetsFromAllSnapshot(tradeDay)
 	}
 
-	var result []*entity.StockTarget
-	for _, v := range t {
-		if len(result) >= 25 {
-			break
-		}
+	rankCodes := make([]string, len(t))
+	for i, v := range t {
+		rankCodes[i] = v.GetCode()
+	}
+	searcher.Get().SetPopularity(rankCodes)
 
+	var candidates []*entity.TargetCandidate
+	for i, v := range t {
 		stock := uc.cc.GetStockDetail(v.GetCode())
 		if stock == nil {
 			continue
 		}
 
-		result = append(result, &entity.StockTarget{
-			Rank:     len(result) + 1,
-			StockNum: v.GetCode(),
-			Volume:   v.GetTotalVolume(),
-			TradeDay: tradeDay,
-			Stock:    stock,
+		candidates = append(candidates, &entity.TargetCandidate{
+			Stock:       stock,
+			Rank:        i + 1,
+			Close:       v.GetClose(),
+			Volume:      v.GetTotalVolume(),
+			VolumeRatio: v.GetVolumeRatio(),
 		})
 	}
-	return result, nil
+	return uc.selectTargets(tradeDay, candidates), nil
 }
 
 func (uc *TargetUseCase) GetCurrentVolumeRank() (*pb.StockVolumeRankResponse, error) {
@@ -165,26 +283,22 @@ func (uc *TargetUseCase) searchTradeDayTargetsFromAllSnapshot(tradeDay time.Time
 		return data[i].GetTotalVolume() > data[j].GetTotalVolume()
 	})
 
-	var result []*entity.StockTarget
-	for _, v := range data[:200] {
+	var candidates []*entity.TargetCandidate
+	for i, v := range data[:200] {
 		stock := uc.cc.GetStockDetail(v.GetCode())
 		if stock == nil {
 			continue
 		}
 
-		if len(result) >= 25 {
-			break
-		}
-
-		result = append(result, &entity.StockTarget{
-			Rank:     len(result) + 1,
-			StockNum: v.GetCode(),
-			Volume:   v.GetTotalVolume(),
-			TradeDay: tradeDay,
-			Stock:    stock,
+		candidates = append(candidates, &entity.TargetCandidate{
+			Stock:       stock,
+			Rank:        i + 1,
+			Close:       v.GetClose(),
+			Volume:      v.GetTotalVolume(),
+			VolumeRatio: v.GetVolumeRatio(),
 		})
 	}
-	return result, nil
+	return uc.selectTargets(tradeDay, candidates), nil
 }
 
 func (uc *TargetUseCase) getVolumeRankFromSnapshot() (*pb.StockVolumeRankResponse, error) {
@@ -244,57 +358,118 @@ func (uc *TargetUseCase) getVolumeRankFromSnapshot() (*pb.StockVolumeRankRespons
 	return result, nil
 }
 
-// func (uc *TargetUseCase) realTimeAddTargets() error {
-// 	data, err := uc.gRPCAPI.GetAllStockSnapshot()
-// 	if err != nil {
-// 		return err
-// 	}
-
-// 	// at least 200 snapshot to rank volume
-// 	if len(data) < 200 {
-// 		uc.logger.Warnf("stock snapshot len is not enough: %d", len(data))
-// 		return nil
-// 	}
-
-// 	sort.SliceStable(data, func(i, j int) bool {
-// 		return data[i].GetTotalVolume() > data[j].GetTotalVolume()
-// 	})
-// 	data = data[:uc.targetFilter.RealTimeRank]
-
-// 	currentTargets := uc.cc.GetStockTargets()
-// 	targetsMap := make(map[string]*entity.StockTarget)
-// 	for _, t := range currentTargets {
-// 		targetsMap[t.StockNum] = t
-// 	}
-
-// 	var newTargets []*entity.StockTarget
-// 	for i, d := range data {
-// 		stock := uc.cc.GetStockDetail(d.GetCode())
-// 		if stock == nil {
-// 			continue
-// 		}
-
-// 		if !uc.targetFilter.IsTarget(stock, d.GetClose()) {
-// 			continue
-// 		}
-
-// 		if targetsMap[d.GetCode()] == nil {
-// 			newTargets = append(newTargets, &entity.StockTarget{
-// 				Rank:     100 + i + 1,
-// 				StockNum: d.GetCode(),
-// 				Volume:   d.GetTotalVolume(),
-// 				TradeDay: uc.basic.TradeDay,
-// 				Stock:    stock,
-// 			})
-// 		}
-// 	}
-
-// 	if len(newTargets) != 0 {
-// 		uc.cc.AppendStockTargets(newTargets)
-// 		uc.publishNewStockTargets(newTargets)
-// 		for _, t := range newTargets {
-// 			uc.logger.Infof("New target: %s", t.StockNum)
-// 		}
-// 	}
-// 	return nil
-// }
+// realTimeAddTargets ranks all snapshots while the market is open, new stocks passing the rule are added as targets.
+func (uc *TargetUseCase) realTimeAddTargets() {
+	for range time.NewTicker(targetRealTimeInterval).C {
+		tradeDay := uc.tradeDay.GetStockTradeDay()
+		if !tradeDay.IsStockMarketOpenNow() {
+			continue
+		}
+		if err := uc.addRealTimeTargets(tradeDay.TradeDay); err != nil {
+			uc.logger.Error(err)
+		}
+	}
+}
+
+func (uc *TargetUseCase) addRealTimeTargets(tradeDay time.Time) error {
+	rule := uc.GetTargetRule(context.Background())
+	if rule.RealTimeRank == 0 {
+		return nil
+	}
+
+	data, err := uc.gRPCAPI.GetAllStockSnapshot()
+	if err != nil {
+		return err
+	}
+
+	// at least 200 snapshot to rank volume
+	if len(data) < 200 {
+		uc.logger.Warnf("stock snapshot len is not enough: %d", len(data))
+		return nil
+	}
+
+	sort.SliceStable(data, func(i, j int) bool {
+		return data[i].GetTotalVolume() > data[j].GetTotalVolume()
+	})
+	if len(data) > rule.RealTimeRank {
+		data = data[:rule.RealTimeRank]
+	}
+
+	targetsMap := make(map[string]struct{})
+	for _, t := range uc.cc.GetStockTargets() {
+		targetsMap[t.StockNum] = struct{}{}
+	}
+
+	var newTargets []*entity.StockTarget
+	for i, d := range data {
+		if _, ok := targetsMap[d.GetCode()]; ok {
+			continue
+		}
+		stock := uc.cc.GetStockDetail(d.GetCode())
+		if stock == nil {
+			continue
+		}
+
+		reason, ok := rule.Check(&entity.TargetCandidate{
+			Stock:       stock,
+			Rank:        i + 1,
+			Close:       d.GetClose(),
+			Volume:      d.GetTotalVolume(),
+			VolumeRatio: d.GetVolumeRatio(),
+		})
+		if !ok {
+			continue
+		}
+
+		newTargets = append(newTargets, &entity.StockTarget{
+			Rank:     targetRealTimeRankBase + i + 1,
+			StockNum: d.GetCode(),
+			Volume:   d.GetTotalVolume(),
+			TradeDay: tradeDay,
+			Stock:    stock,
+			Reason:   "intraday " + reason,
+		})
+	}
+
+	if len(newTargets) == 0 {
+		return nil
+	}
+
+	uc.cc.AppendStockTargets(newTargets)
+	uc.publishNewStockTargets(newTargets)
+	uc.notifyNewTargets(newTargets)
+	for _, t := range newTargets {
+		uc.logger.Infof("New target: %s", t.StockNum)
+	}
+	return nil
+}
+
+// SubscribeNewTargets returns a channel of targets added intraday, it is closed when ctx is done.
+func (uc *TargetUseCase) SubscribeNewTargets(ctx context.Context) <-chan []*entity.StockTarget {
+	ch := make(chan []*entity.StockTarget, 1)
+
+	uc.newTargetSubLock.Lock()
+	uc.newTargetSub[ch] = struct{}{}
+	uc.newTargetSubLock.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		uc.newTargetSubLock.Lock()
+		delete(uc.newTargetSub, ch)
+		close(ch)
+		uc.newTargetSubLock.Unlock()
+	}()
+	return ch
+}
+
+// notifyNewTargets skips subscribers not reading, they still get the full list by GetTargets.
+func (uc *TargetUseCase) notifyNewTargets(targets []*entity.StockTarget) {
+	uc.newTargetSubLock.Lock()
+	defer uc.newTargetSubLock.Unlock()
+	for ch := range uc.newTargetSub {
+		select {
+		case ch <- targets:
+		default:
+		}
+	}
+}
@@ -3,12 +3,15 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/robfig/cron/v3"
 	"github.com/toc-taiwan/toc-machine-trading/internal/config"
 	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
+	"github.com/toc-taiwan/toc-machine-trading/internal/usecase/cache"
 	"github.com/toc-taiwan/toc-machine-trading/internal/usecase/grpc"
 	"github.com/toc-taiwan/toc-machine-trading/internal/usecase/modules/calendar"
 	"github.com/toc-taiwan/toc-machine-trading/internal/usecase/modules/quota"
@@ -17,10 +20,25 @@ import (
 	"github.com/toc-taiwan/toc-machine-trading/pkg/log"
 )
 
+// expiryWarningSpec is before the day session opens, futureRollSpec is before the day session closes at 13:45.
+const (
+	expiryWarningSpec = "50 8 * * *"
+	futureRollSpec    = "0 13 * * *"
+
+	// futureRollFillTimeout is how long the close leg may take to fill before it is cancelled.
+	futureRollFillTimeout  = time.Minute
+	futureRollPollInterval = time.Second
+)
+
 // TradeUseCase -.
 type TradeUseCase struct {
-	repo repo.TradeRepo
-	sc   grpc.TradegRPCAPI
+	repo  repo.TradeRepo
+	sc    grpc.TradegRPCAPI
+	rgRPC grpc.RealTimegRPCAPI
+	cc    *cache.Cache
+
+	continuous    *continuousResolver
+	continuousCfg config.Continuous
 
 	quota    *quota.Quota
 	tradeDay *calendar.Calendar
@@ -30,8 +48,10 @@ type TradeUseCase struct {
 
 	finishedStockOrderMap  map[string]*entity.StockOrder
 	finishedFutureOrderMap map[string]*entity.FutureOrder
+	finishedOptionOrderMap map[string]*entity.OptionOrder
 	updateFutureOrderLock  sync.Mutex
 	updateStockOrderLock   sync.Mutex
+	updateOptionOrderLock  sync.Mutex
 
 	logger *log.Log
 	bus    *eventbus.Bus
@@ -45,15 +65,21 @@ func NewTrade() Trade {
 	tradeDay := calendar.Get()
 	uc := &TradeUseCase{
 		sc:    grpc.NewTrade(cfg.GetSinopacConn(), cfg.Simulation),
+		rgRPC: grpc.NewRealTime(cfg.GetSinopacConn()),
+		cc:    cache.Get(),
 		repo:  repo.NewTrade(cfg.GetPostgresPool()),
 		quota: quota.NewQuota(cfg.Quota),
 
+		continuous:    newContinuousResolver(),
+		continuousCfg: cfg.Continuous,
+
 		tradeDay:       tradeDay,
 		stockTradeDay:  tradeDay.GetStockTradeDay(),
 		futureTradeDay: tradeDay.GetFutureTradeDay(),
 
 		finishedStockOrderMap:  make(map[string]*entity.StockOrder),
 		finishedFutureOrderMap: make(map[string]*entity.FutureOrder),
+		finishedOptionOrderMap: make(map[string]*entity.OptionOrder),
 
 		logger: log.Get(),
 		bus:    eventbus.Get(),
@@ -61,12 +87,22 @@ func NewTrade() Trade {
 
 	uc.bus.SubscribeAsync(topicInsertOrUpdateStockOrder, true, uc.updateStockOrderCacheAndInsertDB)
 	uc.bus.SubscribeAsync(topicInsertOrUpdateFutureOrder, true, uc.updateFutureOrderCacheAndInsertDB)
+	uc.bus.SubscribeAsync(topicInsertOrUpdateOptionOrder, true, uc.updateOptionOrderCacheAndInsertDB)
 	uc.bus.SubscribeAsync(topicUpdateAuthTradeUser, true, uc.updateAuthUserMap)
 
 	go uc.askOrderStatus(cfg.Simulation)
 	go uc.updateAccountDetail()
 	go uc.updateAllTradeBalance()
 
+	job := cron.New()
+	if _, err := job.AddFunc(expiryWarningSpec, uc.publishFutureExpiryWarnings); err != nil {
+		uc.logger.Fatal(err)
+	}
+	if _, err := job.AddFunc(futureRollSpec, uc.rollExpiringFutures); err != nil {
+		uc.logger.Fatal(err)
+	}
+	job.Start()
+
 	return uc
 }
 
@@ -230,6 +266,13 @@ func (uc *TradeUseCase) updateAllTradeBalance() {
 				uc.logger.Fatal(err)
 			}
 			uc.calculateFutureTradeBalance(futureOrders, uc.futureTradeDay.TradeDay)
+
+			// options share the future trade day
+			optionOrders, err := uc.repo.QueryAllOptionOrderByDate(context.Background(), uc.futureTradeDay.ToStartEndArray())
+			if err != nil {
+				uc.logger.Fatal(err)
+			}
+			uc.calculateOptionTradeBalance(optionOrders, uc.futureTradeDay.TradeDay)
 		}
 	}
 }
@@ -559,6 +602,141 @@ func (uc *TradeUseCase) calculateReverseFutureBalance(reverse []*entity.FutureOr
 	return reverseBalance, tradeCount
 }
 
+func (uc *TradeUseCase) updateOptionOrderCacheAndInsertDB(order *entity.OptionOrder) {
+	defer uc.updateOptionOrderLock.Unlock()
+	uc.updateOptionOrderLock.Lock()
+	if _, ok := uc.finishedOptionOrderMap[order.OrderID]; ok {
+		return
+	}
+
+	// insert or update order to db
+	if err := uc.repo.InsertOrUpdateOptionOrderByOrderID(context.Background(), order); err != nil {
+		uc.logger.Fatal(err)
+	}
+
+	if !order.Cancellable() {
+		uc.finishedOptionOrderMap[order.OrderID] = order
+	}
+}
+
+// BuyOption -.
+func (uc *TradeUseCase) BuyOption(order *entity.OptionOrder) (string, entity.OrderStatus, error) {
+	if err := uc.checkOptionOrder(order); err != nil {
+		return "", entity.StatusUnknow, err
+	}
+
+	result, err := uc.sc.BuyOption(order)
+	if err != nil {
+		return "", entity.StatusUnknow, err
+	}
+
+	if e := result.GetError(); e != "" {
+		return "", entity.StatusUnknow, errors.New(e)
+	}
+
+	return result.GetOrderId(), entity.StringToOrderStatus(result.GetStatus()), nil
+}
+
+// SellOption -.
+func (uc *TradeUseCase) SellOption(order *entity.OptionOrder) (string, entity.OrderStatus, error) {
+	if err := uc.checkOptionOrder(order); err != nil {
+		return "", entity.StatusUnknow, err
+	}
+
+	result, err := uc.sc.SellOption(order)
+	if err != nil {
+		return "", entity.StatusUnknow, err
+	}
+
+	if e := result.GetError(); e != "" {
+		return "", entity.StatusUnknow, errors.New(e)
+	}
+
+	return result.GetOrderId(), entity.StringToOrderStatus(result.GetStatus()), nil
+}
+
+// checkOptionOrder rejects options the quota cannot price, balance and report use the TAIEX multiplier.
+func (uc *TradeUseCase) checkOptionOrder(order *entity.OptionOrder) error {
+	if order.Price <= 0 || order.Quantity <= 0 {
+		return ErrOptionOrderInvalid
+	}
+	option := uc.cc.GetOptionDetail(order.Code)
+	if option == nil {
+		return ErrOptionNotFound
+	}
+	if !quota.IsOptionSupported(option.Category) {
+		return ErrOptionNotSupported
+	}
+	return nil
+}
+
+// calculateOptionTradeBalance splits orders the same way as futures, balance is premium with fee and tax.
+func (uc *TradeUseCase) calculateOptionTradeBalance(allOrders []*entity.OptionOrder, tradeDay time.Time) {
+	var forward, reverse []*entity.OptionOrder
+	qtyMap := make(map[string]int64)
+	for _, v := range allOrders {
+		if v.Status != entity.StatusFilled {
+			continue
+		}
+
+		switch v.Action {
+		case entity.ActionBuy:
+			if qtyMap[v.Code] >= 0 {
+				forward = append(forward, v)
+			} else {
+				reverse = append(reverse, v)
+			}
+			qtyMap[v.Code] += v.Quantity
+		case entity.ActionSell:
+			if qtyMap[v.Code] > 0 {
+				forward = append(forward, v)
+			} else {
+				reverse = append(reverse, v)
+			}
+			qtyMap[v.Code] -= v.Quantity
+		}
+	}
+
+	forwardBalance, forwardCount := uc.calculateOptionBalance(forward)
+	revereBalance, reverseCount := uc.calculateOptionBalance(reverse)
+	tmp := &entity.OptionTradeBalance{
+		TradeDay:   tradeDay,
+		TradeCount: forwardCount + reverseCount,
+		Forward:    forwardBalance,
+		Reverse:    revereBalance,
+		Total:      forwardBalance + revereBalance,
+	}
+
+	err := uc.repo.InsertOrUpdateOptionTradeBalance(context.Background(), tmp)
+	if err != nil {
+		uc.logger.Fatal(err)
+	}
+}
+
+// calculateOptionBalance returns 0 balance until all positions are closed.
+func (uc *TradeUseCase) calculateOptionBalance(orders []*entity.OptionOrder) (int64, int64) {
+	var balance, tradeCount int64
+	var qty int64
+	for _, v := range orders {
+		tradeCount++
+
+		switch v.Action {
+		case entity.ActionBuy:
+			qty += v.Quantity
+			balance -= uc.quota.GetOptionBuyCost(v.Price, v.Quantity)
+		case entity.ActionSell:
+			qty -= v.Quantity
+			balance += uc.quota.GetOptionSellCost(v.Price, v.Quantity)
+		}
+	}
+
+	if qty != 0 {
+		return 0, tradeCount
+	}
+
+	return balance, tradeCount
+}
+
 // GetAllStockOrder -.
 func (uc *TradeUseCase) GetAllStockOrder(ctx context.Context) ([]*entity.StockOrder, error) {
 	return uc.repo.QueryAllStockOrder(ctx)
@@ -586,6 +764,15 @@ func (uc *TradeUseCase) GetAllFutureTradeBalance(ctx context.Context) ([]*entity
 	return tradeBalanceArr, nil
 }
 
+func (uc *TradeUseCase) GetAllOptionOrder(ctx context.Context) ([]*entity.OptionOrder, error) {
+	return uc.repo.QueryAllOptionOrder(ctx)
+}
+
+// GetAllOptionTradeBalance -.
+func (uc *TradeUseCase) GetAllOptionTradeBalance(ctx context.Context) ([]*entity.OptionTradeBalance, error) {
+	return uc.repo.QueryAllOptionTradeBalance(ctx)
+}
+
 // GetFuturePosition .
 func (uc *TradeUseCase) GetFuturePosition() ([]*entity.FuturePosition, error) {
 	query, err := uc.sc.GetFuturePosition()
@@ -606,6 +793,176 @@ func (uc *TradeUseCase) GetFuturePosition() ([]*entity.FuturePosition, error) {
 	return result, nil
 }
 
+// GetFutureExpiryWarnings returns open positions delivered within WarnDaysBefore trade days.
+func (uc *TradeUseCase) GetFutureExpiryWarnings(ctx context.Context) ([]*entity.FutureExpiryWarning, error) {
+	positions, err := uc.GetFuturePosition()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	result := []*entity.FutureExpiryWarning{}
+	for _, p := range positions {
+		future := uc.cc.GetFutureDetail(p.Code)
+		if future == nil || p.Position == 0 {
+			continue
+		}
+
+		deliveryDay := time.Date(future.DeliveryDate.Year(), future.DeliveryDate.Month(), future.DeliveryDate.Day(), 0, 0, 0, 0, time.Local)
+		daysLeft := uc.tradeDay.CountTradeDay(today, deliveryDay)
+		if daysLeft > uc.continuousCfg.WarnDaysBefore {
+			continue
+		}
+
+		warning := &entity.FutureExpiryWarning{
+			Code:          p.Code,
+			Category:      future.Category,
+			DeliveryDate:  future.DeliveryDate,
+			TradeDaysLeft: daysLeft,
+			Direction:     p.Direction,
+			Position:      p.Position,
+		}
+		schedule, err := uc.continuous.schedule(ctx, future.Category)
+		if err != nil {
+			return nil, err
+		}
+		if next := schedule.Next(p.Code); next != nil {
+			warning.RollTo = next.Code
+		}
+		result = append(result, warning)
+	}
+	return result, nil
+}
+
+func (uc *TradeUseCase) publishFutureExpiryWarnings() {
+	if _, err := uc.tradeDay.GetFutureTradePeriodByDate(time.Now().Format(entity.ShortTimeLayout)); err != nil {
+		return
+	}
+
+	warnings, err := uc.GetFutureExpiryWarnings(context.Background())
+	if err != nil {
+		uc.logger.Errorf("get future expiry warnings fail: %s", err)
+		return
+	}
+	for _, w := range warnings {
+		uc.logger.Warn(w.String())
+		uc.bus.PublishTopicEvent(topicFutureExpiryWarning, w)
+	}
+}
+
+// rollExpiringFutures closes positions on their roll day and opens the same ones on the next contract.
+func (uc *TradeUseCase) rollExpiringFutures() {
+	if !uc.continuousCfg.AutoRoll {
+		return
+	}
+	if _, err := uc.tradeDay.GetFutureTradePeriodByDate(time.Now().Format(entity.ShortTimeLayout)); err != nil {
+		return
+	}
+
+	warnings, err := uc.GetFutureExpiryWarnings(context.Background())
+	if err != nil {
+		uc.logger.Errorf("get future expiry warnings fail: %s", err)
+		return
+	}
+	for _, w := range warnings {
+		if w.RollTo == "" || !uc.autoRollCategory(w.Category) || w.TradeDaysLeft > uc.continuousCfg.RollDaysBefore {
+			continue
+		}
+
+		// recorded before any order, a position left by a failed roll is for the user, not the next run
+		claimed, err := uc.repo.InsertFutureRoll(context.Background(), &entity.FutureRoll{
+			Code:      w.Code,
+			RollTo:    w.RollTo,
+			Direction: w.Direction,
+			Position:  w.Position,
+			Created:   time.Now(),
+		})
+		if err != nil {
+			uc.logger.Errorf("record roll of %s fail: %s", w.Code, err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		if err := uc.rollFuture(w); err != nil {
+			uc.logger.Errorf("roll %s to %s fail: %s", w.Code, w.RollTo, err)
+			w.RollError = err.Error()
+		} else {
+			w.Rolled = true
+		}
+		uc.logger.Warn(w.String())
+		uc.bus.PublishTopicEvent(topicFutureExpiryWarning, w)
+	}
+}
+
+// autoRollCategory is true for all categories if none is configured.
+func (uc *TradeUseCase) autoRollCategory(category string) bool {
+	if len(uc.continuousCfg.AutoRollCategories) == 0 {
+		return true
+	}
+	for _, v := range uc.continuousCfg.AutoRollCategories {
+		if v == category {
+			return true
+		}
+	}
+	return false
+}
+
+// rollFuture closes the position at the price limit so it fills like a market order,
+// the next contract is opened only after the close is filled. A close not filled in time is cancelled.
+func (uc *TradeUseCase) rollFuture(w *entity.FutureExpiryWarning) error {
+	from, to := uc.cc.GetFutureDetail(w.Code), uc.cc.GetFutureDetail(w.RollTo)
+	if from == nil || to == nil || from.LimitUp == 0 || from.LimitDown == 0 || to.LimitUp == 0 || to.LimitDown == 0 {
+		return fmt.Errorf("price limit of %s or %s not found", w.Code, w.RollTo)
+	}
+
+	closeOrder := &entity.FutureOrder{Code: w.Code, Position: w.Position}
+	openOrder := &entity.FutureOrder{Code: w.RollTo, Position: w.Position}
+	closeFn, openFn := uc.BuyFuture, uc.SellFuture
+	closeOrder.Price, openOrder.Price = from.LimitUp, to.LimitDown
+	if w.Direction == entity.ActionStringBuy {
+		closeFn, openFn = uc.SellFuture, uc.BuyFuture
+		closeOrder.Price, openOrder.Price = from.LimitDown, to.LimitUp
+	}
+
+	orderID, status, err := closeFn(closeOrder)
+	if err != nil {
+		return err
+	}
+	if status != entity.StatusFilled {
+		if status, err = uc.waitFutureOrderFilled(orderID); err != nil {
+			return err
+		}
+		if status != entity.StatusFilled {
+			return fmt.Errorf("close order %s of %s is %s, next contract not opened", orderID, w.Code, status.String())
+		}
+	}
+
+	_, _, err = openFn(openOrder)
+	return err
+}
+
+// waitFutureOrderFilled returns the final status of the order, or cancels it after futureRollFillTimeout.
+func (uc *TradeUseCase) waitFutureOrderFilled(orderID string) (entity.OrderStatus, error) {
+	deadline := time.Now().Add(futureRollFillTimeout)
+	for time.Now().Before(deadline) {
+		uc.updateFutureOrderLock.Lock()
+		order := uc.finishedFutureOrderMap[orderID]
+		uc.updateFutureOrderLock.Unlock()
+		if order != nil {
+			return order.Status, nil
+		}
+		time.Sleep(futureRollPollInterval)
+	}
+
+	if _, _, err := uc.CancelOrderByID(orderID); err != nil {
+		return entity.StatusUnknow, fmt.Errorf("close order %s not filled in %s, cancel fail: %w", orderID, futureRollFillTimeout, err)
+	}
+	return entity.StatusUnknow, fmt.Errorf("close order %s not filled in %s, cancelled", orderID, futureRollFillTimeout)
+}
+
 func (uc *TradeUseCase) IsStockTradeTime() bool {
 	return uc.stockTradeDay.IsStockMarketOpenNow()
 }
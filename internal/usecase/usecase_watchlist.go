@@ -0,0 +1,296 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/toc-taiwan/toc-machine-trading/internal/config"
+	"github.com/toc-taiwan/toc-machine-trading/internal/entity"
+	"github.com/toc-taiwan/toc-machine-trading/internal/usecase/cache"
+	"github.com/toc-taiwan/toc-machine-trading/internal/usecase/repo"
+)
+
+// defaultWatchlistName is used when the pick stock websocket adds a stock before any list exists.
+const defaultWatchlistName = "Default"
+
+// WatchlistUseCase keeps named stock lists per user, the default list follows the pick stock websocket.
+type WatchlistUseCase struct {
+	repo       repo.WatchlistRepo
+	systemRepo repo.SystemRepo
+
+	cc *cache.Cache
+}
+
+// NewWatchlist -.
+func NewWatchlist() Watchlist {
+	cfg := config.Get()
+	return &WatchlistUseCase{
+		repo:       repo.NewWatchlist(cfg.GetPostgresPool()),
+		systemRepo: repo.NewSystemRepo(cfg.GetPostgresPool()),
+		cc:         cache.Get(),
+	}
+}
+
+func (uc *WatchlistUseCase) queryUserID(ctx context.Context, username string) (int, error) {
+	user, err := uc.systemRepo.QueryUserByUsername(ctx, username)
+	if err != nil {
+		return 0, err
+	}
+	if user == nil {
+		return 0, ErrUserNotFound
+	}
+	return user.ID, nil
+}
+
+func (uc *WatchlistUseCase) GetWatchlists(ctx context.Context, username string) ([]*entity.Watchlist, error) {
+	userID, err := uc.queryUserID(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	return uc.repo.QueryWatchlistByUserID(ctx, userID)
+}
+
+// queryUserWatchlist returns the list with all lists of the user, so callers can check names and default.
+func (uc *WatchlistUseCase) queryUserWatchlist(ctx context.Context, username string, id int) (*entity.Watchlist, []*entity.Watchlist, error) {
+	lists, err := uc.GetWatchlists(ctx, username)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, v := range lists {
+		if v.ID == id {
+			return v, lists, nil
+		}
+	}
+	return nil, nil, ErrWatchlistNotFound
+}
+
+func checkWatchlistName(name string, id int, lists []*entity.Watchlist) error {
+	if name == "" {
+		return ErrWatchlistInvalid
+	}
+	for _, v := range lists {
+		if v.ID != id && v.Name == name {
+			return ErrWatchlistInvalid
+		}
+	}
+	return nil
+}
+
+// CreateWatchlist creates the list, the first list of the user is always default.
+func (uc *WatchlistUseCase) CreateWatchlist(ctx context.Context, username string, t *entity.NewWatchlist) (*entity.Watchlist, error) {
+	userID, err := uc.queryUserID(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	lists, err := uc.repo.QueryWatchlistByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	name := strings.TrimSpace(t.Name)
+	if err = checkWatchlistName(name, 0, lists); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	list := &entity.Watchlist{
+		UserID:  userID,
+		Name:    name,
+		Default: t.Default || len(lists) == 0,
+		Sort:    t.Sort,
+		Items:   []*entity.WatchlistItem{},
+		Created: now,
+		Updated: now,
+	}
+	if err = uc.repo.InsertWatchlist(ctx, list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// UpdateWatchlist renames or reorders the list, default can only be moved to another list, not unset.
+func (uc *WatchlistUseCase) UpdateWatchlist(ctx context.Context, username string, id int, t *entity.NewWatchlist) (*entity.Watchlist, error) {
+	list, lists, err := uc.queryUserWatchlist(ctx, username, id)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(t.Name)
+	if err = checkWatchlistName(name, id, lists); err != nil {
+		return nil, err
+	}
+
+	list.Name = name
+	list.Default = list.Default || t.Default
+	list.Sort = t.Sort
+	list.Updated = time.Now()
+	if err = uc.repo.UpdateWatchlist(ctx, list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// DeleteWatchlist deletes the list, the next list in order becomes default if the deleted one was.
+func (uc *WatchlistUseCase) DeleteWatchlist(ctx context.Context, username string, id int) error {
+	list, lists, err := uc.queryUserWatchlist(ctx, username, id)
+	if err != nil {
+		return err
+	}
+
+	if err = uc.repo.DeleteWatchlist(ctx, list.ID); err != nil {
+		return err
+	}
+	if !list.Default {
+		return nil
+	}
+
+	for _, v := range lists {
+		if v.ID == list.ID {
+			continue
+		}
+		v.Default = true
+		v.Updated = time.Now()
+		return uc.repo.UpdateWatchlist(ctx, v)
+	}
+	return nil
+}
+
+// PutWatchlistItem adds the stock or updates its note and sort.
+func (uc *WatchlistUseCase) PutWatchlistItem(ctx context.Context, username string, id int, t *entity.NewWatchlistItem) (*entity.Watchlist, error) {
+	list, _, err := uc.queryUserWatchlist(ctx, username, id)
+	if err != nil {
+		return nil, err
+	}
+	if uc.cc.GetStockDetail(t.Code) == nil {
+		return nil, ErrWatchlistCodeNotFound
+	}
+
+	item := &entity.WatchlistItem{
+		WatchlistID: list.ID,
+		UserID:      list.UserID,
+		Code:        t.Code,
+		Note:        t.Note,
+		Sort:        len(list.Items),
+		Created:     time.Now(),
+	}
+	for _, v := range list.Items {
+		if v.Code == t.Code {
+			item.Sort = v.Sort
+			item.Created = v.Created
+		}
+	}
+	if t.Sort != nil {
+		item.Sort = *t.Sort
+	}
+
+	if err = uc.repo.InsertOrUpdateWatchlistItem(ctx, []*entity.WatchlistItem{item}); err != nil {
+		return nil, err
+	}
+	list, _, err = uc.queryUserWatchlist(ctx, username, id)
+	return list, err
+}
+
+func (uc *WatchlistUseCase) DeleteWatchlistItem(ctx context.Context, username string, id int, code string) (*entity.Watchlist, error) {
+	list, _, err := uc.queryUserWatchlist(ctx, username, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = uc.repo.DeleteWatchlistItem(ctx, list.ID, []string{code}); err != nil {
+		return nil, err
+	}
+	list, _, err = uc.queryUserWatchlist(ctx, username, id)
+	return list, err
+}
+
+// SortWatchlistItem reorders items to the given codes, which must be exactly the codes in the list.
+func (uc *WatchlistUseCase) SortWatchlistItem(ctx context.Context, username string, id int, codes []string) (*entity.Watchlist, error) {
+	list, _, err := uc.queryUserWatchlist(ctx, username, id)
+	if err != nil {
+		return nil, err
+	}
+
+	codeMap := make(map[string]struct{})
+	for _, v := range list.Items {
+		codeMap[v.Code] = struct{}{}
+	}
+	if len(codes) != len(codeMap) {
+		return nil, ErrWatchlistSortInvalid
+	}
+	for _, v := range codes {
+		if _, ok := codeMap[v]; !ok {
+			return nil, ErrWatchlistSortInvalid
+		}
+		delete(codeMap, v)
+	}
+
+	if err = uc.repo.UpdateWatchlistItemSort(ctx, list.ID, codes); err != nil {
+		return nil, err
+	}
+	list, _, err = uc.queryUserWatchlist(ctx, username, id)
+	return list, err
+}
+
+func (uc *WatchlistUseCase) queryDefaultWatchlist(ctx context.Context, username string) (*entity.Watchlist, error) {
+	lists, err := uc.GetWatchlists(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range lists {
+		if v.Default {
+			return v, nil
+		}
+	}
+	return nil, nil
+}
+
+// GetDefaultWatchlistCodes is used to seed the pick stock websocket.
+func (uc *WatchlistUseCase) GetDefaultWatchlistCodes(ctx context.Context, username string) ([]string, error) {
+	list, err := uc.queryDefaultWatchlist(ctx, username)
+	if err != nil || list == nil {
+		return nil, err
+	}
+	return list.Codes(), nil
+}
+
+// SyncDefaultWatchlist applies pick stock websocket changes to the default list, creating it on first add.
+func (uc *WatchlistUseCase) SyncDefaultWatchlist(ctx context.Context, username string, add, remove []string) error {
+	list, err := uc.queryDefaultWatchlist(ctx, username)
+	if err != nil {
+		return err
+	}
+	if list == nil {
+		if len(add) == 0 {
+			return nil
+		}
+		if list, err = uc.CreateWatchlist(ctx, username, &entity.NewWatchlist{Name: defaultWatchlistName, Default: true}); err != nil {
+			return err
+		}
+	}
+
+	codeMap := make(map[string]struct{})
+	for _, v := range list.Items {
+		codeMap[v.Code] = struct{}{}
+	}
+
+	var items []*entity.WatchlistItem
+	now := time.Now()
+	for _, code := range add {
+		if _, ok := codeMap[code]; ok || uc.cc.GetStockDetail(code) == nil {
+			continue
+		}
+		codeMap[code] = struct{}{}
+		items = append(items, &entity.WatchlistItem{
+			WatchlistID: list.ID,
+			UserID:      list.UserID,
+			Code:        code,
+			Sort:        len(list.Items) + len(items),
+			Created:     now,
+		})
+	}
+	if err = uc.repo.InsertOrUpdateWatchlistItem(ctx, items); err != nil {
+		return err
+	}
+	return uc.repo.DeleteWatchlistItem(ctx, list.ID, remove)
+}
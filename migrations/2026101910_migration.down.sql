@@ -0,0 +1,11 @@
+BEGIN;
+
+ALTER TABLE system_account DROP CONSTRAINT IF EXISTS "fk_system_account_role";
+
+ALTER TABLE system_account DROP COLUMN IF EXISTS "role";
+
+DROP TABLE IF EXISTS system_role_permission;
+
+DROP TABLE IF EXISTS system_role;
+
+COMMIT;
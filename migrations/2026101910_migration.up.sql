@@ -0,0 +1,42 @@
+BEGIN;
+
+CREATE TABLE
+    system_role (
+        "name" VARCHAR PRIMARY KEY,
+        "description" VARCHAR NOT NULL
+    );
+
+CREATE TABLE
+    system_role_permission (
+        "id" SERIAL PRIMARY KEY,
+        "role" VARCHAR NOT NULL,
+        "permission" VARCHAR NOT NULL,
+        UNIQUE ("role", "permission")
+    );
+
+ALTER TABLE system_role_permission ADD CONSTRAINT "fk_system_role_permission_role" FOREIGN KEY ("role") REFERENCES system_role ("name");
+
+INSERT INTO
+    system_role ("name", "description")
+VALUES
+    ('admin', 'manage users, roles and notifications'),
+    ('trader', 'place and cancel orders'),
+    ('viewer', 'read market data only');
+
+INSERT INTO
+    system_role_permission ("role", "permission")
+VALUES
+    ('admin', 'view'),
+    ('admin', 'trade'),
+    ('admin', 'admin'),
+    ('trader', 'view'),
+    ('trader', 'trade'),
+    ('viewer', 'view');
+
+ALTER TABLE system_account ADD COLUMN "role" VARCHAR NOT NULL DEFAULT 'viewer';
+
+ALTER TABLE system_account ADD CONSTRAINT "fk_system_account_role" FOREIGN KEY ("role") REFERENCES system_role ("name");
+
+UPDATE system_account SET "role" = 'trader' WHERE "auth_trade" = TRUE;
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP TABLE IF EXISTS system_password_reset;
+
+ALTER TABLE system_account DROP COLUMN IF EXISTS "token_valid_after";
+
+COMMIT;
@@ -0,0 +1,19 @@
+BEGIN;
+
+ALTER TABLE system_account ADD COLUMN "token_valid_after" TIMESTAMPTZ NOT NULL DEFAULT to_timestamp(0);
+
+CREATE TABLE
+    system_password_reset (
+        "id" SERIAL PRIMARY KEY,
+        "user_id" INT NOT NULL,
+        "code_hash" VARCHAR NOT NULL,
+        "expire_at" TIMESTAMPTZ NOT NULL,
+        "used" BOOLEAN NOT NULL DEFAULT FALSE,
+        "created" TIMESTAMPTZ NOT NULL
+    );
+
+CREATE INDEX system_password_reset_user_index ON system_password_reset USING btree ("user_id");
+
+ALTER TABLE system_password_reset ADD CONSTRAINT "fk_system_password_reset_user" FOREIGN KEY ("user_id") REFERENCES system_account ("id");
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP TABLE IF EXISTS system_email_verification;
+
+ALTER TABLE system_account DROP COLUMN IF EXISTS "created";
+
+COMMIT;
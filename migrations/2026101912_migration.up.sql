@@ -0,0 +1,20 @@
+BEGIN;
+
+ALTER TABLE system_account ADD COLUMN "created" TIMESTAMPTZ NOT NULL DEFAULT NOW();
+
+CREATE TABLE
+    system_email_verification (
+        "id" SERIAL PRIMARY KEY,
+        "user_id" INT NOT NULL,
+        "code_hash" VARCHAR NOT NULL,
+        "expire_at" TIMESTAMPTZ NOT NULL,
+        "attempts" INT NOT NULL DEFAULT 0,
+        "used" BOOLEAN NOT NULL DEFAULT FALSE,
+        "created" TIMESTAMPTZ NOT NULL
+    );
+
+CREATE INDEX system_email_verification_user_index ON system_email_verification USING btree ("user_id");
+
+ALTER TABLE system_email_verification ADD CONSTRAINT "fk_system_email_verification_user" FOREIGN KEY ("user_id") REFERENCES system_account ("id");
+
+COMMIT;
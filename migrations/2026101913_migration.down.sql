@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP TABLE IF EXISTS system_session;
+
+ALTER TABLE system_jwt DROP COLUMN IF EXISTS "retire_at";
+
+COMMIT;
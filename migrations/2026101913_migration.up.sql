@@ -0,0 +1,23 @@
+BEGIN;
+
+ALTER TABLE system_jwt ADD COLUMN "retire_at" TIMESTAMPTZ;
+
+CREATE TABLE
+    system_session (
+        "id" SERIAL PRIMARY KEY,
+        "session_id" VARCHAR NOT NULL UNIQUE,
+        "user_id" INT NOT NULL,
+        "refresh_token_hash" VARCHAR NOT NULL UNIQUE,
+        "user_agent" VARCHAR NOT NULL,
+        "ip" VARCHAR NOT NULL,
+        "created" TIMESTAMPTZ NOT NULL,
+        "last_seen" TIMESTAMPTZ NOT NULL,
+        "expire_at" TIMESTAMPTZ NOT NULL,
+        "revoked" BOOLEAN NOT NULL DEFAULT FALSE
+    );
+
+CREATE INDEX system_session_user_index ON system_session USING btree ("user_id");
+
+ALTER TABLE system_session ADD CONSTRAINT "fk_system_session_user" FOREIGN KEY ("user_id") REFERENCES system_account ("id");
+
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+
+DROP TABLE IF EXISTS system_setting;
+
+DROP TABLE IF EXISTS system_totp_backup_code;
+
+DROP TABLE IF EXISTS system_totp;
+
+COMMIT;
@@ -0,0 +1,33 @@
+BEGIN;
+
+CREATE TABLE
+    system_totp (
+        "user_id" INT PRIMARY KEY,
+        "secret" VARCHAR NOT NULL,
+        "enabled" BOOLEAN NOT NULL DEFAULT FALSE,
+        "last_used_step" BIGINT NOT NULL DEFAULT 0,
+        "created" TIMESTAMPTZ NOT NULL
+    );
+
+ALTER TABLE system_totp ADD CONSTRAINT "fk_system_totp_user" FOREIGN KEY ("user_id") REFERENCES system_account ("id");
+
+CREATE TABLE
+    system_totp_backup_code (
+        "id" SERIAL PRIMARY KEY,
+        "user_id" INT NOT NULL,
+        "code_hash" VARCHAR NOT NULL,
+        "used" BOOLEAN NOT NULL DEFAULT FALSE
+    );
+
+CREATE INDEX system_totp_backup_code_user_index ON system_totp_backup_code USING btree ("user_id");
+
+ALTER TABLE system_totp_backup_code ADD CONSTRAINT "fk_system_totp_backup_code_user" FOREIGN KEY ("user_id") REFERENCES system_account ("id");
+
+CREATE TABLE
+    system_setting (
+        "key" VARCHAR PRIMARY KEY,
+        "value" VARCHAR NOT NULL,
+        "updated" TIMESTAMPTZ NOT NULL
+    );
+
+COMMIT;
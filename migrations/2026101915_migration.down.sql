@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE IF EXISTS system_api_key;
+
+COMMIT;
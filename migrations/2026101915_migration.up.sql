@@ -0,0 +1,22 @@
+BEGIN;
+
+CREATE TABLE
+    system_api_key (
+        "id" SERIAL PRIMARY KEY,
+        "key_id" VARCHAR NOT NULL UNIQUE,
+        "user_id" INT NOT NULL,
+        "name" VARCHAR NOT NULL,
+        "key_hash" VARCHAR NOT NULL,
+        "scopes" VARCHAR[] NOT NULL,
+        "ip_allowlist" VARCHAR[] NOT NULL,
+        "expire_at" TIMESTAMPTZ,
+        "last_used" TIMESTAMPTZ,
+        "revoked" BOOLEAN NOT NULL DEFAULT FALSE,
+        "created" TIMESTAMPTZ NOT NULL
+    );
+
+CREATE INDEX system_api_key_user_index ON system_api_key USING btree ("user_id");
+
+ALTER TABLE system_api_key ADD CONSTRAINT "fk_system_api_key_user" FOREIGN KEY ("user_id") REFERENCES system_account ("id");
+
+COMMIT;
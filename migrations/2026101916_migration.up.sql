@@ -0,0 +1,37 @@
+BEGIN;
+
+CREATE TABLE
+    alert_rule (
+        "id" SERIAL PRIMARY KEY,
+        "user_id" INT NOT NULL,
+        "type" VARCHAR NOT NULL,
+        "code" VARCHAR NOT NULL,
+        "threshold" DECIMAL NOT NULL,
+        "cooldown" INT NOT NULL,
+        "enabled" BOOLEAN NOT NULL DEFAULT TRUE,
+        "last_triggered" TIMESTAMPTZ,
+        "created" TIMESTAMPTZ NOT NULL
+    );
+
+CREATE INDEX alert_rule_user_index ON alert_rule USING btree ("user_id");
+
+ALTER TABLE alert_rule ADD CONSTRAINT "fk_alert_rule_user" FOREIGN KEY ("user_id") REFERENCES system_account ("id");
+
+CREATE TABLE
+    alert_history (
+        "id" SERIAL PRIMARY KEY,
+        "rule_id" INT NOT NULL,
+        "user_id" INT NOT NULL,
+        "type" VARCHAR NOT NULL,
+        "code" VARCHAR NOT NULL,
+        "threshold" DECIMAL NOT NULL,
+        "value" DECIMAL NOT NULL,
+        "message" VARCHAR NOT NULL,
+        "triggered" TIMESTAMPTZ NOT NULL
+    );
+
+CREATE INDEX alert_history_user_index ON alert_history USING btree ("user_id", "triggered");
+
+ALTER TABLE alert_history ADD CONSTRAINT "fk_alert_history_user" FOREIGN KEY ("user_id") REFERENCES system_account ("id");
+
+COMMIT;
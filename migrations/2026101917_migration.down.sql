@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE system_push_token DROP COLUMN IF EXISTS "order_push";
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE system_push_token ADD COLUMN "order_push" BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP TABLE IF EXISTS system_notify_preference;
+
+DROP TABLE IF EXISTS system_notify_address;
+
+COMMIT;
@@ -0,0 +1,25 @@
+BEGIN;
+
+CREATE TABLE
+    system_notify_address (
+        "id" SERIAL PRIMARY KEY,
+        "user_id" INT NOT NULL UNIQUE,
+        "slack_webhook" VARCHAR NOT NULL,
+        "webhook_url" VARCHAR NOT NULL,
+        "updated" TIMESTAMPTZ NOT NULL
+    );
+
+ALTER TABLE system_notify_address ADD CONSTRAINT "fk_system_notify_address_user" FOREIGN KEY ("user_id") REFERENCES system_account ("id");
+
+CREATE TABLE
+    system_notify_preference (
+        "id" SERIAL PRIMARY KEY,
+        "user_id" INT NOT NULL,
+        "event" VARCHAR NOT NULL,
+        "channels" VARCHAR[] NOT NULL,
+        UNIQUE ("user_id", "event")
+    );
+
+ALTER TABLE system_notify_preference ADD CONSTRAINT "fk_system_notify_preference_user" FOREIGN KEY ("user_id") REFERENCES system_account ("id");
+
+COMMIT;
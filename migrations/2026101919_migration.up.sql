@@ -0,0 +1,11 @@
+BEGIN;
+
+CREATE TABLE
+    report_daily (
+        "id" SERIAL PRIMARY KEY,
+        "date" TIMESTAMPTZ NOT NULL UNIQUE,
+        "content" JSONB NOT NULL,
+        "created" TIMESTAMPTZ NOT NULL
+    );
+
+COMMIT;
@@ -0,0 +1,33 @@
+BEGIN;
+
+CREATE TABLE
+    watchlist (
+        "id" SERIAL PRIMARY KEY,
+        "user_id" INT NOT NULL,
+        "name" VARCHAR NOT NULL,
+        "is_default" BOOLEAN NOT NULL DEFAULT FALSE,
+        "sort" INT NOT NULL,
+        "created" TIMESTAMPTZ NOT NULL,
+        "updated" TIMESTAMPTZ NOT NULL,
+        UNIQUE ("user_id", "name")
+    );
+
+ALTER TABLE watchlist ADD CONSTRAINT "fk_watchlist_user" FOREIGN KEY ("user_id") REFERENCES system_account ("id");
+
+CREATE TABLE
+    watchlist_item (
+        "id" SERIAL PRIMARY KEY,
+        "watchlist_id" INT NOT NULL,
+        "user_id" INT NOT NULL,
+        "code" VARCHAR NOT NULL,
+        "note" VARCHAR NOT NULL,
+        "sort" INT NOT NULL,
+        "created" TIMESTAMPTZ NOT NULL,
+        UNIQUE ("watchlist_id", "code")
+    );
+
+ALTER TABLE watchlist_item ADD CONSTRAINT "fk_watchlist_item_watchlist" FOREIGN KEY ("watchlist_id") REFERENCES watchlist ("id");
+
+ALTER TABLE watchlist_item ADD CONSTRAINT "fk_watchlist_item_user" FOREIGN KEY ("user_id") REFERENCES system_account ("id");
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE basic_targets DROP COLUMN IF EXISTS "reason";
+
+COMMIT;
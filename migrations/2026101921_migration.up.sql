@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE basic_targets ADD COLUMN "reason" VARCHAR NOT NULL DEFAULT '';
+
+COMMIT;
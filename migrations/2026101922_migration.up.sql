@@ -0,0 +1,19 @@
+BEGIN;
+
+CREATE TABLE
+    analyze_pattern_hit (
+        "id" SERIAL PRIMARY KEY,
+        "date" TIMESTAMPTZ NOT NULL,
+        "stock_num" VARCHAR NOT NULL,
+        "pattern" VARCHAR NOT NULL,
+        "detail" VARCHAR NOT NULL,
+        "value" DECIMAL NOT NULL,
+        "created" TIMESTAMPTZ NOT NULL,
+        UNIQUE ("date", "stock_num", "pattern")
+    );
+
+CREATE INDEX analyze_pattern_hit_date_index ON analyze_pattern_hit USING btree ("date");
+
+ALTER TABLE analyze_pattern_hit ADD CONSTRAINT "fk_analyze_pattern_hit_stock" FOREIGN KEY ("stock_num") REFERENCES basic_stock ("number");
+
+COMMIT;
@@ -0,0 +1,20 @@
+BEGIN;
+
+CREATE TABLE
+    history_future_kbar (
+        "id" SERIAL PRIMARY KEY,
+        "code" VARCHAR NOT NULL,
+        "trade_day" TIMESTAMPTZ NOT NULL,
+        "kbar_time" TIMESTAMPTZ NOT NULL,
+        "open" DECIMAL NOT NULL,
+        "high" DECIMAL NOT NULL,
+        "low" DECIMAL NOT NULL,
+        "close" DECIMAL NOT NULL,
+        "volume" INT NOT NULL
+    );
+
+CREATE INDEX history_future_kbar_code_trade_day_index ON history_future_kbar USING btree ("code", "trade_day");
+
+ALTER TABLE history_future_kbar ADD CONSTRAINT "fk_history_future_kbar_future" FOREIGN KEY ("code") REFERENCES basic_future ("code");
+
+COMMIT;
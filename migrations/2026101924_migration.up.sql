@@ -0,0 +1,16 @@
+BEGIN;
+
+CREATE TABLE
+    basic_corporate_action (
+        "id" SERIAL PRIMARY KEY,
+        "stock_num" VARCHAR NOT NULL,
+        "ex_date" TIMESTAMPTZ NOT NULL,
+        "action_type" VARCHAR NOT NULL,
+        "cash" DECIMAL NOT NULL,
+        "ratio" DECIMAL NOT NULL,
+        UNIQUE ("stock_num", "ex_date", "action_type")
+    );
+
+ALTER TABLE basic_corporate_action ADD CONSTRAINT "fk_basic_corporate_action_stock" FOREIGN KEY ("stock_num") REFERENCES basic_stock ("number");
+
+COMMIT;
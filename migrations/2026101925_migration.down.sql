@@ -0,0 +1,86 @@
+BEGIN;
+
+-- archived partitions are not restored, rows of the partitions left are copied back
+ALTER TABLE history_stock_close RENAME TO history_stock_close_partitioned;
+ALTER INDEX history_stock_close_stock_num_index RENAME TO history_stock_close_partitioned_stock_num_index;
+
+CREATE TABLE
+    history_stock_close_unpartitioned (
+        "id" SERIAL PRIMARY KEY,
+        "date" TIMESTAMPTZ NOT NULL,
+        "stock_num" VARCHAR NOT NULL,
+        "close" DECIMAL NOT NULL
+    );
+
+INSERT INTO history_stock_close_unpartitioned ("date", "stock_num", "close")
+SELECT "date", "stock_num", "close" FROM history_stock_close_partitioned ORDER BY "id";
+
+DROP TABLE history_stock_close_partitioned;
+
+ALTER TABLE history_stock_close_unpartitioned RENAME TO history_stock_close;
+ALTER SEQUENCE history_stock_close_unpartitioned_id_seq RENAME TO history_stock_close_id_seq;
+ALTER INDEX history_stock_close_unpartitioned_pkey RENAME TO history_stock_close_pkey;
+
+CREATE INDEX history_stock_close_stock_num_index ON history_stock_close USING btree ("stock_num");
+
+ALTER TABLE history_stock_close ADD CONSTRAINT "fk_history_stock_close_stock" FOREIGN KEY ("stock_num") REFERENCES basic_stock ("number");
+
+ALTER TABLE history_stock_kbar RENAME TO history_stock_kbar_partitioned;
+ALTER INDEX history_stock_kbar_stock_num_index RENAME TO history_stock_kbar_partitioned_stock_num_index;
+
+CREATE TABLE
+    history_stock_kbar_unpartitioned (
+        "id" SERIAL PRIMARY KEY,
+        "stock_num" VARCHAR NOT NULL,
+        "kbar_time" TIMESTAMPTZ NOT NULL,
+        "open" DECIMAL NOT NULL,
+        "high" DECIMAL NOT NULL,
+        "low" DECIMAL NOT NULL,
+        "close" DECIMAL NOT NULL,
+        "volume" INT NOT NULL
+    );
+
+INSERT INTO history_stock_kbar_unpartitioned ("stock_num", "kbar_time", "open", "high", "low", "close", "volume")
+SELECT "stock_num", "kbar_time", "open", "high", "low", "close", "volume" FROM history_stock_kbar_partitioned ORDER BY "id";
+
+DROP TABLE history_stock_kbar_partitioned;
+
+ALTER TABLE history_stock_kbar_unpartitioned RENAME TO history_stock_kbar;
+ALTER SEQUENCE history_stock_kbar_unpartitioned_id_seq RENAME TO history_stock_kbar_id_seq;
+ALTER INDEX history_stock_kbar_unpartitioned_pkey RENAME TO history_stock_kbar_pkey;
+
+CREATE INDEX history_stock_kbar_stock_num_index ON history_stock_kbar USING btree ("stock_num");
+
+ALTER TABLE history_stock_kbar ADD CONSTRAINT "fk_history_stock_kbar_stock" FOREIGN KEY ("stock_num") REFERENCES basic_stock ("number");
+
+ALTER TABLE history_stock_tick RENAME TO history_stock_tick_partitioned;
+ALTER INDEX history_stock_tick_stock_num_index RENAME TO history_stock_tick_partitioned_stock_num_index;
+
+CREATE TABLE
+    history_stock_tick_unpartitioned (
+        "id" SERIAL PRIMARY KEY,
+        "stock_num" VARCHAR NOT NULL,
+        "tick_time" TIMESTAMPTZ NOT NULL,
+        "close" DECIMAL NOT NULL,
+        "tick_type" INT NOT NULL,
+        "volume" INT NOT NULL,
+        "bid_price" DECIMAL NOT NULL,
+        "bid_volume" INT NOT NULL,
+        "ask_price" DECIMAL NOT NULL,
+        "ask_volume" INT NOT NULL
+    );
+
+INSERT INTO history_stock_tick_unpartitioned ("stock_num", "tick_time", "close", "tick_type", "volume", "bid_price", "bid_volume", "ask_price", "ask_volume")
+SELECT "stock_num", "tick_time", "close", "tick_type", "volume", "bid_price", "bid_volume", "ask_price", "ask_volume" FROM history_stock_tick_partitioned ORDER BY "id";
+
+DROP TABLE history_stock_tick_partitioned;
+
+ALTER TABLE history_stock_tick_unpartitioned RENAME TO history_stock_tick;
+ALTER SEQUENCE history_stock_tick_unpartitioned_id_seq RENAME TO history_stock_tick_id_seq;
+ALTER INDEX history_stock_tick_unpartitioned_pkey RENAME TO history_stock_tick_pkey;
+
+CREATE INDEX history_stock_tick_stock_num_index ON history_stock_tick USING btree ("stock_num");
+
+ALTER TABLE history_stock_tick ADD CONSTRAINT "fk_history_stock_tick_stock" FOREIGN KEY ("stock_num") REFERENCES basic_stock ("number");
+
+COMMIT;
@@ -0,0 +1,116 @@
+BEGIN;
+
+-- month bounds follow the time zone of the app, partitions are named by month like history_stock_tick_p202510
+SET LOCAL TIME ZONE 'Asia/Taipei';
+
+CREATE FUNCTION pg_temp.create_monthly_partitions(parent TEXT, from_time TIMESTAMPTZ, to_time TIMESTAMPTZ) RETURNS VOID AS $$
+DECLARE
+    month_start TIMESTAMPTZ;
+BEGIN
+    FOR month_start IN
+        SELECT generate_series(
+            date_trunc('month', COALESCE(from_time, now())),
+            date_trunc('month', GREATEST(COALESCE(to_time, now()), now())) + INTERVAL '1 month',
+            INTERVAL '1 month'
+        )
+    LOOP
+        EXECUTE format(
+            'CREATE TABLE %I PARTITION OF %I FOR VALUES FROM (%L) TO (%L)',
+            parent || '_p' || to_char(month_start, 'YYYYMM'), parent, month_start, month_start + INTERVAL '1 month'
+        );
+    END LOOP;
+END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE history_stock_close RENAME TO history_stock_close_unpartitioned;
+ALTER SEQUENCE history_stock_close_id_seq RENAME TO history_stock_close_unpartitioned_id_seq;
+ALTER INDEX history_stock_close_pkey RENAME TO history_stock_close_unpartitioned_pkey;
+ALTER INDEX history_stock_close_stock_num_index RENAME TO history_stock_close_unpartitioned_stock_num_index;
+
+CREATE TABLE
+    history_stock_close (
+        "id" SERIAL,
+        "date" TIMESTAMPTZ NOT NULL,
+        "stock_num" VARCHAR NOT NULL,
+        "close" DECIMAL NOT NULL,
+        PRIMARY KEY ("id", "date")
+    ) PARTITION BY RANGE ("date");
+
+CREATE INDEX history_stock_close_stock_num_index ON history_stock_close USING btree ("stock_num", "date");
+
+ALTER TABLE history_stock_close ADD CONSTRAINT "fk_history_stock_close_stock" FOREIGN KEY ("stock_num") REFERENCES basic_stock ("number");
+
+SELECT pg_temp.create_monthly_partitions('history_stock_close', (SELECT min("date") FROM history_stock_close_unpartitioned), (SELECT max("date") FROM history_stock_close_unpartitioned));
+
+INSERT INTO history_stock_close ("id", "date", "stock_num", "close")
+SELECT "id", "date", "stock_num", "close" FROM history_stock_close_unpartitioned;
+
+SELECT setval(pg_get_serial_sequence('history_stock_close', 'id'), COALESCE((SELECT max("id") FROM history_stock_close), 0) + 1, false);
+
+DROP TABLE history_stock_close_unpartitioned;
+
+ALTER TABLE history_stock_kbar RENAME TO history_stock_kbar_unpartitioned;
+ALTER SEQUENCE history_stock_kbar_id_seq RENAME TO history_stock_kbar_unpartitioned_id_seq;
+ALTER INDEX history_stock_kbar_pkey RENAME TO history_stock_kbar_unpartitioned_pkey;
+ALTER INDEX history_stock_kbar_stock_num_index RENAME TO history_stock_kbar_unpartitioned_stock_num_index;
+
+CREATE TABLE
+    history_stock_kbar (
+        "id" SERIAL,
+        "stock_num" VARCHAR NOT NULL,
+        "kbar_time" TIMESTAMPTZ NOT NULL,
+        "open" DECIMAL NOT NULL,
+        "high" DECIMAL NOT NULL,
+        "low" DECIMAL NOT NULL,
+        "close" DECIMAL NOT NULL,
+        "volume" INT NOT NULL,
+        PRIMARY KEY ("id", "kbar_time")
+    ) PARTITION BY RANGE ("kbar_time");
+
+CREATE INDEX history_stock_kbar_stock_num_index ON history_stock_kbar USING btree ("stock_num", "kbar_time");
+
+ALTER TABLE history_stock_kbar ADD CONSTRAINT "fk_history_stock_kbar_stock" FOREIGN KEY ("stock_num") REFERENCES basic_stock ("number");
+
+SELECT pg_temp.create_monthly_partitions('history_stock_kbar', (SELECT min("kbar_time") FROM history_stock_kbar_unpartitioned), (SELECT max("kbar_time") FROM history_stock_kbar_unpartitioned));
+
+INSERT INTO history_stock_kbar ("id", "stock_num", "kbar_time", "open", "high", "low", "close", "volume")
+SELECT "id", "stock_num", "kbar_time", "open", "high", "low", "close", "volume" FROM history_stock_kbar_unpartitioned;
+
+SELECT setval(pg_get_serial_sequence('history_stock_kbar', 'id'), COALESCE((SELECT max("id") FROM history_stock_kbar), 0) + 1, false);
+
+DROP TABLE history_stock_kbar_unpartitioned;
+
+ALTER TABLE history_stock_tick RENAME TO history_stock_tick_unpartitioned;
+ALTER SEQUENCE history_stock_tick_id_seq RENAME TO history_stock_tick_unpartitioned_id_seq;
+ALTER INDEX history_stock_tick_pkey RENAME TO history_stock_tick_unpartitioned_pkey;
+ALTER INDEX history_stock_tick_stock_num_index RENAME TO history_stock_tick_unpartitioned_stock_num_index;
+
+CREATE TABLE
+    history_stock_tick (
+        "id" SERIAL,
+        "stock_num" VARCHAR NOT NULL,
+        "tick_time" TIMESTAMPTZ NOT NULL,
+        "close" DECIMAL NOT NULL,
+        "tick_type" INT NOT NULL,
+        "volume" INT NOT NULL,
+        "bid_price" DECIMAL NOT NULL,
+        "bid_volume" INT NOT NULL,
+        "ask_price" DECIMAL NOT NULL,
+        "ask_volume" INT NOT NULL,
+        PRIMARY KEY ("id", "tick_time")
+    ) PARTITION BY RANGE ("tick_time");
+
+CREATE INDEX history_stock_tick_stock_num_index ON history_stock_tick USING btree ("stock_num", "tick_time");
+
+ALTER TABLE history_stock_tick ADD CONSTRAINT "fk_history_stock_tick_stock" FOREIGN KEY ("stock_num") REFERENCES basic_stock ("number");
+
+SELECT pg_temp.create_monthly_partitions('history_stock_tick', (SELECT min("tick_time") FROM history_stock_tick_unpartitioned), (SELECT max("tick_time") FROM history_stock_tick_unpartitioned));
+
+INSERT INTO history_stock_tick ("id", "stock_num", "tick_time", "close", "tick_type", "volume", "bid_price", "bid_volume", "ask_price", "ask_volume")
+SELECT "id", "stock_num", "tick_time", "close", "tick_type", "volume", "bid_price", "bid_volume", "ask_price", "ask_volume" FROM history_stock_tick_unpartitioned;
+
+SELECT setval(pg_get_serial_sequence('history_stock_tick', 'id'), COALESCE((SELECT max("id") FROM history_stock_tick), 0) + 1, false);
+
+DROP TABLE history_stock_tick_unpartitioned;
+
+COMMIT;
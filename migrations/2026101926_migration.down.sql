@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE system_password_reset DROP COLUMN "attempts";
+
+COMMIT;
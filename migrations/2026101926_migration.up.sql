@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE system_password_reset ADD COLUMN "attempts" INT NOT NULL DEFAULT 0;
+
+COMMIT;
@@ -0,0 +1,15 @@
+BEGIN;
+
+CREATE TABLE
+    trade_future_roll (
+        "id" SERIAL PRIMARY KEY,
+        "code" VARCHAR NOT NULL UNIQUE,
+        "roll_to" VARCHAR NOT NULL,
+        "direction" VARCHAR NOT NULL,
+        "position" INT NOT NULL,
+        "created" TIMESTAMPTZ NOT NULL
+    );
+
+ALTER TABLE trade_future_roll ADD CONSTRAINT "fk_trade_future_roll_future" FOREIGN KEY ("code") REFERENCES basic_future ("code");
+
+COMMIT;
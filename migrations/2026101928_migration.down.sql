@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP TABLE IF EXISTS history_future_kbar_day;
+
+ALTER TABLE history_future_kbar DROP CONSTRAINT IF EXISTS "history_future_kbar_code_kbar_time_key";
+
+COMMIT;
@@ -0,0 +1,22 @@
+BEGIN;
+
+-- concurrent fetches of the same day may have stored duplicates, keep the first
+DELETE FROM history_future_kbar a USING history_future_kbar b
+WHERE
+    a."code" = b."code"
+    AND a."kbar_time" = b."kbar_time"
+    AND a."id" > b."id";
+
+ALTER TABLE history_future_kbar ADD CONSTRAINT "history_future_kbar_code_kbar_time_key" UNIQUE ("code", "kbar_time");
+
+CREATE TABLE
+    history_future_kbar_day (
+        "id" SERIAL PRIMARY KEY,
+        "code" VARCHAR NOT NULL,
+        "trade_day" TIMESTAMPTZ NOT NULL,
+        UNIQUE ("code", "trade_day")
+    );
+
+ALTER TABLE history_future_kbar_day ADD CONSTRAINT "fk_history_future_kbar_day_future" FOREIGN KEY ("code") REFERENCES basic_future ("code");
+
+COMMIT;
@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/patrickmn/go-cache"
 )
@@ -53,6 +54,12 @@ func (c *Cache) Set(k string, x interface{}) {
 	c.getCacher(category).Set(k, x, 0)
 }
 
+// SetWithExpiration removes the item after d.
+func (c *Cache) SetWithExpiration(k string, x interface{}, d time.Duration) {
+	category, k := c.splitKey(k)
+	c.getCacher(category).Set(k, x, d)
+}
+
 func (c *Cache) Get(k string) (interface{}, bool) {
 	category, k := c.splitKey(k)
 	return c.getCacher(category).Get(k)
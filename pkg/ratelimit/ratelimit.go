@@ -0,0 +1,177 @@
+// Package ratelimit package ratelimit implements keyed token buckets with failure lockout
+package ratelimit
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Config -.
+type Config struct {
+	// Rate is tokens refilled per second
+	Rate float64
+	// Burst is the bucket size
+	Burst int
+	// MaxFailures in FailureWindow locks the key for Lockout, 0 disables lockout
+	MaxFailures   int
+	FailureWindow time.Duration
+	Lockout       time.Duration
+}
+
+// State is the snapshot of a key.
+type State struct {
+	Key         string     `json:"key"`
+	Tokens      float64    `json:"tokens"`
+	Failures    int        `json:"failures"`
+	Rejected    int64      `json:"rejected"`
+	LockedUntil *time.Time `json:"locked_until,omitempty"`
+	LastSeen    time.Time  `json:"last_seen"`
+}
+
+type entry struct {
+	tokens      float64
+	last        time.Time
+	failures    []time.Time
+	lockedUntil time.Time
+	rejected    int64
+}
+
+// Limiter -.
+type Limiter struct {
+	cfg     Config
+	entries map[string]*entry
+	lock    sync.Mutex
+	now     func() time.Time
+}
+
+// New -.
+func New(cfg Config) *Limiter {
+	return &Limiter{
+		cfg:     cfg,
+		entries: make(map[string]*entry),
+		now:     time.Now,
+	}
+}
+
+func (l *Limiter) get(key string, now time.Time) *entry {
+	e, ok := l.entries[key]
+	if !ok {
+		e = &entry{tokens: float64(l.cfg.Burst), last: now}
+		l.entries[key] = e
+	}
+	return e
+}
+
+// Allow takes a token of key, returns false and how long to wait if the key is locked or out of tokens.
+func (l *Limiter) Allow(key string) (bool, time.Duration) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := l.now()
+	e := l.get(key, now)
+
+	if now.Before(e.lockedUntil) {
+		e.rejected++
+		return false, e.lockedUntil.Sub(now)
+	}
+
+	e.tokens += now.Sub(e.last).Seconds() * l.cfg.Rate
+	if e.tokens > float64(l.cfg.Burst) {
+		e.tokens = float64(l.cfg.Burst)
+	}
+	e.last = now
+
+	if e.tokens < 1 {
+		e.rejected++
+		if l.cfg.Rate <= 0 {
+			return false, l.cfg.FailureWindow
+		}
+		return false, time.Duration((1 - e.tokens) / l.cfg.Rate * float64(time.Second))
+	}
+	e.tokens--
+	return true, 0
+}
+
+// Fail records a failure of key, returns true if the key is locked by this failure.
+func (l *Limiter) Fail(key string) bool {
+	if l.cfg.MaxFailures <= 0 {
+		return false
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := l.now()
+	e := l.get(key, now)
+	e.failures = append(e.failures, now)
+
+	valid := e.failures[:0]
+	for _, v := range e.failures {
+		if now.Sub(v) < l.cfg.FailureWindow {
+			valid = append(valid, v)
+		}
+	}
+	e.failures = valid
+
+	if len(e.failures) < l.cfg.MaxFailures {
+		return false
+	}
+	e.failures = nil
+	e.lockedUntil = now.Add(l.cfg.Lockout)
+	return true
+}
+
+// Reset clears failures and lockout of key.
+func (l *Limiter) Reset(key string) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if e, ok := l.entries[key]; ok {
+		e.failures = nil
+		e.lockedUntil = time.Time{}
+	}
+}
+
+// Cleanup removes keys idle longer than idle and not locked.
+func (l *Limiter) Cleanup(idle time.Duration) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := l.now()
+	for k, e := range l.entries {
+		if now.Sub(e.last) > idle && now.After(e.lockedUntil) {
+			delete(l.entries, k)
+		}
+	}
+}
+
+// Snapshot returns states of all keys, most rejected first.
+func (l *Limiter) Snapshot() []State {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := l.now()
+	result := make([]State, 0, len(l.entries))
+	for k, e := range l.entries {
+		s := State{
+			Key:      k,
+			Tokens:   e.tokens,
+			Failures: len(e.failures),
+			Rejected: e.rejected,
+			LastSeen: e.last,
+		}
+		if now.Before(e.lockedUntil) {
+			lockedUntil := e.lockedUntil
+			s.LockedUntil = &lockedUntil
+		}
+		result = append(result, s)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Rejected != result[j].Rejected {
+			return result[i].Rejected > result[j].Rejected
+		}
+		return result[i].Key < result[j].Key
+	})
+	return result
+}
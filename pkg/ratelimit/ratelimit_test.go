@@ -0,0 +1,178 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+type clock struct {
+	t time.Time
+}
+
+func (c *clock) now() time.Time {
+	return c.t
+}
+
+func (c *clock) add(d time.Duration) {
+	c.t = c.t.Add(d)
+}
+
+func newTestLimiter(cfg Config) (*Limiter, *clock) {
+	c := &clock{t: time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)}
+	l := New(cfg)
+	l.now = c.now
+	return l, c
+}
+
+func TestAllow(t *testing.T) {
+	type step struct {
+		after    time.Duration
+		want     bool
+		wantWait time.Duration
+	}
+	tests := []struct {
+		name  string
+		cfg   Config
+		steps []step
+	}{
+		{
+			name:  "burst then reject",
+			cfg:   Config{Rate: 1, Burst: 3},
+			steps: []step{{0, true, 0}, {0, true, 0}, {0, true, 0}, {0, false, time.Second}},
+		},
+		{
+			name:  "refill one token",
+			cfg:   Config{Rate: 2, Burst: 1},
+			steps: []step{{0, true, 0}, {0, false, 500 * time.Millisecond}, {500 * time.Millisecond, true, 0}, {0, false, 500 * time.Millisecond}},
+		},
+		{
+			name:  "partial refill waits the rest",
+			cfg:   Config{Rate: 1, Burst: 1},
+			steps: []step{{0, true, 0}, {250 * time.Millisecond, false, 750 * time.Millisecond}},
+		},
+		{
+			name:  "refill is capped by burst",
+			cfg:   Config{Rate: 10, Burst: 2},
+			steps: []step{{0, true, 0}, {0, true, 0}, {time.Hour, true, 0}, {0, true, 0}, {0, false, 100 * time.Millisecond}},
+		},
+		{
+			name:  "no rate waits failure window",
+			cfg:   Config{Rate: 0, Burst: 1, FailureWindow: time.Minute},
+			steps: []step{{0, true, 0}, {time.Hour, false, time.Minute}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, c := newTestLimiter(tt.cfg)
+			for i, s := range tt.steps {
+				c.add(s.after)
+				ok, wait := l.Allow("key")
+				if ok != s.want || wait != s.wantWait {
+					t.Fatalf("step %d: Allow() = %v, %v, want %v, %v", i, ok, wait, s.want, s.wantWait)
+				}
+			}
+		})
+	}
+}
+
+func TestAllowKeysAreIndependent(t *testing.T) {
+	l, _ := newTestLimiter(Config{Rate: 1, Burst: 1})
+	if ok, _ := l.Allow("a"); !ok {
+		t.Fatal("Allow(a) rejected the first request")
+	}
+	if ok, _ := l.Allow("a"); ok {
+		t.Fatal("Allow(a) accepted over burst")
+	}
+	if ok, _ := l.Allow("b"); !ok {
+		t.Error("Allow(b) rejected by the bucket of a")
+	}
+}
+
+func TestFailLockout(t *testing.T) {
+	cfg := Config{Rate: 100, Burst: 100, MaxFailures: 3, FailureWindow: time.Minute, Lockout: 10 * time.Minute}
+	tests := []struct {
+		name       string
+		failAfter  []time.Duration
+		wantLocked bool
+	}{
+		{"below max", []time.Duration{0, 0}, false},
+		{"max in window", []time.Duration{0, 20 * time.Second, 20 * time.Second}, true},
+		{"old failure out of window", []time.Duration{0, 40 * time.Second, 40 * time.Second}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, c := newTestLimiter(cfg)
+			var locked bool
+			for _, v := range tt.failAfter {
+				c.add(v)
+				locked = l.Fail("key")
+			}
+			if locked != tt.wantLocked {
+				t.Fatalf("Fail() = %v, want %v", locked, tt.wantLocked)
+			}
+			if ok, _ := l.Allow("key"); ok == tt.wantLocked {
+				t.Errorf("Allow() = %v after locked %v", ok, locked)
+			}
+		})
+	}
+}
+
+func TestLockoutExpiry(t *testing.T) {
+	l, c := newTestLimiter(Config{Rate: 1, Burst: 1, MaxFailures: 1, FailureWindow: time.Minute, Lockout: 10 * time.Minute})
+	if !l.Fail("key") {
+		t.Fatal("Fail() did not lock")
+	}
+
+	c.add(4 * time.Minute)
+	if ok, wait := l.Allow("key"); ok || wait != 6*time.Minute {
+		t.Fatalf("Allow() = %v, %v while locked, want false, 6m", ok, wait)
+	}
+	if s := l.Snapshot(); len(s) != 1 || s[0].LockedUntil == nil || s[0].Rejected != 1 {
+		t.Fatalf("Snapshot() = %+v, want locked with 1 rejected", s)
+	}
+
+	c.add(6 * time.Minute)
+	if ok, _ := l.Allow("key"); !ok {
+		t.Fatal("Allow() rejected after lockout expired")
+	}
+	if s := l.Snapshot(); s[0].LockedUntil != nil {
+		t.Errorf("Snapshot() locked until %v after expiry", s[0].LockedUntil)
+	}
+}
+
+func TestReset(t *testing.T) {
+	l, _ := newTestLimiter(Config{Rate: 1, Burst: 1, MaxFailures: 2, FailureWindow: time.Minute, Lockout: time.Hour})
+	l.Fail("key")
+	l.Reset("key")
+	if l.Fail("key") {
+		t.Fatal("Fail() locked by a failure cleared by Reset")
+	}
+
+	if !l.Fail("key") {
+		t.Fatal("Fail() did not lock")
+	}
+	l.Reset("key")
+	if ok, _ := l.Allow("key"); !ok {
+		t.Error("Allow() rejected after Reset")
+	}
+	l.Reset("unknown")
+}
+
+func TestCleanup(t *testing.T) {
+	l, c := newTestLimiter(Config{Rate: 1, Burst: 1, MaxFailures: 1, FailureWindow: time.Minute, Lockout: time.Hour})
+	l.Allow("idle")
+	l.Allow("locked")
+	l.Fail("locked")
+
+	c.add(30 * time.Minute)
+	l.Allow("active")
+	l.Cleanup(10 * time.Minute)
+
+	got := map[string]bool{}
+	for _, v := range l.Snapshot() {
+		got[v.Key] = true
+	}
+	if got["idle"] || !got["locked"] || !got["active"] {
+		t.Errorf("Cleanup() kept %v, want locked and active", got)
+	}
+}
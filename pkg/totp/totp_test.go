@@ -0,0 +1,96 @@
+package totp
+
+import (
+	"testing"
+	"time"
+)
+
+// rfcSecret is the sha1 seed of RFC 6238 appendix B
+var rfcSecret = b32.EncodeToString([]byte("12345678901234567890"))
+
+func TestCode(t *testing.T) {
+	// RFC 6238 appendix B sha1 vectors, the last 6 digits as Digits is 6
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+	for _, tt := range tests {
+		t.Run(time.Unix(tt.unix, 0).UTC().Format(time.RFC3339), func(t *testing.T) {
+			got, err := Code(rfcSecret, Step(time.Unix(tt.unix, 0)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Code() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	code, err := Code(rfcSecret, Step(now))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		code   string
+		at     time.Time
+		wantOK bool
+	}{
+		{"current step", code, now, true},
+		{"previous step", code, now.Add(Period * time.Second), true},
+		{"next step", code, now.Add(-Period * time.Second), true},
+		{"two steps later", code, now.Add(2 * Period * time.Second), false},
+		{"two steps earlier", code, now.Add(-2 * Period * time.Second), false},
+		{"wrong code", "000000", now, false},
+		{"wrong length", code[:Digits-1], now, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := Validate(rfcSecret, tt.code, tt.at, 1)
+			if ok != tt.wantOK {
+				t.Fatalf("Validate() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && step != Step(now) {
+				t.Errorf("Validate() step = %d, want %d", step, Step(now))
+			}
+		})
+	}
+}
+
+func TestValidateOnce(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	code, err := Code(rfcSecret, Step(now))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	step, ok := ValidateOnce(rfcSecret, code, now, 1, 0)
+	if !ok {
+		t.Fatal("ValidateOnce() rejected an unused code")
+	}
+	if _, ok = ValidateOnce(rfcSecret, code, now, 1, step); ok {
+		t.Error("ValidateOnce() accepted the used code again")
+	}
+	if _, ok = ValidateOnce(rfcSecret, code, now.Add(Period*time.Second), 1, step); ok {
+		t.Error("ValidateOnce() accepted the used code in the next step")
+	}
+
+	next, err := Code(rfcSecret, step+1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok = ValidateOnce(rfcSecret, next, now.Add(Period*time.Second), 1, step); !ok {
+		t.Error("ValidateOnce() rejected the code of the next step")
+	}
+}